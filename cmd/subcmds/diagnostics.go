@@ -0,0 +1,72 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package subcmds
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
+)
+
+const (
+	diagnosticsFormatFlag = "diagnostics-format"
+	textDiagnosticsFormat = "text"
+	jsonDiagnosticsFormat = "json"
+)
+
+var diagnosticsFormats = []string{textDiagnosticsFormat, jsonDiagnosticsFormat}
+
+// reporter is a verification, diff or query report
+type reporter interface {
+	Summary() string
+}
+
+// printDiagnostics prints the diagnostics of synthesis and optimization. In text format, warnings and errors are printed
+// to the output and notices to stderr; in json format, all diagnostics are printed to the output
+func printDiagnostics(cmd *cobra.Command, args *inArgs, diagnostics ir.Diagnostics) error {
+	if args.diagnosticsFormat == jsonDiagnosticsFormat {
+		return printJSONDiagnostics(cmd, diagnostics)
+	}
+	printTextDiagnostics(cmd.ErrOrStderr(), diagnostics.Filter(ir.SeverityInfo))
+	cmd.Print(diagnostics.Filter(ir.SeverityError, ir.SeverityWarning).String())
+	return nil
+}
+
+// printReportDiagnostics prints the summary of a report to the output, and the diagnostics of reading the configs to
+// stderr. In json format, the diagnostics, with those of the report itself (if it has any), replace the summary
+func printReportDiagnostics(cmd *cobra.Command, args *inArgs, diagnostics ir.Diagnostics, report reporter) error {
+	if args.diagnosticsFormat == jsonDiagnosticsFormat {
+		if r, ok := report.(interface{ Diagnostics() ir.Diagnostics }); ok {
+			diagnostics = append(diagnostics, r.Diagnostics()...)
+		}
+		return printJSONDiagnostics(cmd, diagnostics)
+	}
+	printTextDiagnostics(cmd.ErrOrStderr(), diagnostics)
+	cmd.Print(report.Summary())
+	return nil
+}
+
+func printTextDiagnostics(w io.Writer, diagnostics ir.Diagnostics) {
+	for _, d := range diagnostics {
+		fmt.Fprintf(w, "%s: %s\n", d.Severity, d)
+	}
+}
+
+func printJSONDiagnostics(cmd *cobra.Command, diagnostics ir.Diagnostics) error {
+	if diagnostics == nil {
+		diagnostics = ir.Diagnostics{} // printed as an empty list rather than null
+	}
+	data, err := json.MarshalIndent(diagnostics, "", "  ")
+	if err != nil {
+		return err
+	}
+	cmd.Print(string(data))
+	return nil
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package subcmds
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/diff"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
+)
+
+const otherConfigFlag = "other-config"
+
+func newDiffCommand(args *inArgs) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "compare the connectivity allowed by two configurations",
+		Long: `Report the connections allowed by the SGs/nACLs of one configuration and not by the other, per SG target/subnet and per direction.
+		--config and --other-config parameters must be supplied.`,
+	}
+
+	// flags
+	cmd.PersistentFlags().StringVar(&args.otherConfigFile, otherConfigFlag, "",
+		"JSON file containing the configuration object to compare with")
+
+	// flags settings
+	_ = cmd.MarkPersistentFlagRequired(otherConfigFlag)
+
+	// subcmds
+	cmd.AddCommand(newDiffACLCommand(args))
+	cmd.AddCommand(newDiffSGCommand(args))
+
+	return cmd
+}
+
+func difference(cmd *cobra.Command, args *inArgs,
+	newDiffer func(*ir.ConfigDefs, ir.Collection, *ir.ConfigDefs, ir.Collection) diff.Differ, isSG bool) error {
+	cmd.SilenceUsage = true // if we got this far, flags are syntactically correct, so no need to print usage
+	firstDefs, first, firstDiagnostics, err := parseConfig(args.configFile, isSG)
+	if err != nil {
+		return err
+	}
+	secondDefs, second, secondDiagnostics, err := parseConfig(args.otherConfigFile, isSG)
+	if err != nil {
+		return err
+	}
+	report := newDiffer(firstDefs, first, secondDefs, second).Diff()
+	if err := printReportDiagnostics(cmd, args, append(firstDiagnostics, secondDiagnostics...), report); err != nil {
+		return err
+	}
+	return writeToFile(args.outputFile, bytes.NewBufferString(report.Text(args.configFile, args.otherConfigFile)))
+}
+
+// parseConfig reads the definitions and the SGs/nACLs of a config
+func parseConfig(configFile string, isSG bool) (*ir.ConfigDefs, ir.Collection, ir.Diagnostics, error) {
+	defs, err := readDefs(configFile)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("could not parse config file %v: %w", configFile, err)
+	}
+	collection, diagnostics, err := parseCollection(configFile, isSG)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("could not parse config file %v: %w", configFile, err)
+	}
+	return defs, collection, diagnostics, nil
+}
@@ -0,0 +1,26 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package subcmds
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/diff"
+)
+
+func newDiffACLCommand(args *inArgs) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "acl",
+		Short: "Compare the connectivity allowed by the Network ACLs of two configurations",
+		Long: `Compare the connectivity allowed by nACLs with the same name in the same VPC.
+		An nACL that exists in only one of the configurations is compared to an nACL that allows nothing.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return difference(cmd, args, diff.NewACLDiffer, false)
+		},
+	}
+	return cmd
+}
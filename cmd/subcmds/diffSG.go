@@ -0,0 +1,27 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package subcmds
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/diff"
+)
+
+func newDiffSGCommand(args *inArgs) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sg",
+		Short: "Compare the connectivity allowed by the Security Groups of two configurations",
+		Long: `Compare the connectivity allowed by SGs with the same name in the same VPC.
+		An SG that exists in only one of the configurations is compared to an SG that allows nothing.
+		Remote SGs are compared by name.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return difference(cmd, args, diff.NewSGDiffer, true)
+		},
+	}
+	return cmd
+}
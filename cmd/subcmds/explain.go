@@ -0,0 +1,63 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package subcmds
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/explain"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/synth"
+)
+
+func newExplainCommand(args *inArgs) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "explain",
+		Short: "map each synthesized SG/nACL rule to the spec connections it serves",
+		Long: `Synthesize SGs or nACLs, and report the required-connections and allowed-protocols indices that each rule serves,
+		as JSON or HTML (by the --format flag or the output file extension; default: JSON).
+		--config and --spec parameters must be supplied.`,
+	}
+
+	addSynthFlags(cmd, args)
+
+	// subcmds
+	cmd.AddCommand(newExplainACLCommand(args))
+	cmd.AddCommand(newExplainSGCommand(args))
+
+	return cmd
+}
+
+func explanation(cmd *cobra.Command, args *inArgs, newSynthesizer func(*ir.Spec, *synth.Options) synth.Synthesizer, isSG bool) error {
+	format := jsonOutputFormat
+	if args.outputFmt == htmlOutputFormat || cmd.Flags().Changed(outputFmtFlag) {
+		format = args.outputFmt
+	}
+	if format != jsonOutputFormat && format != htmlOutputFormat {
+		return fmt.Errorf("bad output format for explain: %q; %s", format, mustBeOneOf([]string{jsonOutputFormat, htmlOutputFormat}))
+	}
+	collection, _, err := synthesize(cmd, args, newSynthesizer, isSG)
+	if err != nil {
+		return err
+	}
+	var report *explain.Report
+	if isSG {
+		report = explain.SGReport(collection.(*ir.SGCollection), "")
+	} else {
+		report = explain.ACLReport(collection.(*ir.ACLCollection), "")
+	}
+	data, err := report.JSON()
+	if format == htmlOutputFormat {
+		data, err = report.HTML()
+	}
+	if err != nil {
+		return err
+	}
+	return writeToFile(args.outputFile, bytes.NewBuffer(data))
+}
@@ -0,0 +1,32 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package subcmds
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/synth"
+)
+
+func newExplainACLCommand(args *inArgs) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "acl",
+		Short: "Map each synthesized Network ACL rule to the spec connections it serves",
+		Long: `Synthesize Network ACLs from the connectivity specification, and report the spec connections and protocols
+		that each rule serves, including the rules that allow their responses.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return explanation(cmd, args, synth.NewACLSynthesizer, false)
+		},
+	}
+
+	cmd.Flags().BoolVar(&args.singleacl, singleACLFlag, false, "whether to generate a single acl")
+	addInternalCidrsFlag(cmd, args)
+	addACLStrategyFlag(cmd, args)
+	addResponsesFlags(cmd, args)
+
+	return cmd
+}
@@ -0,0 +1,26 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package subcmds
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/synth"
+)
+
+func newExplainSGCommand(args *inArgs) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sg",
+		Short: "Map each synthesized Security Group rule to the spec connections it serves",
+		Long: `Synthesize Security Groups from the connectivity specification, and report the spec connections and protocols
+		that each rule serves.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return explanation(cmd, args, synth.NewSGSynthesizer, true)
+		},
+	}
+	return cmd
+}
@@ -14,6 +14,8 @@ import (
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/optimize"
 )
 
+const inPlaceFlag = "in-place"
+
 func newOptimizeCommand(args *inArgs) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "optimize",
@@ -21,6 +23,11 @@ func newOptimizeCommand(args *inArgs) *cobra.Command {
 		Long:  `optimization of existing SGs and nACLs`,
 	}
 
+	// flags
+	cmd.PersistentFlags().BoolVar(&args.inPlace, inPlaceFlag, false,
+		"whether to generate import and moved blocks, so that applying the optimized resources updates the existing ones in place"+
+			" (only possible when the output format is tf)")
+
 	// sub cmds
 	cmd.AddCommand(newOptimizeSGCommand(args))
 	cmd.AddCommand(newOptimizeACLCommand(args))
@@ -30,12 +37,15 @@ func newOptimizeCommand(args *inArgs) *cobra.Command {
 
 func optimization(cmd *cobra.Command, args *inArgs, newOptimizer func(ir.Collection, string) optimize.Optimizer, isSG bool) error {
 	cmd.SilenceUsage = true // if we got this far, flags are syntactically correct, so no need to print usage
-	collection, err := parseCollection(args, isSG)
+	collection, readDiagnostics, err := parseCollection(args.configFile, isSG)
 	if err != nil {
 		return fmt.Errorf("could not parse config file %v: %w", args.configFile, err)
 	}
 	optimizer := newOptimizer(collection, args.firewallName)
-	optimizedCollection, err := optimizer.Optimize()
+	optimizedCollection, diagnostics, err := optimizer.Optimize()
+	if printErr := printDiagnostics(cmd, args, append(readDiagnostics, diagnostics...)); printErr != nil {
+		return printErr
+	}
 	if err != nil {
 		return err
 	}
@@ -15,6 +15,7 @@ import (
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/io"
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/io/confio"
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/io/tfio"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/io/tfstateio"
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
 )
 
@@ -60,7 +61,7 @@ func writeOutput(args *inArgs, collection ir.Collection, vpcNames []string, isSy
 
 func writeCollection(args *inArgs, collection ir.Collection, vpc string, isSynth bool) (*bytes.Buffer, error) {
 	var data bytes.Buffer
-	writer, err := pickWriter(args, &data)
+	writer, err := pickWriter(args, &data, collection)
 	if err != nil {
 		return nil, err
 	}
@@ -70,21 +71,49 @@ func writeCollection(args *inArgs, collection ir.Collection, vpc string, isSynth
 	return &data, nil
 }
 
-func pickWriter(args *inArgs, data *bytes.Buffer) (ir.Writer, error) {
+func pickWriter(args *inArgs, data *bytes.Buffer, collection ir.Collection) (ir.Writer, error) {
 	w := bufio.NewWriter(data)
 	switch args.outputFmt {
 	case tfOutputFormat:
-		return tfio.NewWriter(w), nil
+		return tfWriter(args, w, collection)
 	case csvOutputFormat:
 		return io.NewCSVWriter(w), nil
 	case mdOutputFormat:
 		return io.NewMDWriter(w), nil
 	case jsonOutputFormat:
+		if tfstateio.IsState(args.configFile) {
+			return nil, fmt.Errorf("the %s output format requires a config object file, not a terraform state", jsonOutputFormat)
+		}
 		return confio.NewWriter(w, args.configFile)
 	}
 	return nil, fmt.Errorf("bad output format: %q", args.outputFmt)
 }
 
+// tfWriter returns a tf writer that attaches the resources of the collection to those of the config (if args.attach),
+// and updates the resources of the config in place (if args.inPlace); the optimizers change the collection they
+// optimize, so the original collection is read again
+func tfWriter(args *inArgs, w *bufio.Writer, collection ir.Collection) (ir.Writer, error) {
+	var defs *ir.ConfigDefs
+	if args.attach {
+		var err error
+		if defs, err = readDefs(args.configFile); err != nil {
+			return nil, err
+		}
+	}
+	if !args.inPlace {
+		if defs != nil {
+			return tfio.NewWriterWithAttachments(w, defs), nil
+		}
+		return tfio.NewWriter(w), nil
+	}
+	_, isSG := collection.(*ir.SGCollection)
+	original, _, err := parseCollection(args.configFile, isSG)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse config file %v: %w", args.configFile, err)
+	}
+	return tfio.NewWriterWithImports(w, defs, original), nil
+}
+
 func writeToFile(outputFile string, data *bytes.Buffer) error {
 	if outputFile == "" {
 		fmt.Println(data.String())
@@ -97,12 +126,8 @@ func writeLocals(args *inArgs, vpcNames []ir.ID, collection ir.Collection) error
 	if !args.locals {
 		return nil
 	}
-	var data *bytes.Buffer
-	var err error
-
-	_, isACLCollection := collection.(*ir.ACLCollection)
-
-	if data, err = tfio.WriteLocals(vpcNames, isACLCollection); err != nil {
+	data, err := locals(vpcNames, collection)
+	if err != nil {
 		return err
 	}
 
@@ -115,3 +140,22 @@ func writeLocals(args *inArgs, vpcNames []ir.ID, collection ir.Collection) error
 	}
 	return writeToFile(outputFile, data)
 }
+
+// locals returns the locals of the nACLs or the SGs of the collection, or of both if it is layered
+func locals(vpcNames []ir.ID, collection ir.Collection) (*bytes.Buffer, error) {
+	if _, isLayered := collection.(*ir.LayeredCollection); isLayered {
+		data, err := tfio.WriteLocals(vpcNames, true)
+		if err != nil {
+			return nil, err
+		}
+		sgData, err := tfio.WriteLocals(vpcNames, false)
+		if err != nil {
+			return nil, err
+		}
+		data.WriteString("\n")
+		_, err = data.ReadFrom(sgData)
+		return data, err
+	}
+	_, isACLCollection := collection.(*ir.ACLCollection)
+	return tfio.WriteLocals(vpcNames, isACLCollection)
+}
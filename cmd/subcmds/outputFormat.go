@@ -15,6 +15,8 @@ const (
 	csvOutputFormat     = "csv"
 	mdOutputFormat      = "md"
 	jsonOutputFormat    = "json"
+	txtOutputFormat     = "txt"  // verification, diff and query reports
+	htmlOutputFormat    = "html" // explanation reports
 	defaultOutputFormat = csvOutputFormat
 )
 
@@ -41,6 +43,10 @@ func inferFormatUsingFilename(filename string) (string, error) {
 		return mdOutputFormat, nil
 	case strings.HasSuffix(filename, ".json"):
 		return jsonOutputFormat, nil
+	case strings.HasSuffix(filename, ".txt"):
+		return txtOutputFormat, nil
+	case strings.HasSuffix(filename, ".html"):
+		return htmlOutputFormat, nil
 	default:
 		return "", fmt.Errorf("bad output format")
 	}
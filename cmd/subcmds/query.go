@@ -0,0 +1,147 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package subcmds
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/np-guard/models/pkg/netp"
+
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/io/confio"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ipset"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/optimize"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/query"
+)
+
+const (
+	srcFlag      = "src"
+	dstFlag      = "dst"
+	protocolFlag = "protocol"
+	srcPortFlag  = "src-port"
+	dstPortFlag  = "dst-port"
+	icmpTypeFlag = "icmp-type"
+	icmpCodeFlag = "icmp-code"
+
+	anyProtocol = "any"
+	noValue     = -1
+)
+
+func newQueryCommand(args *inArgs) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "query",
+		Short: "check whether existing SGs/nACLs allow a given flow",
+		Long: `Report whether the existing SGs/nACLs allow a flow between two IP addresses, and which rules decide it.
+		--config, --src and --dst parameters must be supplied.`,
+	}
+
+	// flags
+	cmd.PersistentFlags().StringVar(&args.src, srcFlag, "", "source IP address of the flow")
+	cmd.PersistentFlags().StringVar(&args.dst, dstFlag, "", "destination IP address of the flow")
+	cmd.PersistentFlags().StringVar(&args.protocol, protocolFlag, anyProtocol,
+		"protocol of the flow; "+mustBeOneOf([]string{"tcp", "udp", "icmp", anyProtocol}))
+	cmd.PersistentFlags().IntVar(&args.srcPort, srcPortFlag, noValue, "source port of a tcp/udp flow; -1 for any port")
+	cmd.PersistentFlags().IntVar(&args.dstPort, dstPortFlag, noValue, "destination port of a tcp/udp flow; -1 for any port")
+	cmd.PersistentFlags().IntVar(&args.icmpType, icmpTypeFlag, noValue, "type of an icmp flow; -1 for any type")
+	cmd.PersistentFlags().IntVar(&args.icmpCode, icmpCodeFlag, noValue, "code of an icmp flow; -1 for any code")
+
+	// flags settings
+	_ = cmd.MarkPersistentFlagRequired(srcFlag)
+	_ = cmd.MarkPersistentFlagRequired(dstFlag)
+
+	// subcmds
+	cmd.AddCommand(newQueryACLCommand(args))
+	cmd.AddCommand(newQuerySGCommand(args))
+
+	return cmd
+}
+
+func queryFlow(cmd *cobra.Command, args *inArgs, newQuerier func(*ir.ConfigDefs, ir.Collection) query.Querier, isSG bool) error {
+	flow, err := parseFlow(args)
+	if err != nil {
+		return err
+	}
+	cmd.SilenceUsage = true // if we got this far, flags are syntactically correct, so no need to print usage
+	defs, err := confio.ReadDefs(args.configFile)
+	if err != nil {
+		return fmt.Errorf("could not parse config file %v: %w", args.configFile, err)
+	}
+	collection, diagnostics, err := parseCollection(args.configFile, isSG)
+	if err != nil {
+		return fmt.Errorf("could not parse config file %v: %w", args.configFile, err)
+	}
+	result := newQuerier(defs, collection).Query(flow)
+	if err := printReportDiagnostics(cmd, args, diagnostics, result); err != nil {
+		return err
+	}
+	return writeToFile(args.outputFile, bytes.NewBufferString(result.String()))
+}
+
+func parseFlow(args *inArgs) (*query.Flow, error) {
+	src, err := ipset.IPBlockFromIPAddress(args.src)
+	if err != nil {
+		return nil, fmt.Errorf("invalid source IP address %v: %w", args.src, err)
+	}
+	dst, err := ipset.IPBlockFromIPAddress(args.dst)
+	if err != nil {
+		return nil, fmt.Errorf("invalid destination IP address %v: %w", args.dst, err)
+	}
+	p, err := parseProtocol(args)
+	if err != nil {
+		return nil, err
+	}
+	return query.NewFlow(src, dst, optimize.ProtocolToTransportSet(p))
+}
+
+func parseProtocol(args *inArgs) (netp.Protocol, error) {
+	isICMP := strings.EqualFold(args.protocol, string(netp.ProtocolStringICMP))
+	if !isICMP && (args.icmpType != noValue || args.icmpCode != noValue) {
+		return nil, fmt.Errorf("--%s and --%s require an icmp flow", icmpTypeFlag, icmpCodeFlag)
+	}
+	if isICMP || strings.EqualFold(args.protocol, anyProtocol) {
+		if args.srcPort != noValue || args.dstPort != noValue {
+			return nil, fmt.Errorf("--%s and --%s require a tcp or udp flow", srcPortFlag, dstPortFlag)
+		}
+	}
+
+	switch {
+	case strings.EqualFold(args.protocol, anyProtocol):
+		return netp.AnyProtocol{}, nil
+	case isICMP:
+		return parseICMP(args)
+	case strings.EqualFold(args.protocol, string(netp.ProtocolStringTCP)), strings.EqualFold(args.protocol, string(netp.ProtocolStringUDP)):
+		minSrc, maxSrc := portRange(args.srcPort)
+		minDst, maxDst := portRange(args.dstPort)
+		return netp.NewTCPUDP(strings.EqualFold(args.protocol, string(netp.ProtocolStringTCP)), minSrc, maxSrc, minDst, maxDst)
+	default:
+		return nil, fmt.Errorf("bad protocol %v; %v", args.protocol, mustBeOneOf([]string{"tcp", "udp", "icmp", anyProtocol}))
+	}
+}
+
+func parseICMP(args *inArgs) (netp.Protocol, error) {
+	if args.icmpType == noValue {
+		if args.icmpCode != noValue {
+			return nil, fmt.Errorf("--%s requires --%s", icmpCodeFlag, icmpTypeFlag)
+		}
+		return netp.NewICMP(nil)
+	}
+	typeCode := &netp.ICMPTypeCode{Type: args.icmpType}
+	if args.icmpCode != noValue {
+		typeCode.Code = &args.icmpCode
+	}
+	return netp.NewICMP(typeCode)
+}
+
+func portRange(port int) (minPort, maxPort int) {
+	if port == noValue {
+		return netp.MinPort, netp.MaxPort
+	}
+	return port, port
+}
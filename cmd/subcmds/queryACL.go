@@ -0,0 +1,26 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package subcmds
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/query"
+)
+
+func newQueryACLCommand(args *inArgs) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "acl",
+		Short: "Check whether existing Network ACLs allow a given flow",
+		Long: `Check the flow against the outbound rules of the nACL of the source subnet and the inbound rules of the nACL of the
+		destination subnet, in first-match order, and report the deciding rules. Responses are not checked.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return queryFlow(cmd, args, query.NewACLQuerier, false)
+		},
+	}
+	return cmd
+}
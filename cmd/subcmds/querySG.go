@@ -0,0 +1,26 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package subcmds
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/query"
+)
+
+func newQuerySGCommand(args *inArgs) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sg",
+		Short: "Check whether existing Security Groups allow a given flow",
+		Long: `Check the flow against the outbound rules of the SGs attached to the source NIF/VPE and the inbound rules of the SGs
+		attached to the destination NIF/VPE, and report all the rules that allow it.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return queryFlow(cmd, args, query.NewSGQuerier, true)
+		},
+	}
+	return cmd
+}
@@ -17,20 +17,46 @@ const (
 	outputFmtFlag  = "format"
 	outputFileFlag = "output-file"
 	localsFlag     = "locals"
+	attachFlag     = "attach"
 	outputDirFlag  = "output-dir"
 	prefixFlag     = "prefix"
 )
 
 type inArgs struct {
-	configFile   string
-	specFile     string
-	outputFmt    string
-	outputFile   string
-	outputDir    string
-	prefix       string
-	firewallName string
-	singleacl    bool
-	locals       bool
+	configFile      string
+	otherConfigFile string
+	specFile        string
+	specFormat      string
+	outputFmt       string
+	outputFile      string
+	outputDir       string
+	prefix          string
+	firewallName    string
+	singleacl       bool
+	aclStrategy     string
+	tcpResponses    string
+	udpResponses    string
+	internalCidrs   []string
+	locals          bool
+	attach          bool
+	inPlace         bool
+	optimize        bool
+
+	diagnosticsFormat string
+
+	// synthesis quotas
+	maxACLRules     int
+	maxSGRules      int
+	maxSGsPerTarget int
+
+	// query flow
+	src      string
+	dst      string
+	protocol string
+	srcPort  int
+	dstPort  int
+	icmpType int
+	icmpCode int
 }
 
 func newRootCommand() *cobra.Command {
@@ -39,8 +65,9 @@ func newRootCommand() *cobra.Command {
 
 	rootCmd := &cobra.Command{
 		Use:   "vpcgen",
-		Short: "A tool for synthesizing and optimizing VPC network configurations",
-		Long:  `A tool for synthesizing and optimizing VPC network configurations, namely Network ACLs and Security Groups.`,
+		Short: "A tool for synthesizing, optimizing, verifying, comparing and querying VPC network configurations",
+		Long: `A tool for synthesizing, optimizing, verifying, comparing and querying VPC network configurations,
+		namely Network ACLs and Security Groups.`,
 		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
 			return validateFlags(args)
 		},
@@ -48,7 +75,7 @@ func newRootCommand() *cobra.Command {
 
 	// flags
 	rootCmd.PersistentFlags().StringVarP(&args.configFile, configFlag, "c", "",
-		"JSON file containing a configuration object of existing resources")
+		"JSON file containing a configuration object of existing resources, or the output of \"terraform show -json\"")
 	rootCmd.PersistentFlags().StringVarP(&args.outputFmt, outputFmtFlag, "f", "", "Output format; "+mustBeOneOf(outputFormats))
 	rootCmd.PersistentFlags().StringVarP(&args.outputFile, outputFileFlag, "o", "", "Write all generated resources to the specified file")
 	rootCmd.PersistentFlags().StringVarP(&args.outputDir, outputDirFlag, "d", "",
@@ -56,6 +83,10 @@ func newRootCommand() *cobra.Command {
 	rootCmd.PersistentFlags().StringVarP(&args.prefix, prefixFlag, "p", "", "The prefix of the files that will be created.")
 	rootCmd.PersistentFlags().BoolVarP(&args.locals, localsFlag, "l", false,
 		"whether to generate a locals.tf file (only possible when the output format is tf)")
+	rootCmd.PersistentFlags().BoolVar(&args.attach, attachFlag, false,
+		"whether to attach the generated nACLs to their subnets and the SGs to their targets (only possible when the output format is tf)")
+	rootCmd.PersistentFlags().StringVar(&args.diagnosticsFormat, diagnosticsFormatFlag, textDiagnosticsFormat,
+		"Format of warnings and other diagnostics; "+mustBeOneOf(diagnosticsFormats))
 
 	// flags set for all commands
 	rootCmd.PersistentFlags().SortFlags = false
@@ -64,6 +95,10 @@ func newRootCommand() *cobra.Command {
 	// sub cmds
 	rootCmd.AddCommand(newSynthCommand(args))
 	rootCmd.AddCommand(newOptimizeCommand(args))
+	rootCmd.AddCommand(newVerifyCommand(args))
+	rootCmd.AddCommand(newExplainCommand(args))
+	rootCmd.AddCommand(newDiffCommand(args))
+	rootCmd.AddCommand(newQueryCommand(args))
 
 	// prevent Cobra from creating a default 'completion' command
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
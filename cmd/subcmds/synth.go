@@ -8,42 +8,98 @@ package subcmds
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/io/jsonio"
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/synth"
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/utils"
 )
 
-const specFlag = "spec"
+const (
+	specFlag            = "spec"
+	specFormatFlag      = "spec-format"
+	optimizeFlag        = "optimize"
+	maxACLRulesFlag     = "max-acl-rules"
+	maxSGRulesFlag      = "max-sg-rules"
+	maxSGsPerTargetFlag = "max-sgs-per-target"
+)
 
 func newSynthCommand(args *inArgs) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "synth",
 		Short: "generate a SG/nACL collection",
-		Long: `Generate nACLS or Security Groups to only allow the specified connectivity.
+		Long: `Generate nACLS, Security Groups or both to only allow the specified connectivity.
 		--config and --spec parameters must be supplied.`,
 	}
 
-	// flags
-	cmd.PersistentFlags().StringVarP(&args.specFile, specFlag, "s", "", "JSON file containing spec file")
-
-	// flags settings
-	_ = cmd.MarkPersistentFlagRequired(specFlag)
+	addSynthFlags(cmd, args)
 
 	// subcmds
 	cmd.AddCommand(newSynthACLCommand(args))
 	cmd.AddCommand(newSynthSGCommand(args))
+	cmd.AddCommand(newSynthAllCommand(args))
 
 	return cmd
 }
 
-func synthesis(cmd *cobra.Command, args *inArgs, newSynthesizer func(*ir.Spec, bool) synth.Synthesizer, singleacl, isSG bool) error {
-	cmd.SilenceUsage = true // if we got this far, flags are syntactically correct, so no need to print usage
-	spec, err := unmarshal(args, isSG)
+// addSynthFlags adds the flags of the synthesis to a command and its subcommands
+func addSynthFlags(cmd *cobra.Command, args *inArgs) {
+	// flags
+	cmd.PersistentFlags().StringVarP(&args.specFile, specFlag, "s", "", "JSON or YAML file containing spec file")
+	cmd.PersistentFlags().StringVar(&args.specFormat, specFormatFlag, "",
+		"Spec file format; "+mustBeOneOf(jsonio.SpecFormats)+" (default: by the spec file extension)")
+	cmd.PersistentFlags().BoolVar(&args.optimize, optimizeFlag, false, "whether to optimize the generated rules")
+	cmd.PersistentFlags().IntVar(&args.maxACLRules, maxACLRulesFlag, synth.DefaultQuotas().ACLRules,
+		"maximal number of rules in an nACL")
+	cmd.PersistentFlags().IntVar(&args.maxSGRules, maxSGRulesFlag, synth.DefaultQuotas().SGRules,
+		"maximal number of rules in an SG; larger SGs are split into several SGs with the same targets")
+	cmd.PersistentFlags().IntVar(&args.maxSGsPerTarget, maxSGsPerTargetFlag, synth.DefaultQuotas().SGsPerTarget,
+		"maximal number of SGs attached to a single target")
+
+	// flags settings
+	_ = cmd.MarkPersistentFlagRequired(specFlag)
+}
+
+func synthesis(cmd *cobra.Command, args *inArgs, newSynthesizer func(*ir.Spec, *synth.Options) synth.Synthesizer, isSG bool) error {
+	collection, spec, err := synthesize(cmd, args, newSynthesizer, isSG)
 	if err != nil {
 		return err
 	}
-	synthesizer := newSynthesizer(spec, singleacl)
-	collection, warning := synthesizer.Synth()
-	cmd.Print(warning)
 	return writeOutput(args, collection, utils.MapKeys(spec.Defs.ConfigDefs.VPCs), true)
 }
+
+// synthesize returns the synthesized collection and the spec it was synthesized from, and prints the diagnostics
+func synthesize(cmd *cobra.Command, args *inArgs, newSynthesizer func(*ir.Spec, *synth.Options) synth.Synthesizer,
+	isSG bool) (ir.Collection, *ir.Spec, error) {
+	cmd.SilenceUsage = true // if we got this far, flags are syntactically correct, so no need to print usage
+	options, err := synthOptions(args)
+	if err != nil {
+		return nil, nil, err
+	}
+	spec, err := unmarshal(args, isSG)
+	if err != nil {
+		return nil, nil, err
+	}
+	collection, err := runSynthesizer(cmd, args, newSynthesizer(spec, options))
+	return collection, spec, err
+}
+
+func synthOptions(args *inArgs) (*synth.Options, error) {
+	quotas := &synth.Quotas{ACLRules: args.maxACLRules, SGRules: args.maxSGRules, SGsPerTarget: args.maxSGsPerTarget}
+	if err := quotas.Validate(); err != nil {
+		return nil, err
+	}
+	return &synth.Options{SingleACL: args.singleacl, ACLStrategy: synth.ACLStrategy(args.aclStrategy), Optimize: args.optimize,
+		Quotas: quotas, Responses: responsePolicies(args)}, nil
+}
+
+// runSynthesizer returns the synthesized collection, and prints the diagnostics
+func runSynthesizer(cmd *cobra.Command, args *inArgs, synthesizer synth.Synthesizer) (ir.Collection, error) {
+	collection, diagnostics, err := synthesizer.Synth()
+	if printErr := printDiagnostics(cmd, args, diagnostics); printErr != nil {
+		return nil, printErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return collection, nil
+}
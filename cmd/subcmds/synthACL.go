@@ -8,10 +8,17 @@ package subcmds
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/synth"
 )
 
-const singleACLFlag = "single"
+const (
+	singleACLFlag     = "single"
+	internalCidrsFlag = "internal-cidrs"
+	aclStrategyFlag   = "acl-strategy"
+	tcpResponsesFlag  = "tcp-responses"
+	udpResponsesFlag  = "udp-responses"
+)
 
 func newSynthACLCommand(args *inArgs) *cobra.Command {
 	cmd := &cobra.Command{
@@ -21,11 +28,42 @@ func newSynthACLCommand(args *inArgs) *cobra.Command {
 		Endpoints in the required-connectivity specification may be subnets, subnet segments, CIDR segments and externals.`,
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return synthesis(cmd, args, synth.NewACLSynthesizer, args.singleacl, false)
+			return synthesis(cmd, args, synth.NewACLSynthesizer, false)
 		},
 	}
 
 	cmd.Flags().BoolVar(&args.singleacl, singleACLFlag, false, "whether to generate a single acl")
+	addInternalCidrsFlag(cmd, args)
+	addACLStrategyFlag(cmd, args)
+	addResponsesFlags(cmd, args)
 
 	return cmd
 }
+
+// addInternalCidrsFlag adds the flag that overrides the internal address space, which nACLs deny before the rules of
+// connections with external resources
+func addInternalCidrsFlag(cmd *cobra.Command, args *inArgs) {
+	cmd.Flags().StringSliceVar(&args.internalCidrs, internalCidrsFlag, nil,
+		"CIDRs of the internal address space, which connections with externals must not reach "+
+			"(default: the address prefixes of the VPC and the prefixes advertised to it through transit gateways)")
+}
+
+// addACLStrategyFlag adds the flag that sets how precise the synthesized nACL rules are
+func addACLStrategyFlag(cmd *cobra.Command, args *inArgs) {
+	cmd.Flags().StringVar(&args.aclStrategy, aclStrategyFlag, string(synth.FineACLs),
+		"how precise the nACL rules are; "+mustBeOneOf(synth.ACLStrategies)+
+			" (coarse nACLs allow any protocol between the subnets of the required connections, leaving the rest to SGs)")
+}
+
+// addResponsesFlags adds the flags that set which responses of TCP and UDP connections the stateless nACLs allow
+func addResponsesFlags(cmd *cobra.Command, args *inArgs) {
+	cmd.Flags().StringVar(&args.tcpResponses, tcpResponsesFlag, string(ir.ResponseExact),
+		"which responses of TCP connections nACLs allow; "+mustBeOneOf(ir.TCPResponsePolicies)+
+			" (exact allows any destination port of the response, ephemeral only ports 1024-65535)")
+	cmd.Flags().StringVar(&args.udpResponses, udpResponsesFlag, string(ir.ResponseNone),
+		"which responses of UDP connections nACLs allow; "+mustBeOneOf(ir.UDPResponsePolicies))
+}
+
+func responsePolicies(args *inArgs) *ir.ResponsePolicies {
+	return &ir.ResponsePolicies{TCP: ir.ResponsePolicy(args.tcpResponses), UDP: ir.ResponsePolicy(args.udpResponses)}
+}
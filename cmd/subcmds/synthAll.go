@@ -0,0 +1,58 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package subcmds
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/io/jsonio"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/synth"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/utils"
+)
+
+func newSynthAllCommand(args *inArgs) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "all",
+		Short: "Generate both Network ACLs and Security Groups from connectivity specification",
+		Long: `Generate Network ACLs and Security Groups that both only allow the specified connectivity, as two layers of defense.
+		Each layer is verified to allow all the required connections, and both are written to the same output.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return layeredSynthesis(cmd, args)
+		},
+	}
+
+	cmd.Flags().BoolVar(&args.singleacl, singleACLFlag, false, "whether to generate a single acl")
+	addInternalCidrsFlag(cmd, args)
+	addACLStrategyFlag(cmd, args)
+	addResponsesFlags(cmd, args)
+
+	return cmd
+}
+
+func layeredSynthesis(cmd *cobra.Command, args *inArgs) error {
+	cmd.SilenceUsage = true // if we got this far, flags are syntactically correct, so no need to print usage
+	options, err := synthOptions(args)
+	if err != nil {
+		return err
+	}
+	// the config is read for each spec, since resources are resolved differently for SGs and for nACLs.
+	// the forbidden connections are denied by the nACLs, so the SGs are synthesized without them
+	reader := jsonio.NewReaderWithFormat(args.specFormat)
+	sgSpec, err := unmarshalWithReader(args, reader.SkippingForbiddenForSGs(), true)
+	if err != nil {
+		return err
+	}
+	aclSpec, err := unmarshalWithReader(args, reader, false)
+	if err != nil {
+		return err
+	}
+	collection, err := runSynthesizer(cmd, args, synth.NewLayeredSynthesizer(sgSpec, aclSpec, options))
+	if err != nil {
+		return err
+	}
+	return writeOutput(args, collection, utils.MapKeys(sgSpec.Defs.ConfigDefs.VPCs), true)
+}
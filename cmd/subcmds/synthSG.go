@@ -15,11 +15,11 @@ func newSynthSGCommand(args *inArgs) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "sg",
 		Short: "Generate Security Groups from connectivity specification",
-		Long: `Generate Security Groups for Network Interfaces and VPEs to only allow the specified connectivity. 
-		Endpoints in the required-connectivity specification may be Instances (VSIs), Network Interfaces, VPEs and externals.`,
+		Long: `Generate Security Groups for Network Interfaces, VPEs and load balancers to only allow the specified connectivity. 
+		Endpoints in the required-connectivity specification may be Instances (VSIs), Network Interfaces, VPEs, load balancers and externals.`,
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return synthesis(cmd, args, synth.NewSGSynthesizer, false, true)
+			return synthesis(cmd, args, synth.NewSGSynthesizer, true)
 		},
 	}
 	return cmd
@@ -10,16 +10,25 @@ import (
 
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/io/confio"
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/io/jsonio"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/io/tfstateio"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ipset"
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
 )
 
 func unmarshal(args *inArgs, isSG bool) (*ir.Spec, error) {
-	defs, err := confio.ReadDefs(args.configFile)
+	return unmarshalWithReader(args, jsonio.NewReaderWithFormat(args.specFormat), isSG)
+}
+
+func unmarshalWithReader(args *inArgs, reader *jsonio.Reader, isSG bool) (*ir.Spec, error) {
+	defs, err := readDefs(args.configFile)
 	if err != nil {
 		return nil, fmt.Errorf("could not parse config file %v: %w", args.configFile, err)
 	}
+	if defs.InternalAddrsOverride, err = parseInternalCidrs(args.internalCidrs); err != nil {
+		return nil, err
+	}
 
-	model, err := jsonio.NewReader().ReadSpec(args.specFile, defs, isSG)
+	model, err := reader.ReadSpec(args.specFile, defs, isSG)
 	if err != nil {
 		return nil, fmt.Errorf("could not parse connectivity file %s: %w", args.specFile, err)
 	}
@@ -27,9 +36,39 @@ func unmarshal(args *inArgs, isSG bool) (*ir.Spec, error) {
 	return model, nil
 }
 
-func parseCollection(args *inArgs, isSG bool) (ir.Collection, error) {
+// parseInternalCidrs returns the internal address space given by the --internal-cidrs flag, or nil if it is not set
+func parseInternalCidrs(cidrs []string) (*ipset.IPBlock, error) {
+	if len(cidrs) == 0 {
+		return nil, nil
+	}
+	res := ipset.NewIPBlock()
+	for _, cidr := range cidrs {
+		ipBlock, err := ipset.IPBlockFromCidr(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("bad --%s: %w", internalCidrsFlag, err)
+		}
+		res = res.Union(ipBlock)
+	}
+	return res, nil
+}
+
+// readDefs reads either a config object file or the output of `terraform show -json`
+func readDefs(configFile string) (*ir.ConfigDefs, error) {
+	if tfstateio.IsState(configFile) {
+		return tfstateio.ReadDefs(configFile)
+	}
+	return confio.ReadDefs(configFile)
+}
+
+func parseCollection(configFile string, isSG bool) (ir.Collection, ir.Diagnostics, error) {
+	if tfstateio.IsState(configFile) {
+		if isSG {
+			return tfstateio.ReadSGs(configFile)
+		}
+		return tfstateio.ReadACLs(configFile)
+	}
 	if isSG {
-		return confio.ReadSGs(args.configFile)
+		return confio.ReadSGs(configFile)
 	}
-	return confio.ReadACLs(args.configFile)
+	return confio.ReadACLs(configFile)
 }
@@ -5,7 +5,14 @@ SPDX-License-Identifier: Apache-2.0
 
 package subcmds
 
-import "fmt"
+import (
+	"fmt"
+	"slices"
+
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/io/jsonio"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/synth"
+)
 
 func validateFlags(args *inArgs) error {
 	if args.outputDir != "" && args.outputFile != "" {
@@ -20,5 +27,42 @@ func validateFlags(args *inArgs) error {
 	if args.locals && args.outputFmt != tfOutputFormat {
 		return fmt.Errorf("--locals flag requires setting the output format to tf")
 	}
+	if args.attach && args.outputFmt != tfOutputFormat {
+		return fmt.Errorf("--attach flag requires setting the output format to tf")
+	}
+	if args.inPlace && args.outputFmt != tfOutputFormat {
+		return fmt.Errorf("--in-place flag requires setting the output format to tf")
+	}
+	if err := validateChoiceFlags(args); err != nil {
+		return err
+	}
+	if !slices.Contains(diagnosticsFormats, args.diagnosticsFormat) {
+		return fmt.Errorf("bad diagnostics format %q; %s", args.diagnosticsFormat, mustBeOneOf(diagnosticsFormats))
+	}
+	// json diagnostics are printed to the standard output, so the generated resources or the report must go elsewhere
+	if args.diagnosticsFormat == jsonDiagnosticsFormat && args.outputFile == "" && args.outputDir == "" {
+		return fmt.Errorf("--%s %s requires -o or -d, since the diagnostics are printed to the standard output",
+			diagnosticsFormatFlag, jsonDiagnosticsFormat)
+	}
+	return nil
+}
+
+// validateChoiceFlags checks the flags that take one of a list of values, if they are set
+func validateChoiceFlags(args *inArgs) error {
+	choiceFlags := []struct {
+		name    string
+		value   string
+		choices []string
+	}{
+		{"spec format", args.specFormat, jsonio.SpecFormats},
+		{"nACL strategy", args.aclStrategy, synth.ACLStrategies},
+		{"TCP response policy", args.tcpResponses, ir.TCPResponsePolicies},
+		{"UDP response policy", args.udpResponses, ir.UDPResponsePolicies},
+	}
+	for _, f := range choiceFlags {
+		if f.value != "" && !slices.Contains(f.choices, f.value) {
+			return fmt.Errorf("bad %s %q; %s", f.name, f.value, mustBeOneOf(f.choices))
+		}
+	}
 	return nil
 }
@@ -0,0 +1,57 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package subcmds
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/io/jsonio"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/verify"
+)
+
+func newVerifyCommand(args *inArgs) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "verify existing SGs/nACLs against a connectivity specification",
+		Long: `Report required connections that are blocked by the existing SGs/nACLs, and connections they allow that are not required.
+		--config and --spec parameters must be supplied.`,
+	}
+
+	// flags
+	cmd.PersistentFlags().StringVarP(&args.specFile, specFlag, "s", "", "JSON or YAML file containing spec file")
+	cmd.PersistentFlags().StringVar(&args.specFormat, specFormatFlag, "",
+		"Spec file format; "+mustBeOneOf(jsonio.SpecFormats)+" (default: by the spec file extension)")
+
+	// flags settings
+	_ = cmd.MarkPersistentFlagRequired(specFlag)
+
+	// subcmds
+	cmd.AddCommand(newVerifyACLCommand(args))
+	cmd.AddCommand(newVerifySGCommand(args))
+
+	return cmd
+}
+
+func verification(cmd *cobra.Command, args *inArgs, newVerifier func(*ir.Spec, ir.Collection) verify.Verifier, isSG bool) error {
+	cmd.SilenceUsage = true // if we got this far, flags are syntactically correct, so no need to print usage
+	spec, err := unmarshal(args, isSG)
+	if err != nil {
+		return err
+	}
+	collection, diagnostics, err := parseCollection(args.configFile, isSG)
+	if err != nil {
+		return fmt.Errorf("could not parse config file %v: %w", args.configFile, err)
+	}
+	report := newVerifier(spec, collection).Verify()
+	if err := printReportDiagnostics(cmd, args, append(spec.Diagnostics, diagnostics...), report); err != nil {
+		return err
+	}
+	return writeToFile(args.outputFile, bytes.NewBufferString(report.String()))
+}
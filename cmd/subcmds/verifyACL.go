@@ -0,0 +1,33 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package subcmds
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/verify"
+)
+
+func newVerifyACLCommand(args *inArgs) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "acl",
+		Short: "Verify existing Network ACLs against connectivity specification",
+		Long: `Verify that the Network ACLs attached to each subnet allow the specified connectivity and their responses, and nothing else.
+		Endpoints in the required-connectivity specification may be subnets, subnet segments, CIDR segments and externals.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return verification(cmd, args, func(s *ir.Spec, collection ir.Collection) verify.Verifier {
+				return verify.NewACLVerifierWithResponses(s, collection, responsePolicies(args))
+			}, false)
+		},
+	}
+
+	addInternalCidrsFlag(cmd, args)
+	addResponsesFlags(cmd, args)
+
+	return cmd
+}
@@ -0,0 +1,26 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package subcmds
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/verify"
+)
+
+func newVerifySGCommand(args *inArgs) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sg",
+		Short: "Verify existing Security Groups against connectivity specification",
+		Long: `Verify that the Security Groups attached to each VSI and VPE allow the specified connectivity, and nothing else.
+		Endpoints in the required-connectivity specification may be Instances (VSIs), Network Interfaces, VPEs, load balancers and externals.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return verification(cmd, args, verify.NewSGVerifier, true)
+		},
+	}
+	return cmd
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package diff
+
+import (
+	"slices"
+
+	"github.com/np-guard/models/pkg/netset"
+
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ipset"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
+	acloptimizer "github.com/np-guard/vpc-network-config-synthesis/pkg/optimize/acl"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/utils"
+)
+
+type ACLDiffer struct {
+	firstDefs  *ir.ConfigDefs
+	first      *ir.ACLCollection
+	secondDefs *ir.ConfigDefs
+	second     *ir.ACLCollection
+}
+
+// NewACLDiffer creates and returns a new ACLDiffer instance
+func NewACLDiffer(firstDefs *ir.ConfigDefs, first ir.Collection, secondDefs *ir.ConfigDefs, second ir.Collection) Differ {
+	return &ACLDiffer{firstDefs: firstDefs, first: first.(*ir.ACLCollection), secondDefs: secondDefs, second: second.(*ir.ACLCollection)}
+}
+
+// Diff compares the connectivity of each subnet, as allowed by the nACL attached to it in each config, so nACLs are
+// matched by their subnets rather than by their names. a subnet that exists only in one config is compared to a subnet
+// without connectivity
+func (a *ACLDiffer) Diff() *Report {
+	report := &Report{Kind: "subnets"}
+	subnets := slices.Concat(utils.SortedMapKeys(a.firstDefs.Subnets), utils.SortedMapKeys(a.secondDefs.Subnets))
+	for _, subnet := range slices.Compact(slices.Sorted(slices.Values(subnets))) {
+		report.Compared++
+		report.Results = slices.Concat(report.Results, compare(subnet,
+			subnetConns(a.firstDefs, a.first, subnet), subnetConns(a.secondDefs, a.second, subnet)))
+	}
+	return report
+}
+
+// subnetConns returns the connections to and from the subnet allowed by its nACL, or nil if the subnet does not exist.
+// connections within the subnet are not filtered by its nACL, so they are not compared
+func subnetConns(defs *ir.ConfigDefs, collection *ir.ACLCollection, subnet ir.ID) map[ir.Direction]*ipset.EndpointsTrafficSet {
+	details, ok := defs.Subnets[subnet]
+	if !ok {
+		return nil
+	}
+	acl := collection.AttachedACL(subnet, details.NetworkACL)
+	others := ipset.GetCidrAll().Union(ipset.GetIPv6CidrAll()).Subtract(details.CIDR)
+	local := map[ir.Direction]*ipset.EndpointsTrafficSet{
+		ir.Inbound:  ipset.NewEndpointsTrafficSet(others, details.CIDR, netset.AllTransports()),
+		ir.Outbound: ipset.NewEndpointsTrafficSet(details.CIDR, others, netset.AllTransports()),
+	}
+	res := map[ir.Direction]*ipset.EndpointsTrafficSet{}
+	for _, d := range directions {
+		res[d] = ipset.EmptyEndpointsTrafficSet()
+		if acl != nil {
+			res[d] = acloptimizer.AllowedConnections(acl.DirectionRules(d)).Intersect(local[d])
+		}
+	}
+	return res
+}
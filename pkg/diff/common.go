@@ -0,0 +1,98 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package diff compares the connectivity allowed by two collections of SGs/nACLs, rather than their rules.
+package diff
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ipset"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
+)
+
+type (
+	Differ interface {
+		Diff() *Report
+	}
+
+	// Report holds the connectivity differences, per resource (subnets for nACLs, SG targets for SGs) and per direction
+	Report struct {
+		// "subnets" or "SG targets"
+		Kind string
+
+		// number of compared resources
+		Compared int
+
+		Results []*Result
+	}
+
+	Result struct {
+		Resource     ir.ID
+		Direction    ir.Direction
+		OnlyInFirst  *ipset.EndpointsTrafficSet
+		OnlyInSecond *ipset.EndpointsTrafficSet
+	}
+)
+
+var directions = []ir.Direction{ir.Inbound, ir.Outbound}
+
+// compare compares the connectivity allowed for a resource by two collections, given per direction.
+// a resource missing from a config has no connectivity.
+func compare(resource ir.ID, first, second map[ir.Direction]*ipset.EndpointsTrafficSet) []*Result {
+	res := make([]*Result, 0)
+	for _, d := range directions {
+		firstConns, secondConns := ipset.EmptyEndpointsTrafficSet(), ipset.EmptyEndpointsTrafficSet()
+		if first != nil {
+			firstConns = first[d]
+		}
+		if second != nil {
+			secondConns = second[d]
+		}
+		result := &Result{Resource: resource, Direction: d,
+			OnlyInFirst:  firstConns.Subtract(secondConns),
+			OnlyInSecond: secondConns.Subtract(firstConns),
+		}
+		if !result.OnlyInFirst.IsEmpty() || !result.OnlyInSecond.IsEmpty() {
+			res = append(res, result)
+		}
+	}
+	return res
+}
+
+// DifferentCount returns the number of resources whose connectivity differs
+func (r *Report) DifferentCount() int {
+	resources := make([]ir.ID, len(r.Results))
+	for i, result := range r.Results {
+		resources[i] = result.Resource
+	}
+	return len(slices.Compact(slices.Sorted(slices.Values(resources))))
+}
+
+func (r *Report) Summary() string {
+	return fmt.Sprintf("%d of %d compared %s allow different connectivity", r.DifferentCount(), r.Compared, r.Kind)
+}
+
+// Text returns the report, where first and second describe the compared collections
+func (r *Report) Text(first, second string) string {
+	if len(r.Results) == 0 {
+		return fmt.Sprintf("%s and %s allow the same connectivity\n", first, second)
+	}
+	var sb strings.Builder
+	for _, result := range r.Results {
+		fmt.Fprintf(&sb, "%s %s:\n", result.Resource, result.Direction)
+		if !result.OnlyInFirst.IsEmpty() {
+			fmt.Fprintf(&sb, "\tonly in %s:\n", first)
+			sb.WriteString(result.OnlyInFirst.Lines("\t\t"))
+		}
+		if !result.OnlyInSecond.IsEmpty() {
+			fmt.Fprintf(&sb, "\tonly in %s:\n", second)
+			sb.WriteString(result.OnlyInSecond.Lines("\t\t"))
+		}
+	}
+	return sb.String()
+}
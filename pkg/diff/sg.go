@@ -0,0 +1,120 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package diff
+
+import (
+	"slices"
+
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ipset"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/optimize"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/utils"
+)
+
+type SGDiffer struct {
+	first  *sgConfig
+	second *sgConfig
+}
+
+// sgConfig is one of the compared configs
+type sgConfig struct {
+	defs       *ir.ConfigDefs
+	collection *ir.SGCollection
+
+	// IP addresses of the NIFs/reserved IPs/private IPs, per VPC and per the unscoped target name
+	targets map[ir.ID]map[string]*ipset.IPBlock
+
+	// the IP addresses of targets, per VPC and per any name an SG may use for them: the unscoped NIF/VPE/LB names of a
+	// config object, and the scoped NIF/instance/VPE/LB names of a synthesized collection
+	targetIPs map[ir.ID]map[string]*ipset.IPBlock
+}
+
+// NewSGDiffer creates and returns a new SGDiffer instance
+func NewSGDiffer(firstDefs *ir.ConfigDefs, first ir.Collection, secondDefs *ir.ConfigDefs, second ir.Collection) Differ {
+	return &SGDiffer{first: newSGConfig(firstDefs, first.(*ir.SGCollection)), second: newSGConfig(secondDefs, second.(*ir.SGCollection))}
+}
+
+func newSGConfig(defs *ir.ConfigDefs, collection *ir.SGCollection) *sgConfig {
+	res := &sgConfig{defs: defs, collection: collection, targets: defs.SGTargetIPs(), targetIPs: defs.SGTargetIPs()}
+	for _, vpc := range utils.SortedMapKeys(res.targets) {
+		for _, target := range utils.SortedMapKeys(res.targets[vpc]) {
+			res.targetIPs[vpc][vpc+"/"+target] = res.targets[vpc][target]
+		}
+	}
+	for _, nif := range utils.SortedMapKeys(defs.NIFs) {
+		vpc, instance := ir.VpcFromScopedResource(nif), defs.NIFs[nif].Instance
+		if res.targetIPs[vpc][instance] == nil {
+			res.targetIPs[vpc][instance] = ipset.NewIPBlock()
+		}
+		res.targetIPs[vpc][instance] = res.targetIPs[vpc][instance].Union(defs.NIFs[nif].Address())
+	}
+	return res
+}
+
+// Diff compares the connectivity of each SG target (NIF, VPE or load balancer), as allowed by the SGs attached to it in
+// each config, so SGs are matched by their targets rather than by their names. A remote SG stands for the IP addresses
+// of its targets. a target that exists only in one config is compared to a target without connectivity
+func (s *SGDiffer) Diff() *Report {
+	report := &Report{Kind: "SG targets"}
+	targets := slices.Concat(s.first.scopedTargets(), s.second.scopedTargets())
+	for _, target := range slices.Compact(slices.Sorted(slices.Values(targets))) {
+		report.Compared++
+		report.Results = slices.Concat(report.Results, compare(target, s.first.targetConns(target), s.second.targetConns(target)))
+	}
+	return report
+}
+
+func (c *sgConfig) scopedTargets() []ir.ID {
+	res := make([]ir.ID, 0)
+	for _, vpc := range utils.SortedMapKeys(c.targets) {
+		for _, target := range utils.SortedMapKeys(c.targets[vpc]) {
+			res = append(res, vpc+"/"+target)
+		}
+	}
+	return res
+}
+
+// targetConns returns the connections of the (scoped) target allowed by its SGs, or nil if the target does not exist
+func (c *sgConfig) targetConns(scopedTarget ir.ID) map[ir.Direction]*ipset.EndpointsTrafficSet {
+	vpc, target := ir.VpcFromScopedResource(scopedTarget), ir.UnscopedName(scopedTarget)
+	ips, ok := c.targets[vpc][target]
+	if !ok {
+		return nil
+	}
+	names := []string{target, scopedTarget}
+	if nif, ok := c.defs.NIFs[scopedTarget]; ok {
+		names = append(names, nif.Instance)
+	}
+	res := map[ir.Direction]*ipset.EndpointsTrafficSet{ir.Inbound: ipset.EmptyEndpointsTrafficSet(),
+		ir.Outbound: ipset.EmptyEndpointsTrafficSet()}
+	for _, name := range names {
+		for _, sg := range c.collection.AttachedSGs(vpc, name) {
+			res[ir.Inbound] = res[ir.Inbound].Union(c.allowedConnections(sg.InboundRules, vpc, ips, ir.Inbound))
+			res[ir.Outbound] = res[ir.Outbound].Union(c.allowedConnections(sg.OutboundRules, vpc, ips, ir.Outbound))
+		}
+	}
+	return res
+}
+
+// allowedConnections returns the connections of the given target IPs allowed by SG rules of the given direction
+func (c *sgConfig) allowedConnections(rules map[string][]*ir.SGRule, vpc ir.ID, ips *ipset.IPBlock,
+	direction ir.Direction) *ipset.EndpointsTrafficSet {
+	res := ipset.EmptyEndpointsTrafficSet()
+	for _, local := range utils.SortedMapKeys(rules) {
+		for _, rule := range rules[local] {
+			localIPs := ips.Intersect(rule.Local)
+			if localIPs.IsEmpty() {
+				continue
+			}
+			src, dst := localIPs, c.collection.RemoteIPs(rule.Remote, vpc, c.targetIPs)
+			if direction == ir.Inbound {
+				src, dst = dst, src
+			}
+			res = res.Union(ipset.NewEndpointsTrafficSet(src, dst, optimize.ProtocolToTransportSet(rule.Protocol)))
+		}
+	}
+	return res
+}
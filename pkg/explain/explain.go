@@ -0,0 +1,105 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package explain maps the rules of synthesized Network ACLs and Security Groups to the spec connections and protocols
+// they serve.
+package explain
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"html/template"
+	"strings"
+
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/optimize"
+)
+
+type (
+	// Report lists the rules of a synthesized collection, in the order they are written
+	Report struct {
+		Rules []*Rule `json:"rules"`
+	}
+
+	Rule struct {
+		Firewall string `json:"firewall"`
+
+		// the index of the rule among the rules of the firewall, starting from 0, as in the names of the terraform rules
+		Index int `json:"index"`
+
+		Direction   ir.Direction     `json:"direction"`
+		Rule        string           `json:"rule"`
+		Explanation string           `json:"explanation,omitempty"`
+		Origins     []*ir.RuleOrigin `json:"origins"`
+	}
+)
+
+// SGReport returns the rules of the SGs of all VPCs, or of the given VPC
+func SGReport(collection *ir.SGCollection, vpc string) *Report {
+	res := &Report{Rules: []*Rule{}}
+	for _, vpcName := range collection.VpcNames() {
+		if vpc != vpcName && vpc != "" {
+			continue
+		}
+		for _, sgName := range collection.SortedSGNames(vpcName) {
+			for i, rule := range collection.SGs[vpcName][sgName].AllRules() {
+				res.Rules = append(res.Rules, &Rule{Firewall: sgName.String(), Index: i, Direction: rule.Direction,
+					Rule: optimize.SGRuleString(rule), Explanation: rule.Explanation, Origins: origins(rule.Origins)})
+			}
+		}
+	}
+	return res
+}
+
+// ACLReport returns the rules of the nACLs of all VPCs, or of the given VPC
+func ACLReport(collection *ir.ACLCollection, vpc string) *Report {
+	res := &Report{Rules: []*Rule{}}
+	for _, vpcName := range collection.VpcNames() {
+		if vpc != vpcName && vpc != "" {
+			continue
+		}
+		for _, aclName := range collection.SortedACLNames(vpcName) {
+			for i, rule := range collection.ACLs[vpcName][aclName].Rules() {
+				res.Rules = append(res.Rules, &Rule{Firewall: aclName, Index: i, Direction: rule.Direction,
+					Rule: optimize.ACLRuleString(rule), Explanation: rule.Explanation, Origins: origins(rule.Origins)})
+			}
+		}
+	}
+	return res
+}
+
+func (r *Report) JSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+func (r *Report) HTML() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := htmlReport.Execute(&buf, r); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// origins returns an empty list rather than nil, so that rules with no origins are written as such
+func origins(o []*ir.RuleOrigin) []*ir.RuleOrigin {
+	if o == nil {
+		return []*ir.RuleOrigin{}
+	}
+	return o
+}
+
+//go:embed report.html.tmpl
+var htmlTemplate string
+
+var htmlReport = template.Must(template.New("explain").Funcs(template.FuncMap{
+	"join": func(origins []*ir.RuleOrigin) string {
+		res := make([]string, len(origins))
+		for i, o := range origins {
+			res[i] = o.String()
+		}
+		return strings.Join(res, "; ")
+	},
+}).Parse(htmlTemplate))
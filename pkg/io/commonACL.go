@@ -12,8 +12,8 @@ import (
 	"strconv"
 
 	"github.com/np-guard/models/pkg/netp"
-	"github.com/np-guard/models/pkg/netset"
 
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ipset"
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
 )
 
@@ -90,10 +90,13 @@ func action(a ir.Action) string {
 	return "Allow"
 }
 
-func printIP(ip *netset.IPBlock, protocol netp.Protocol, isSource bool) (string, error) {
+func printIP(ip *ipset.IPBlock, protocol netp.Protocol, isSource bool) (string, error) {
 	ipString := ip.String()
-	if ip.Equal(netset.GetCidrAll()) {
+	switch {
+	case ip.Equal(ipset.GetCidrAll()):
 		ipString = "Any IP" //nolint:goconst // independent decision for SG and ACL
+	case ip.Equal(ipset.GetIPv6CidrAll()):
+		ipString = "Any IPv6" //nolint:goconst // independent decision for SG and ACL
 	}
 	switch p := protocol.(type) {
 	case netp.ICMP:
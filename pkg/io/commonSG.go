@@ -11,8 +11,8 @@ import (
 	"slices"
 
 	"github.com/np-guard/models/pkg/netp"
-	"github.com/np-guard/models/pkg/netset"
 
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ipset"
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
 )
 
@@ -81,7 +81,7 @@ func makeSGRow(rule *ir.SGRule, sgName ir.SGName) ([]string, error) {
 
 func sgRemoteType(t ir.RemoteType) (string, error) {
 	switch p := t.(type) {
-	case *netset.IPBlock:
+	case *ipset.IPBlock:
 		if ipString := p.ToIPAddressString(); ipString != "" { // single IP address
 			return "IP address", nil
 		}
@@ -94,12 +94,15 @@ func sgRemoteType(t ir.RemoteType) (string, error) {
 
 func sgRemote(r ir.RemoteType) (string, error) {
 	switch tr := r.(type) {
-	case *netset.IPBlock:
-		s := tr.String()
-		if s == netset.CidrAll {
+	case *ipset.IPBlock:
+		switch s := tr.String(); s {
+		case ipset.CidrAll:
 			return "Any IP", nil
+		case ipset.IPv6CidrAll:
+			return "Any IPv6", nil
+		default:
+			return s, nil
 		}
-		return s, nil
 	case ir.SGName:
 		return tr.String(), nil
 	}
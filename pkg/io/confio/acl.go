@@ -21,46 +21,46 @@ import (
 func (w *Writer) WriteACL(collection *ir.ACLCollection, _ string, isSynth bool) error {
 	var err error
 	if isSynth {
-		err = makeACLs(w.model, collection)
+		err = w.makeACLs(collection)
 	} else {
-		err = updateACLs(w.model, collection)
+		err = w.updateACLs(collection)
 	}
 	if err != nil {
 		return err
 	}
-	globalIndex = 0 // making test results more predictable
+	w.refIndex = 0 // making test results more predictable
 	return w.writeModel()
 }
 
 // makeACLs calls makeSingleACL if we generated a single ACL,
 // o.w. it calls makeACL
-func makeACLs(model *configModel.ResourcesContainerModel, collection *ir.ACLCollection) error {
-	if len(model.SubnetList) == 0 {
+func (w *Writer) makeACLs(collection *ir.ACLCollection) error {
+	if len(w.model.SubnetList) == 0 {
 		return nil
 	}
-	subnet := model.SubnetList[0]
+	subnet := w.model.SubnetList[0]
 	vpcName := *subnet.VPC.Name
 	aclName := ScopingString(vpcName, *subnet.Name)
 
 	// decide if we are in a single-ACL mode
 	if _, ok := collection.ACLs[vpcName][aclName]; ok {
-		return makeACL(model, collection)
+		return w.makeACL(collection)
 	}
-	return makeSingleACL(model, collection)
+	return w.makeSingleACL(collection)
 }
 
 // makeACL writes all the generates nACLs to the config object
-func makeACL(model *configModel.ResourcesContainerModel, collection *ir.ACLCollection) error {
-	for _, subnet := range model.SubnetList {
+func (w *Writer) makeACL(collection *ir.ACLCollection) error {
+	for _, subnet := range w.model.SubnetList {
 		vpcName := *subnet.VPC.Name
 		aclName := ScopingString(vpcName, *subnet.Name)
 
 		acl := collection.ACLs[vpcName][aclName]
-		aclItem, err := newACLItem(subnet, acl)
+		aclItem, err := w.newACLItem(subnet, acl)
 		if err != nil {
 			return err
 		}
-		model.NetworkACLList = append(model.NetworkACLList, aclItem)
+		w.model.NetworkACLList = append(w.model.NetworkACLList, aclItem)
 		subnet.NetworkACL = newNACLRef(aclItem)
 	}
 
@@ -68,22 +68,22 @@ func makeACL(model *configModel.ResourcesContainerModel, collection *ir.ACLColle
 }
 
 // makeSingleACL writes the generated nACL to the config object
-func makeSingleACL(model *configModel.ResourcesContainerModel, collection *ir.ACLCollection) error {
+func (w *Writer) makeSingleACL(collection *ir.ACLCollection) error {
 	aclItem := &configModel.NetworkACL{}
 	var err error
 
-	for i, subnet := range model.SubnetList {
+	for i, subnet := range w.model.SubnetList {
 		vpcName := *subnet.VPC.Name
 		acl := collection.ACLs[vpcName][ScopingString(vpcName, "singleACL")]
 
 		// if this is the first subnet being added to the ACL, add it to the list of network ACLs
 		// otherwise, add the subnet reference to the existing ACL item
 		if i == 0 {
-			aclItem, err = newACLItem(subnet, acl)
+			aclItem, err = w.newACLItem(subnet, acl)
 			if err != nil {
 				return err
 			}
-			model.NetworkACLList = append(model.NetworkACLList, aclItem)
+			w.model.NetworkACLList = append(w.model.NetworkACLList, aclItem)
 		} else {
 			aclItem.Subnets = append(aclItem.Subnets, *subnetRef(subnet))
 		}
@@ -92,9 +92,9 @@ func makeSingleACL(model *configModel.ResourcesContainerModel, collection *ir.AC
 	return nil
 }
 
-func newACLItem(subnet *configModel.Subnet, acl *ir.ACL) (*configModel.NetworkACL, error) {
-	ref := allocateRef()
-	rules, err := aclRules(acl)
+func (w *Writer) newACLItem(subnet *configModel.Subnet, acl *ir.ACL) (*configModel.NetworkACL, error) {
+	ref := w.allocateRef()
+	rules, err := w.aclRules(acl)
 	if err != nil {
 		return nil, err
 	}
@@ -112,14 +112,14 @@ func newACLItem(subnet *configModel.Subnet, acl *ir.ACL) (*configModel.NetworkAC
 	return aclItem, nil
 }
 
-func aclRules(acl *ir.ACL) ([]vpcv1.NetworkACLRuleItemIntf, error) {
+func (w *Writer) aclRules(acl *ir.ACL) ([]vpcv1.NetworkACLRuleItemIntf, error) {
 	rules := acl.Rules()
 	ruleItems := make([]vpcv1.NetworkACLRuleItemIntf, len(rules))
 
 	var next *vpcv1.NetworkACLRuleReference
 	for i := len(ruleItems) - 1; i >= 0; i-- {
 		name := utils.Ptr(fmt.Sprintf("rule%v", i))
-		ref := allocateRef()
+		ref := w.allocateRef()
 		current := &vpcv1.NetworkACLRuleReference{
 			Name: name,
 			Href: ref.Href,
@@ -138,7 +138,7 @@ func aclRules(acl *ir.ACL) ([]vpcv1.NetworkACLRuleItemIntf, error) {
 
 func makeACLRuleItem(rule *ir.ACLRule, current,
 	next *vpcv1.NetworkACLRuleReference) (vpcv1.NetworkACLRuleItemIntf, error) {
-	iPVersion := utils.Ptr(ipv4Const)
+	iPVersion := ipVersion(rule.Source)
 	direction := direction(rule.Direction)
 	action := action(rule.Action)
 	source := utils.Ptr(rule.Source.ToCidrListString())
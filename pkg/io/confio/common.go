@@ -7,15 +7,19 @@ package confio
 
 import (
 	"bufio"
+	"errors"
 	"io"
 
 	configModel "github.com/np-guard/cloud-resource-collector/pkg/ibm/datamodel"
+
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
 )
 
 // Writer implements ir.Writer
 type Writer struct {
-	w     *bufio.Writer
-	model *configModel.ResourcesContainerModel
+	w        *bufio.Writer
+	model    *configModel.ResourcesContainerModel
+	refIndex int // the index of the last allocated fake reference
 }
 
 func NewWriter(w io.Writer, inputFilename string) (*Writer, error) {
@@ -26,6 +30,15 @@ func NewWriter(w io.Writer, inputFilename string) (*Writer, error) {
 	return &Writer{w: bufio.NewWriter(w), model: model}, nil
 }
 
+// NewWriterFromBytes is NewWriter for the contents of a config_object file
+func NewWriterFromBytes(w io.Writer, data []byte) (*Writer, error) {
+	model, err := unmarshalModel(data)
+	if err != nil {
+		return nil, err
+	}
+	return &Writer{w: bufio.NewWriter(w), model: model}, nil
+}
+
 func (w *Writer) writeModel() error {
 	s, err := w.model.ToJSONString()
 	if err != nil {
@@ -37,3 +50,18 @@ func (w *Writer) writeModel() error {
 	}
 	return w.w.Flush()
 }
+
+// WriteLayered updates the config object with both the nACLs and the SGs of the collection
+func (w *Writer) WriteLayered(collection *ir.LayeredCollection, _ string, isSynth bool) error {
+	var err1, err2 error
+	if isSynth {
+		err1, err2 = w.makeACLs(collection.ACLs), w.writeSGs(collection.SGs)
+	} else {
+		err1, err2 = w.updateACLs(collection.ACLs), w.updateSGs(collection.SGs)
+	}
+	if err := errors.Join(err1, err2); err != nil {
+		return err
+	}
+	w.refIndex = 0 // making test results more predictable
+	return w.writeModel()
+}
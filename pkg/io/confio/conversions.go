@@ -13,6 +13,7 @@ import (
 
 	"github.com/np-guard/models/pkg/netp"
 
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ipset"
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/utils"
 )
@@ -21,6 +22,7 @@ const (
 	icmpConst     = vpcv1.NetworkACLRuleItemNetworkACLRuleProtocolIcmpProtocolIcmpConst
 	allConst      = vpcv1.NetworkACLRuleItemNetworkACLRuleProtocolAllProtocolAllConst
 	ipv4Const     = vpcv1.NetworkACLRuleItemNetworkACLRuleProtocolAllIPVersionIpv4Const
+	ipv6Const     = "ipv6" // the SDK does not define the IPv6 ip_version yet
 	allowConst    = vpcv1.NetworkACLRuleItemNetworkACLRuleProtocolAllActionAllowConst
 	denyConst     = vpcv1.NetworkACLRuleItemNetworkACLRuleProtocolAllActionDenyConst
 	outboundConst = vpcv1.NetworkACLRuleItemNetworkACLRuleProtocolAllDirectionOutboundConst
@@ -33,14 +35,13 @@ type refData struct {
 	Href *string
 }
 
-var globalIndex int = 0
-
-func allocateRef() refData {
-	globalIndex++
+// allocateRef returns fake references for a new resource; they are numbered per writer, so the output is predictable
+func (w *Writer) allocateRef() refData {
+	w.refIndex++
 	return refData{
-		ID:   utils.Ptr(fmt.Sprintf("fake:id:%v", globalIndex)),
-		CRN:  utils.Ptr(fmt.Sprintf("fake:crn:%v", globalIndex)),
-		Href: utils.Ptr(fmt.Sprintf("fake:href:%v", globalIndex)),
+		ID:   utils.Ptr(fmt.Sprintf("fake:id:%v", w.refIndex)),
+		CRN:  utils.Ptr(fmt.Sprintf("fake:crn:%v", w.refIndex)),
+		Href: utils.Ptr(fmt.Sprintf("fake:href:%v", w.refIndex)),
 	}
 }
 
@@ -54,6 +55,14 @@ func action(a ir.Action) *string {
 	return nil
 }
 
+// ipVersion returns the ip_version of a rule of the given addresses, which are all of the same IP version
+func ipVersion(addrs *ipset.IPBlock) *string {
+	if addrs.IsIPv6() {
+		return utils.Ptr(ipv6Const)
+	}
+	return utils.Ptr(ipv4Const)
+}
+
 func direction(d ir.Direction) *string {
 	switch d {
 	case ir.Outbound:
@@ -8,30 +8,28 @@ package confio
 import (
 	"github.com/IBM/vpc-go-sdk/vpcv1"
 
-	configModel "github.com/np-guard/cloud-resource-collector/pkg/ibm/datamodel"
-
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
 )
 
 // updateACLs changes nACLs rules to optimized rules
-func updateACLs(model *configModel.ResourcesContainerModel, collection *ir.ACLCollection) error {
-	for _, acl := range model.NetworkACLList {
+func (w *Writer) updateACLs(collection *ir.ACLCollection) error {
+	for _, acl := range w.model.NetworkACLList {
 		if acl.Name == nil || acl.VPC == nil || acl.VPC.Name == nil {
 			continue
 		}
-		if err := updateACL(&acl.NetworkACL, collection.ACLs[*acl.VPC.Name][*acl.Name]); err != nil {
+		if err := w.updateACL(&acl.NetworkACL, collection.ACLs[*acl.VPC.Name][*acl.Name]); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func updateACL(acl *vpcv1.NetworkACL, optimizedACL *ir.ACL) error {
+func (w *Writer) updateACL(acl *vpcv1.NetworkACL, optimizedACL *ir.ACL) error {
 	optimizedRules := optimizedACL.Rules()
 	if len(optimizedRules) >= len(acl.Rules) {
 		return nil
 	}
-	rules, err := aclRules(optimizedACL)
+	rules, err := w.aclRules(optimizedACL)
 	acl.Rules = rules
 	return err
 }
@@ -14,27 +14,28 @@ import (
 )
 
 // updateSGs updates the config object file with the optimized SG rules
-func updateSGs(model *configModel.ResourcesContainerModel, collection *ir.SGCollection) error {
-	sgRefMap := parseSGRefMap(model)
-	for _, sg := range model.SecurityGroupList {
+func (w *Writer) updateSGs(collection *ir.SGCollection) error {
+	sgRefMap := parseSGRefMap(w.model)
+	for _, sg := range w.model.SecurityGroupList {
 		if sg.Name == nil || sg.VPC == nil || sg.VPC.Name == nil {
 			continue
 		}
-		if err := updateSG(&sg.SecurityGroup, collection.SGs[*sg.VPC.Name][ir.SGName(*sg.Name)], sgRefMap); err != nil {
+		if err := w.updateSG(&sg.SecurityGroup, collection.SGs[*sg.VPC.Name][ir.SGName(*sg.Name)], sgRefMap); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func updateSG(sg *vpcv1.SecurityGroup, optimizedSG *ir.SG, sgRefMap map[string]*vpcv1.SecurityGroupRuleRemoteSecurityGroupReference) error {
+func (w *Writer) updateSG(sg *vpcv1.SecurityGroup, optimizedSG *ir.SG,
+	sgRefMap map[string]*vpcv1.SecurityGroupRuleRemoteSecurityGroupReference) error {
 	optimizedRules := optimizedSG.AllRules()
 	if len(optimizedRules) >= len(sg.Rules) {
 		return nil
 	}
 	sg.Rules = make([]vpcv1.SecurityGroupRuleIntf, len(optimizedRules))
 	for i, rule := range optimizedRules {
-		r, err := makeSGRuleItem(sgRefMap, rule, i)
+		r, err := w.makeSGRuleItem(sgRefMap, rule, i)
 		if err != nil {
 			return err
 		}
@@ -8,44 +8,59 @@ package confio
 import (
 	"errors"
 	"fmt"
-	"log"
+	"os"
 
 	"github.com/IBM/vpc-go-sdk/vpcv1"
 
 	"github.com/np-guard/models/pkg/netp"
-	"github.com/np-guard/models/pkg/netset"
 
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ipset"
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/utils"
 )
 
 // ReadACLs translates ACLs from a config_object file to ir.ACLCollection
-func ReadACLs(filename string) (*ir.ACLCollection, error) {
-	config, err := readModel(filename)
+func ReadACLs(filename string) (*ir.ACLCollection, ir.Diagnostics, error) {
+	data, err := os.ReadFile(filename)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	return ReadACLsFromBytes(data)
+}
+
+// ReadACLsFromBytes is ReadACLs for the contents of a config_object file
+func ReadACLsFromBytes(data []byte) (*ir.ACLCollection, ir.Diagnostics, error) {
+	config, err := unmarshalModel(data)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	result := ir.NewACLCollection()
+	var diagnostics ir.Diagnostics
 	for i, acl := range config.NetworkACLList {
 		if acl.Name == nil || acl.VPC == nil || acl.VPC.Name == nil {
-			log.Printf("Warning: missing acl/VPC name in acl at index %d\n", i)
+			diagnostics = append(diagnostics, ir.NewDiagnostic(ir.SeverityWarning, ir.CodeMissingName,
+				fmt.Sprintf("missing acl/VPC name in acl at index %d; the acl is skipped", i)))
 			continue
 		}
 		inbound, outbound, err := translateACLRules(&acl.NetworkACL)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
+		subnets, subnetDiagnostics := parseAttachedSubnets(&acl.NetworkACL)
+		diagnostics = append(diagnostics, subnetDiagnostics...)
 		vpcName := *acl.VPC.Name
 		if result.ACLs[vpcName] == nil {
 			result.ACLs[vpcName] = make(map[string]*ir.ACL)
 		}
 		result.ACLs[vpcName][*acl.Name] = &ir.ACL{Name: *acl.Name,
-			Subnets:  parseAttachedSubnets(&acl.NetworkACL),
+			Subnets:  subnets,
 			Inbound:  inbound,
 			Outbound: outbound,
+			ID:       utils.GetProperty(acl.ID, ""),
 		}
 	}
-	return result, nil
+	return result, diagnostics, nil
 }
 
 func translateACLRules(acl *vpcv1.NetworkACL) (inbound, outbound []*ir.ACLRule, err error) {
@@ -78,8 +93,8 @@ func translateACLRule(acl *vpcv1.NetworkACL, i int) (*ir.ACLRule, error) {
 func translateACLRuleProtocolAll(rule *vpcv1.NetworkACLRuleItemNetworkACLRuleProtocolAll) (*ir.ACLRule, error) {
 	action, err1 := translateAction(rule.Action)
 	direction, err2 := translateDirection(*rule.Direction)
-	src, err3 := netset.IPBlockFromCidrOrAddress(*rule.Source)
-	dst, err4 := netset.IPBlockFromCidrOrAddress(*rule.Destination)
+	src, err3 := ipset.IPBlockFromCidrOrAddress(*rule.Source)
+	dst, err4 := ipset.IPBlockFromCidrOrAddress(*rule.Destination)
 	if err := errors.Join(err1, err2, err3, err4); err != nil {
 		return nil, err
 	}
@@ -95,8 +110,8 @@ func translateACLRuleProtocolAll(rule *vpcv1.NetworkACLRuleItemNetworkACLRulePro
 func translateACLRuleProtocolTCPUDP(rule *vpcv1.NetworkACLRuleItemNetworkACLRuleProtocolTcpudp) (*ir.ACLRule, error) {
 	action, err1 := translateAction(rule.Action)
 	direction, err2 := translateDirection(*rule.Direction)
-	src, err3 := netset.IPBlockFromCidrOrAddress(*rule.Source)
-	dst, err4 := netset.IPBlockFromCidrOrAddress(*rule.Destination)
+	src, err3 := ipset.IPBlockFromCidrOrAddress(*rule.Source)
+	dst, err4 := ipset.IPBlockFromCidrOrAddress(*rule.Destination)
 	protocol, err5 := translateProtocolTCPUDP(*rule.Protocol, rule.SourcePortMin, rule.SourcePortMax,
 		rule.DestinationPortMin, rule.DestinationPortMax)
 	if err := errors.Join(err1, err2, err3, err4, err5); err != nil {
@@ -115,8 +130,8 @@ func translateACLRuleProtocolTCPUDP(rule *vpcv1.NetworkACLRuleItemNetworkACLRule
 func translateACLRuleProtocolIcmp(rule *vpcv1.NetworkACLRuleItemNetworkACLRuleProtocolIcmp) (*ir.ACLRule, error) {
 	action, err1 := translateAction(rule.Action)
 	direction, err2 := translateDirection(*rule.Direction)
-	src, err3 := netset.IPBlockFromCidrOrAddress(*rule.Source)
-	dst, err4 := netset.IPBlockFromCidrOrAddress(*rule.Destination)
+	src, err3 := ipset.IPBlockFromCidrOrAddress(*rule.Source)
+	dst, err4 := ipset.IPBlockFromCidrOrAddress(*rule.Destination)
 	protocol, err5 := netp.ICMPFromTypeAndCode64WithoutRFCValidation(rule.Type, rule.Code)
 	if err := errors.Join(err1, err2, err3, err4, err5); err != nil {
 		return nil, err
@@ -140,17 +155,20 @@ func translateAction(action *string) (ir.Action, error) {
 	return ir.Deny, fmt.Errorf("an nACL rule action must be either allow or deny")
 }
 
-func parseAttachedSubnets(acl *vpcv1.NetworkACL) []string {
+func parseAttachedSubnets(acl *vpcv1.NetworkACL) ([]string, ir.Diagnostics) {
+	var diagnostics ir.Diagnostics
 	if len(acl.Subnets) == 0 {
-		log.Printf("Warning: nACL %s does not have attached subnets", *acl.Name)
+		diagnostics = append(diagnostics, ir.NewDiagnostic(ir.SeverityInfo, ir.CodeUnattachedFirewall,
+			fmt.Sprintf("nACL %s does not have attached subnets", *acl.Name), *acl.Name))
 	}
 	res := make([]string, 0)
 	for i, subnet := range acl.Subnets {
 		if subnet.Name != nil {
 			res = append(res, *subnet.Name)
 		} else {
-			log.Printf("Warning: error translating subnet %d in %s nACL", i, *acl.Name)
+			diagnostics = append(diagnostics, ir.NewDiagnostic(ir.SeverityWarning, ir.CodeBadAttachment,
+				fmt.Sprintf("error translating subnet %d in nACL %s", i, *acl.Name), *acl.Name))
 		}
 	}
-	return res
+	return res, diagnostics
 }
@@ -8,49 +8,69 @@ package confio
 import (
 	"errors"
 	"fmt"
+	"os"
+	"slices"
 
+	tgwapi "github.com/IBM/networking-go-sdk/transitgatewayapisv1"
 	"github.com/IBM/vpc-go-sdk/vpcv1"
 
 	configModel "github.com/np-guard/cloud-resource-collector/pkg/ibm/datamodel"
 
-	"github.com/np-guard/models/pkg/netset"
-
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ipset"
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/utils"
 )
 
 const EndpointVPE string = "endpoint_gateway"
 
 func ReadDefs(filename string) (*ir.ConfigDefs, error) {
-	config, err := readModel(filename)
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return ReadDefsFromBytes(data)
+}
+
+// ReadDefsFromBytes is ReadDefs for the contents of a config_object file
+func ReadDefsFromBytes(data []byte) (*ir.ConfigDefs, error) {
+	config, err := unmarshalModel(data)
 	if err != nil {
 		return nil, err
 	}
 
-	subnets, err1 := parseSubnets(config)
+	subnets, err1 := parseSubnets(config, data)
 	instances, nifs, err2 := parseInstancesNifs(config)
+	if err2 == nil {
+		err2 = errors.Join(parseFloatingIPs(config, nifs), parseSecondaryIPs(config, nifs))
+	}
 	vpes, vpeEndpoints, err3 := parseVPEs(config)
 	vpcs, err4 := parseVPCs(config)
 	err5 := validateVpcs(vpcs)
-	if err := errors.Join(err1, err2, err3, err4, err5); err != nil {
+	tgws, err6 := parseTransitGateways(config)
+	lbs, lbIPs, err7 := parseLoadBalancers(config, subnets)
+	if err := errors.Join(err1, err2, err3, err4, err5, err6, err7); err != nil {
 		return nil, err
 	}
 
 	return &ir.ConfigDefs{
-		VPCs:           vpcs,
-		Subnets:        subnets,
-		NIFs:           nifs,
-		Instances:      instances,
-		VPEReservedIPs: vpeEndpoints,
-		VPEs:           vpes,
+		VPCs:            vpcs,
+		Subnets:         subnets,
+		NIFs:            nifs,
+		Instances:       instances,
+		VPEReservedIPs:  vpeEndpoints,
+		VPEs:            vpes,
+		LoadBalancerIPs: lbIPs,
+		LoadBalancers:   lbs,
+		TransitGateways: tgws,
 	}, nil
 }
 
 func parseVPCs(config *configModel.ResourcesContainerModel) (map[ir.ID]*ir.VPCDetails, error) {
 	res := make(map[ir.ID]*ir.VPCDetails, len(config.VpcList))
 	for _, vpc := range config.VpcList {
-		addressPrefixes := netset.NewIPBlock()
+		addressPrefixes := ipset.NewIPBlock()
 		for _, addressPrefix := range vpc.AddressPrefixes {
-			address, err := netset.IPBlockFromCidr(*addressPrefix.CIDR)
+			address, err := ipset.IPBlockFromCidr(*addressPrefix.CIDR)
 			if err != nil {
 				return nil, err
 			}
@@ -61,50 +81,159 @@ func parseVPCs(config *configModel.ResourcesContainerModel) (map[ir.ID]*ir.VPCDe
 	return res, nil
 }
 
-func parseSubnets(config *configModel.ResourcesContainerModel) (map[ir.ID]*ir.SubnetDetails, error) {
+// parseSubnets parses the subnets of a config object, whose data is needed for the IPv6 CIDR blocks of dual-stack and
+// IPv6-only subnets
+func parseSubnets(config *configModel.ResourcesContainerModel, data []byte) (map[ir.ID]*ir.SubnetDetails, error) {
+	ipv6CIDRs, err := subnetIPv6CIDRs(data)
+	if err != nil {
+		return nil, err
+	}
 	subnets := make(map[ir.ID]*ir.SubnetDetails, len(config.SubnetList))
 	for _, subnet := range config.SubnetList {
-		cidr, err := netset.IPBlockFromCidr(*subnet.Ipv4CIDRBlock)
+		cidr, err := ir.SubnetCIDR(utils.GetProperty(subnet.Ipv4CIDRBlock, ""),
+			utils.GetProperty(ipv6CIDRs[utils.GetProperty(subnet.ID, "")], ""))
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("subnet %s: %w", *subnet.Name, err)
 		}
 		subnetDetails := ir.SubnetDetails{
 			CIDR: cidr,
 		}
+		if subnet.NetworkACL != nil && subnet.NetworkACL.Name != nil {
+			subnetDetails.NetworkACL = *subnet.NetworkACL.Name
+		}
+		if subnet.PublicGateway != nil && subnet.PublicGateway.Name != nil {
+			subnetDetails.PublicGateway = *subnet.PublicGateway.Name
+		}
 		subnets[ScopingString(*subnet.VPC.Name, *subnet.Name)] = &subnetDetails
 	}
 	return subnets, nil
 }
 
+// instanceNif is a network interface of an instance: either a network interface, or the virtual network interface
+// of a network attachment
+type instanceNif struct {
+	id      *string
+	name    *string
+	address *string
+	subnet  *string
+	virtual bool
+}
+
+func instanceNifs(instance *configModel.Instance) []instanceNif {
+	res := make([]instanceNif, 0, len(instance.NetworkInterfaces)+len(instance.NetworkAttachments))
+	for i := range instance.NetworkInterfaces {
+		nif := &instance.NetworkInterfaces[i]
+		res = append(res, instanceNif{id: nif.ID, name: nif.Name, address: nif.PrimaryIP.Address, subnet: nif.Subnet.Name})
+	}
+	for i := range instance.NetworkAttachments {
+		attachment := &instance.NetworkAttachments[i]
+		vni := attachment.VirtualNetworkInterface
+		res = append(res, instanceNif{id: vni.ID, name: vni.Name, address: attachment.PrimaryIP.Address, subnet: attachment.Subnet.Name,
+			virtual: true})
+	}
+	return res
+}
+
 func parseInstancesNifs(config *configModel.ResourcesContainerModel) (instances map[ir.ID]*ir.InstanceDetails,
 	nifs map[ir.ID]*ir.NifDetails, err error) {
 	instances = make(map[ir.ID]*ir.InstanceDetails, len(config.InstanceList))
 	nifs = make(map[ir.ID]*ir.NifDetails)
 	for _, instance := range config.InstanceList {
 		instanceUniqueName := ScopingString(*instance.VPC.Name, *instance.Name)
-		instanceNifs := make([]ir.ID, len(instance.NetworkInterfaces))
-		for i := range instance.NetworkInterfaces {
-			nifIP, err := netset.IPBlockFromIPAddress(*instance.NetworkInterfaces[i].PrimaryIP.Address)
+		nifsOfInstance := instanceNifs(instance)
+		instanceNifNames := make([]ir.ID, len(nifsOfInstance))
+		for i, nif := range nifsOfInstance {
+			nifIP, err := ipset.IPBlockFromIPAddress(*nif.address)
 			if err != nil {
 				return nil, nil, err
 			}
 			nifDetails := ir.NifDetails{
-				Instance: ScopingString(*instance.VPC.Name, *instance.Name),
+				Instance: instanceUniqueName,
 				IP:       nifIP,
-				Subnet:   ScopingString(*instance.VPC.Name, *instance.NetworkInterfaces[i].Subnet.Name),
+				Subnet:   ScopingString(*instance.VPC.Name, *nif.subnet),
+				Virtual:  nif.virtual,
 			}
-			nifUniqueName := ScopingString(instanceUniqueName, *instance.NetworkInterfaces[i].Name)
+			nifUniqueName := ScopingString(instanceUniqueName, *nif.name)
 			nifs[nifUniqueName] = &nifDetails
-			instanceNifs[i] = nifUniqueName
+			instanceNifNames[i] = nifUniqueName
 		}
 		instanceDetails := ir.InstanceDetails{
-			Nifs: instanceNifs,
+			Nifs: instanceNifNames,
 		}
 		instances[instanceUniqueName] = &instanceDetails
 	}
 	return instances, nifs, nil
 }
 
+// indexNifsByID maps the ids of the network interfaces and virtual network interfaces of the instances to their NIFs
+func indexNifsByID(config *configModel.ResourcesContainerModel, nifs map[ir.ID]*ir.NifDetails) map[string]*ir.NifDetails {
+	res := make(map[string]*ir.NifDetails)
+	for _, instance := range config.InstanceList {
+		for _, nif := range instanceNifs(instance) {
+			res[*nif.id] = nifs[ScopingString(ScopingString(*instance.VPC.Name, *instance.Name), *nif.name)]
+		}
+	}
+	return res
+}
+
+// parseFloatingIPs sets the floating IPs of the NIFs they are bound to
+func parseFloatingIPs(config *configModel.ResourcesContainerModel, nifs map[ir.ID]*ir.NifDetails) error {
+	nifsByID := indexNifsByID(config, nifs)
+	for _, fip := range config.FloatingIPList {
+		target, ok := fip.Target.(*vpcv1.FloatingIPTarget)
+		if !ok || target == nil || target.ID == nil || fip.Address == nil {
+			continue
+		}
+		nif, ok := nifsByID[*target.ID]
+		if !ok {
+			continue // a floating IP of a public gateway
+		}
+		address, err := ipset.IPBlockFromIPAddress(*fip.Address)
+		if err != nil {
+			return err
+		}
+		nif.FloatingIP = address
+	}
+	return nil
+}
+
+// parseSecondaryIPs sets the secondary IPs of the NIFs: the IPs of virtual network interfaces, and the reserved IPs
+// of subnets that are bound to a network interface or a virtual network interface, other than its primary IP
+func parseSecondaryIPs(config *configModel.ResourcesContainerModel, nifs map[ir.ID]*ir.NifDetails) error {
+	nifsByID := indexNifsByID(config, nifs)
+	addresses := map[string][]*string{}
+	for _, vni := range config.VirtualNIList {
+		for i := range vni.Ips {
+			addresses[*vni.ID] = append(addresses[*vni.ID], vni.Ips[i].Address)
+		}
+	}
+	for _, subnet := range config.SubnetList {
+		for _, r := range subnet.ReservedIps {
+			if t, ok := r.Target.(*vpcv1.ReservedIPTarget); ok && t != nil && t.ID != nil && r.Address != nil {
+				addresses[*t.ID] = append(addresses[*t.ID], r.Address)
+			}
+		}
+	}
+	for _, id := range utils.SortedMapKeys(addresses) {
+		nif, ok := nifsByID[id]
+		if !ok {
+			continue // not bound to a NIF, e.g. a reserved IP of a VPE
+		}
+		secondaryIPs := ipset.NewIPBlock()
+		for _, address := range addresses[id] {
+			ip, err := ipset.IPBlockFromIPAddress(*address)
+			if err != nil {
+				return err
+			}
+			secondaryIPs = secondaryIPs.Union(ip)
+		}
+		if secondaryIPs = secondaryIPs.Subtract(nif.IP); !secondaryIPs.IsEmpty() {
+			nif.SecondaryIPs = secondaryIPs
+		}
+	}
+	return nil
+}
+
 func parseVPEs(config *configModel.ResourcesContainerModel) (vpes map[ir.ID]*ir.VPEDetails,
 	vpeReservedIPs map[ir.ID]*ir.VPEReservedIPsDetails, err error) {
 	vpes = make(map[ir.ID]*ir.VPEDetails)
@@ -128,7 +257,7 @@ func parseVPEs(config *configModel.ResourcesContainerModel) (vpes map[ir.ID]*ir.
 			VPEName := ScopingString(*subnet.VPC.Name, *t.Name)
 			subnetName := ScopingString(*subnet.VPC.Name, *subnet.Name)
 
-			vpeIP, err := netset.IPBlockFromIPAddress(*r.Address)
+			vpeIP, err := ipset.IPBlockFromIPAddress(*r.Address)
 			if err != nil {
 				return nil, nil, err
 			}
@@ -147,6 +276,102 @@ func parseVPEs(config *configModel.ResourcesContainerModel) (vpes map[ir.ID]*ir.
 	return vpes, vpeReservedIPs, nil
 }
 
+// parseLoadBalancers translates the load balancers and their private IPs; the VPC of a load balancer is the VPC of its
+// subnets, and each private IP is in one of them
+func parseLoadBalancers(config *configModel.ResourcesContainerModel, subnets map[ir.ID]*ir.SubnetDetails) (
+	lbs map[ir.ID]*ir.LoadBalancerDetails, lbIPs map[ir.ID]*ir.LoadBalancerIPDetails, err error) {
+	subnetsByID := make(map[string]ir.ID, len(config.SubnetList))
+	for _, subnet := range config.SubnetList {
+		subnetsByID[*subnet.ID] = ScopingString(*subnet.VPC.Name, *subnet.Name)
+	}
+	lbs = make(map[ir.ID]*ir.LoadBalancerDetails, len(config.LBList))
+	lbIPs = make(map[ir.ID]*ir.LoadBalancerIPDetails)
+	for _, lb := range config.LBList {
+		var lbSubnets []ir.ID
+		for i := range lb.Subnets {
+			if subnetName, ok := subnetsByID[*lb.Subnets[i].ID]; ok && subnets[subnetName] != nil {
+				lbSubnets = append(lbSubnets, subnetName)
+			}
+		}
+		if len(lbSubnets) == 0 {
+			continue // a load balancer of a VPC that is not part of the config
+		}
+		lbName := ScopingString(ir.VpcFromScopedResource(lbSubnets[0]), *lb.Name)
+		lbDetails := &ir.LoadBalancerDetails{PrivateIPs: []ir.ID{}, Public: lb.IsPublic != nil && *lb.IsPublic}
+		for i := range lb.PrivateIps {
+			ip, err := ipset.IPBlockFromIPAddress(*lb.PrivateIps[i].Address)
+			if err != nil {
+				return nil, nil, err
+			}
+			subnetIndex := slices.IndexFunc(lbSubnets, func(subnet ir.ID) bool { return ip.IsSubset(subnets[subnet].CIDR) })
+			if subnetIndex < 0 {
+				return nil, nil, fmt.Errorf("private IP %s of load balancer %s is not in any of its subnets", *lb.PrivateIps[i].Address, lbName)
+			}
+			ipName := ScopingString(lbName, *lb.PrivateIps[i].Name)
+			lbIPs[ipName] = &ir.LoadBalancerIPDetails{IP: ip, LoadBalancer: lbName, Subnet: lbSubnets[subnetIndex]}
+			lbDetails.PrivateIPs = append(lbDetails.PrivateIPs, ipName)
+		}
+		lbs[lbName] = lbDetails
+	}
+	return lbs, lbIPs, nil
+}
+
+// parseTransitGateways translates the attached VPC connections of the transit gateways to the address prefixes each
+// VPC advertises through each transit gateway
+func parseTransitGateways(config *configModel.ResourcesContainerModel) (map[ir.ID]*ir.TransitGatewayDetails, error) {
+	vpcsByCRN := make(map[string]*configModel.VPC, len(config.VpcList))
+	for _, vpc := range config.VpcList {
+		vpcsByCRN[*vpc.CRN] = vpc
+	}
+	res := make(map[ir.ID]*ir.TransitGatewayDetails, len(config.TransitGatewayList))
+	for _, conn := range config.TransitConnectionList {
+		if *conn.NetworkType != tgwapi.TransitConnection_NetworkType_Vpc || *conn.Status != tgwapi.TransitConnection_Status_Attached {
+			continue
+		}
+		vpc, ok := vpcsByCRN[*conn.NetworkID]
+		if !ok {
+			continue // a VPC that is not part of the config
+		}
+		addressPrefixes := make([]*ipset.IPBlock, len(vpc.AddressPrefixes))
+		for i, addressPrefix := range vpc.AddressPrefixes {
+			address, err := ipset.IPBlockFromCidr(*addressPrefix.CIDR)
+			if err != nil {
+				return nil, err
+			}
+			addressPrefixes[i] = address
+		}
+		filters, err := parsePrefixFilters(conn.PrefixFilters)
+		if err != nil {
+			return nil, fmt.Errorf("transit connection %s: %w", *conn.Name, err)
+		}
+		tgwName := *conn.TransitGateway.Name
+		if res[tgwName] == nil {
+			res[tgwName] = &ir.TransitGatewayDetails{VPCPrefixes: make(map[ir.ID]*ipset.IPBlock)}
+		}
+		res[tgwName].VPCPrefixes[*vpc.Name] = ir.AdvertisedPrefixes(addressPrefixes, filters,
+			ir.PrefixFilterAction(utils.GetProperty(conn.PrefixFiltersDefault, "")))
+	}
+	return res, nil
+}
+
+func parsePrefixFilters(prefixFilters []tgwapi.TransitGatewayConnectionPrefixFilterReference) ([]*ir.PrefixFilter, error) {
+	res := make([]*ir.PrefixFilter, len(prefixFilters))
+	for i := range prefixFilters {
+		prefix, err := ipset.IPBlockFromCidr(*prefixFilters[i].Prefix)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = &ir.PrefixFilter{Action: ir.PrefixFilterAction(utils.GetProperty(prefixFilters[i].Action, "")), Prefix: prefix}
+		if prefixFilters[i].Ge != nil {
+			res[i].Ge = *prefixFilters[i].Ge
+		}
+		if prefixFilters[i].Le != nil {
+			res[i].Le = *prefixFilters[i].Le
+		}
+	}
+	return res, nil
+}
+
 func validateVpcs(vpcs map[ir.ID]*ir.VPCDetails) error {
 	if vpcs == nil {
 		return nil
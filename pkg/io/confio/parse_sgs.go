@@ -8,34 +8,47 @@ package confio
 import (
 	"errors"
 	"fmt"
-	"log"
+	"os"
 
 	"github.com/IBM/vpc-go-sdk/vpcv1"
 
 	"github.com/np-guard/models/pkg/netp"
-	"github.com/np-guard/models/pkg/netset"
 
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ipset"
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/utils"
 )
 
 // ReadSG translates SGs from a config_object file to map[ir.SGName]*SG
-func ReadSGs(filename string) (*ir.SGCollection, error) {
-	config, err := readModel(filename)
+func ReadSGs(filename string) (*ir.SGCollection, ir.Diagnostics, error) {
+	data, err := os.ReadFile(filename)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	return ReadSGsFromBytes(data)
+}
+
+// ReadSGsFromBytes is ReadSGs for the contents of a config_object file
+func ReadSGsFromBytes(data []byte) (*ir.SGCollection, ir.Diagnostics, error) {
+	config, err := unmarshalModel(data)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	result := ir.NewSGCollection()
+	var diagnostics ir.Diagnostics
 	for i, sg := range config.SecurityGroupList {
 		if sg.Name == nil || sg.VPC == nil || sg.VPC.Name == nil {
-			log.Printf("Warning: missing SG/VPC name in sg at index %d\n", i)
+			diagnostics = append(diagnostics, ir.NewDiagnostic(ir.SeverityWarning, ir.CodeMissingName,
+				fmt.Sprintf("missing SG/VPC name in sg at index %d; the SG is skipped", i)))
 			continue
 		}
 		inbound, outbound, err := translateSGRules(&sg.SecurityGroup)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
+		targets, targetDiagnostics := translateTargets(&sg.SecurityGroup)
+		diagnostics = append(diagnostics, targetDiagnostics...)
 		sgName := ir.SGName(*sg.Name)
 		vpcName := *sg.VPC.Name
 		if result.SGs[vpcName] == nil {
@@ -45,10 +58,11 @@ func ReadSGs(filename string) (*ir.SGCollection, error) {
 			SGName:        sgName,
 			InboundRules:  inbound,
 			OutboundRules: outbound,
-			Targets:       translateTargets(&sg.SecurityGroup),
+			Targets:       targets,
+			ID:            utils.GetProperty(sg.ID, ""),
 		}
 	}
-	return result, nil
+	return result, diagnostics, nil
 }
 
 // parse security rules, splitted into ingress and egress rules
@@ -72,15 +86,25 @@ func translateSGRules(sg *vpcv1.SecurityGroup) (ingressRules, egressRules map[st
 
 // translateSGRule translates a security group rule to ir.SGRule
 func translateSGRule(sg *vpcv1.SecurityGroup, index int) (sgRule *ir.SGRule, err error) {
+	var id *string
 	switch r := sg.Rules[index].(type) {
 	case *vpcv1.SecurityGroupRuleSecurityGroupRuleProtocolAll:
-		return translateSGRuleProtocolAll(r)
+		sgRule, err = translateSGRuleProtocolAll(r)
+		id = r.ID
 	case *vpcv1.SecurityGroupRuleSecurityGroupRuleProtocolTcpudp:
-		return translateSGRuleProtocolTCPUDP(r)
+		sgRule, err = translateSGRuleProtocolTCPUDP(r)
+		id = r.ID
 	case *vpcv1.SecurityGroupRuleSecurityGroupRuleProtocolIcmp:
-		return translateSGRuleProtocolIcmp(r)
+		sgRule, err = translateSGRuleProtocolIcmp(r)
+		id = r.ID
+	default:
+		return nil, fmt.Errorf("error parsing rule number %d in sg %s in VPC %s", index, *sg.Name, *sg.VPC.Name)
+	}
+	if err != nil {
+		return nil, err
 	}
-	return nil, fmt.Errorf("error parsing rule number %d in sg %s in VPC %s", index, *sg.Name, *sg.VPC.Name)
+	sgRule.ID = utils.GetProperty(id, "")
+	return sgRule, nil
 }
 
 func translateSGRuleProtocolAll(rule *vpcv1.SecurityGroupRuleSecurityGroupRuleProtocolAll) (sgRule *ir.SGRule, err error) {
@@ -128,9 +152,9 @@ func translateRemote(remote vpcv1.SecurityGroupRuleRemoteIntf) (ir.RemoteType, e
 	if r, ok := remote.(*vpcv1.SecurityGroupRuleRemote); ok {
 		switch {
 		case r.CIDRBlock != nil:
-			return netset.IPBlockFromCidr(*r.CIDRBlock)
+			return ipset.IPBlockFromCidr(*r.CIDRBlock)
 		case r.Address != nil:
-			return netset.IPBlockFromIPAddress(*r.Address)
+			return ipset.IPBlockFromIPAddress(*r.Address)
 		case r.Name != nil:
 			return ir.SGName(*r.Name), nil
 		}
@@ -138,32 +162,35 @@ func translateRemote(remote vpcv1.SecurityGroupRuleRemoteIntf) (ir.RemoteType, e
 	return nil, fmt.Errorf("unexpected SG rule remote")
 }
 
-func translateLocal(local vpcv1.SecurityGroupRuleLocalIntf) (*netset.IPBlock, error) {
+func translateLocal(local vpcv1.SecurityGroupRuleLocalIntf) (*ipset.IPBlock, error) {
 	if l, ok := local.(*vpcv1.SecurityGroupRuleLocal); ok {
 		if l.CIDRBlock != nil {
-			return netset.IPBlockFromCidr(*l.CIDRBlock)
+			return ipset.IPBlockFromCidr(*l.CIDRBlock)
 		}
 		if l.Address != nil {
-			return netset.IPBlockFromIPAddress(*l.Address)
+			return ipset.IPBlockFromIPAddress(*l.Address)
 		}
 	}
 	return nil, fmt.Errorf("error parsing Local field")
 }
 
 // translate SG targets
-func translateTargets(sg *vpcv1.SecurityGroup) []string {
+func translateTargets(sg *vpcv1.SecurityGroup) ([]string, ir.Diagnostics) {
+	var diagnostics ir.Diagnostics
 	if len(sg.Targets) == 0 {
-		log.Printf("Warning: Security Groups %s does not have attached resources", *sg.Name)
+		diagnostics = append(diagnostics, ir.NewDiagnostic(ir.SeverityInfo, ir.CodeUnattachedFirewall,
+			fmt.Sprintf("security group %s does not have attached resources", *sg.Name), *sg.Name))
 	}
 	res := make([]string, 0)
 	for i := range sg.Targets {
 		if t, ok := sg.Targets[i].(*vpcv1.SecurityGroupTargetReference); ok && t.Name != nil {
 			res = append(res, *t.Name)
 		} else {
-			log.Printf("Warning: error translating target %d in %s Security Group", i, *sg.Name)
+			diagnostics = append(diagnostics, ir.NewDiagnostic(ir.SeverityWarning, ir.CodeBadAttachment,
+				fmt.Sprintf("error translating target %d in security group %s", i, *sg.Name), *sg.Name))
 		}
 	}
-	return res
+	return res, diagnostics
 }
 
 func translateProtocolTCPUDP(protocolName string, srcPortMin, srcPortMax, dstPortMin, dstPortMax *int64) (netp.Protocol, error) {
@@ -17,10 +17,36 @@ func readModel(filename string) (*configModel.ResourcesContainerModel, error) {
 	if err != nil {
 		return nil, err
 	}
+	return unmarshalModel(bytes)
+}
+
+func unmarshalModel(bytes []byte) (*configModel.ResourcesContainerModel, error) {
 	model := configModel.ResourcesContainerModel{}
-	err = json.Unmarshal(bytes, &model)
-	if err != nil {
+	if err := json.Unmarshal(bytes, &model); err != nil {
 		return nil, err
 	}
 	return &model, nil
 }
+
+// subnetIPv6 is the IPv6 CIDR block of a subnet, which the SDK does not define yet
+type subnetIPv6 struct {
+	ID            *string `json:"id"`
+	IPv6CIDRBlock *string `json:"ipv6_cidr_block"`
+}
+
+// subnetIPv6CIDRs maps the id of each subnet of a config object to its IPv6 CIDR block, if it has one
+func subnetIPv6CIDRs(bytes []byte) (map[string]*string, error) {
+	model := struct {
+		Subnets []subnetIPv6 `json:"subnets"`
+	}{}
+	if err := json.Unmarshal(bytes, &model); err != nil {
+		return nil, err
+	}
+	res := map[string]*string{}
+	for _, subnet := range model.Subnets {
+		if subnet.ID != nil && subnet.IPv6CIDRBlock != nil {
+			res[*subnet.ID] = subnet.IPv6CIDRBlock
+		}
+	}
+	return res, nil
+}
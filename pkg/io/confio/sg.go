@@ -14,14 +14,16 @@ import (
 
 	configModel "github.com/np-guard/cloud-resource-collector/pkg/ibm/datamodel"
 	"github.com/np-guard/models/pkg/netp"
-	"github.com/np-guard/models/pkg/netset"
 
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ipset"
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/utils"
 )
 
 const ResourceTypeNif = "network_interface"
 const ResourceTypeEndpointGateway = "endpoint_gateway"
+const ResourceTypeLoadBalancer = "load_balancer"
+const ResourceTypeVirtualNif = "virtual_network_interface"
 
 func findAndDeleteTargetFromSG(model *configModel.ResourcesContainerModel, sgIndex int, id *string) {
 	sg := model.SecurityGroupList[sgIndex]
@@ -33,12 +35,12 @@ func findAndDeleteTargetFromSG(model *configModel.ResourcesContainerModel, sgInd
 	}
 }
 
-func lookupOrCreate(nameToSGRemoteRef map[string]*vpcv1.SecurityGroupRuleRemoteSecurityGroupReference,
+func (w *Writer) lookupOrCreate(nameToSGRemoteRef map[string]*vpcv1.SecurityGroupRuleRemoteSecurityGroupReference,
 	name string) *vpcv1.SecurityGroupRuleRemoteSecurityGroupReference {
 	if sgRemoteRef, ok := nameToSGRemoteRef[name]; ok {
 		return sgRemoteRef
 	}
-	ref := allocateRef()
+	ref := w.allocateRef()
 	sgRemoteRef := &vpcv1.SecurityGroupRuleRemoteSecurityGroupReference{
 		ID:   ref.ID,
 		CRN:  ref.CRN,
@@ -49,11 +51,11 @@ func lookupOrCreate(nameToSGRemoteRef map[string]*vpcv1.SecurityGroupRuleRemoteS
 	return sgRemoteRef
 }
 
-func sgRemote(nameToSGRemoteRef map[string]*vpcv1.SecurityGroupRuleRemoteSecurityGroupReference,
+func (w *Writer) sgRemote(nameToSGRemoteRef map[string]*vpcv1.SecurityGroupRuleRemoteSecurityGroupReference,
 	rule *ir.SGRule) vpcv1.SecurityGroupRuleRemoteIntf {
 	st := rule.Remote.String()
 	switch t := rule.Remote.(type) {
-	case *netset.IPBlock:
+	case *ipset.IPBlock:
 		if t.IsSingleIPAddress() { // single IP address
 			return &vpcv1.SecurityGroupRuleRemoteIP{
 				Address: &st,
@@ -63,17 +65,17 @@ func sgRemote(nameToSGRemoteRef map[string]*vpcv1.SecurityGroupRuleRemoteSecurit
 			CIDRBlock: &st,
 		}
 	case ir.SGName:
-		return lookupOrCreate(nameToSGRemoteRef, ir.ChangeScoping(st))
+		return w.lookupOrCreate(nameToSGRemoteRef, ir.ChangeScoping(st))
 	}
 	return nil
 }
 
-func makeSGRuleItem(nameToSGRemoteRef map[string]*vpcv1.SecurityGroupRuleRemoteSecurityGroupReference,
+func (w *Writer) makeSGRuleItem(nameToSGRemoteRef map[string]*vpcv1.SecurityGroupRuleRemoteSecurityGroupReference,
 	rule *ir.SGRule, i int) (vpcv1.SecurityGroupRuleIntf, error) {
-	iPVersion := utils.Ptr(ipv4Const)
+	iPVersion := ipVersion(rule.Local)
 	direction := direction(rule.Direction)
-	ref := allocateRef()
-	remote := sgRemote(nameToSGRemoteRef, rule)
+	ref := w.allocateRef()
+	remote := w.sgRemote(nameToSGRemoteRef, rule)
 
 	var local vpcv1.SecurityGroupRuleLocalIntf
 	if rule.Local.IsSingleIPAddress() {
@@ -128,12 +130,12 @@ func makeSGRuleItem(nameToSGRemoteRef map[string]*vpcv1.SecurityGroupRuleRemoteS
 	return nil, fmt.Errorf("impossible protocol type for sg rule %v: %T", i, rule.Protocol)
 }
 
-func makeSGRules(nameToSGRemoteRef map[string]*vpcv1.SecurityGroupRuleRemoteSecurityGroupReference,
+func (w *Writer) makeSGRules(nameToSGRemoteRef map[string]*vpcv1.SecurityGroupRuleRemoteSecurityGroupReference,
 	sg *ir.SG) ([]vpcv1.SecurityGroupRuleIntf, error) {
 	rules := sg.AllRules()
 	ruleItems := make([]vpcv1.SecurityGroupRuleIntf, len(rules))
 	for i, rule := range rules {
-		rule, err := makeSGRuleItem(nameToSGRemoteRef, rule, i)
+		rule, err := w.makeSGRuleItem(nameToSGRemoteRef, rule, i)
 		if err != nil {
 			return nil, err
 		}
@@ -143,7 +145,7 @@ func makeSGRules(nameToSGRemoteRef map[string]*vpcv1.SecurityGroupRuleRemoteSecu
 }
 
 func parseTargetsSGInstance(instance *configModel.Instance) []vpcv1.SecurityGroupTargetReferenceIntf {
-	targets := make([]vpcv1.SecurityGroupTargetReferenceIntf, len(instance.NetworkInterfaces))
+	targets := make([]vpcv1.SecurityGroupTargetReferenceIntf, 0, len(instance.NetworkInterfaces)+len(instance.NetworkAttachments))
 	for i := range instance.NetworkInterfaces {
 		sgTargetRef := &vpcv1.SecurityGroupTargetReference{
 			Name:         instance.NetworkInterfaces[i].Name,
@@ -151,65 +153,70 @@ func parseTargetsSGInstance(instance *configModel.Instance) []vpcv1.SecurityGrou
 			ID:           instance.NetworkInterfaces[i].ID,
 			ResourceType: utils.Ptr(ResourceTypeNif),
 		}
-		targets[i] = sgTargetRef
+		targets = append(targets, sgTargetRef)
+	}
+	for i := range instance.NetworkAttachments {
+		vni := instance.NetworkAttachments[i].VirtualNetworkInterface
+		sgTargetRef := &vpcv1.SecurityGroupTargetReference{
+			CRN:          vni.CRN,
+			Name:         vni.Name,
+			Href:         vni.Href,
+			ID:           vni.ID,
+			ResourceType: utils.Ptr(ResourceTypeVirtualNif),
+		}
+		targets = append(targets, sgTargetRef)
 	}
 
 	return targets
 }
 
-func updateSGInstances(model *configModel.ResourcesContainerModel, collection *ir.SGCollection,
+func (w *Writer) updateSGInstances(collection *ir.SGCollection,
 	nameToSGRemoteRef map[string]*vpcv1.SecurityGroupRuleRemoteSecurityGroupReference, idToSGIndex map[string]int) error {
-	for _, instance := range model.InstanceList {
+	for _, instance := range w.model.InstanceList {
 		vpc := instance.VPC
 		sgName := ScopingString(*vpc.Name, *instance.Name)
-		sgItemName := utils.Ptr(ir.ChangeScoping(sgName))
-		sg := collection.SGs[*vpc.Name][ir.SGName(sgName)]
-		sgRules, err := makeSGRules(nameToSGRemoteRef, sg)
+		sgRefs, err := w.addSGItems(collection.AttachedSGs(*vpc.Name, sgName), vpc, instance.ResourceGroup,
+			parseTargetsSGInstance(instance), nameToSGRemoteRef)
 		if err != nil {
 			return err
 		}
-		ref := lookupOrCreate(nameToSGRemoteRef, *sgItemName)
-
-		sgItem := configModel.NewSecurityGroup(&vpcv1.SecurityGroup{
-			CRN:           ref.CRN,
-			Href:          ref.Href,
-			ID:            ref.ID,
-			Name:          sgItemName,
-			ResourceGroup: instance.ResourceGroup,
-			Rules:         sgRules,
-			Targets:       parseTargetsSGInstance(instance),
-			VPC:           vpc,
-		})
-		sgItem.Tags = []string{}
-		model.SecurityGroupList = append(model.SecurityGroupList, sgItem)
-
-		sgRef := vpcv1.SecurityGroupReference{
-			CRN:  ref.CRN,
-			Href: ref.Href,
-			ID:   ref.ID,
-			Name: sgItemName,
-		}
 
 		for j := range instance.NetworkInterfaces {
 			for k := range instance.NetworkInterfaces[j].SecurityGroups {
 				sgID := instance.NetworkInterfaces[j].SecurityGroups[k].ID
 				nifID := instance.NetworkInterfaces[j].ID
-				findAndDeleteTargetFromSG(model, idToSGIndex[*sgID], nifID)
+				findAndDeleteTargetFromSG(w.model, idToSGIndex[*sgID], nifID)
 			}
-			instance.NetworkInterfaces[j].SecurityGroups = []vpcv1.SecurityGroupReference{sgRef}
+			instance.NetworkInterfaces[j].SecurityGroups = sgRefs
+		}
+		for j := range instance.NetworkAttachments {
+			updateSGVirtualNif(w.model, instance.NetworkAttachments[j].VirtualNetworkInterface.ID, sgRefs, idToSGIndex)
 		}
 	}
 	return nil
 }
 
-func updateSGEndpointGW(model *configModel.ResourcesContainerModel, collection *ir.SGCollection,
+// updateSGVirtualNif replaces the SGs of a virtual network interface; the virtual network interfaces of network
+// attachments are only referenced by their instances, and their SGs are listed in the virtual network interfaces list
+func updateSGVirtualNif(model *configModel.ResourcesContainerModel, vniID *string, sgRefs []vpcv1.SecurityGroupReference,
+	idToSGIndex map[string]int) {
+	for _, vni := range model.VirtualNIList {
+		if *vni.ID != *vniID {
+			continue
+		}
+		for k := range vni.SecurityGroups {
+			findAndDeleteTargetFromSG(model, idToSGIndex[*vni.SecurityGroups[k].ID], vni.ID)
+		}
+		vni.SecurityGroups = sgRefs
+		return
+	}
+}
+
+func (w *Writer) updateSGEndpointGW(collection *ir.SGCollection,
 	nameToSGRemoteRef map[string]*vpcv1.SecurityGroupRuleRemoteSecurityGroupReference, idToSGIndex map[string]int) error {
-	for _, endpointGW := range model.EndpointGWList {
+	for _, endpointGW := range w.model.EndpointGWList {
 		vpc := endpointGW.VPC
 		sgName := ScopingString(*vpc.Name, *endpointGW.Name)
-		sgItemName := utils.Ptr(ir.ChangeScoping(sgName))
-		sg := collection.SGs[*vpc.Name][ir.SGName(sgName)]
-		ref := lookupOrCreate(nameToSGRemoteRef, *sgItemName)
 		target := &vpcv1.SecurityGroupTargetReference{
 			Name:         endpointGW.Name,
 			Href:         endpointGW.Href,
@@ -217,63 +224,115 @@ func updateSGEndpointGW(model *configModel.ResourcesContainerModel, collection *
 			CRN:          endpointGW.CRN,
 			ResourceType: utils.Ptr(ResourceTypeEndpointGateway),
 		}
+		sgRefs, err := w.addSGItems(collection.AttachedSGs(*vpc.Name, sgName), vpc, endpointGW.ResourceGroup,
+			[]vpcv1.SecurityGroupTargetReferenceIntf{target}, nameToSGRemoteRef)
+		if err != nil {
+			return err
+		}
 
-		sgRules, err := makeSGRules(nameToSGRemoteRef, sg)
+		for j := range endpointGW.SecurityGroups {
+			sgID := endpointGW.SecurityGroups[j].ID
+			endpointGatewayID := endpointGW.ID
+			findAndDeleteTargetFromSG(w.model, idToSGIndex[*sgID], endpointGatewayID)
+		}
+		endpointGW.SecurityGroups = sgRefs
+	}
+	return nil
+}
+
+func (w *Writer) updateSGLoadBalancers(collection *ir.SGCollection,
+	nameToSGRemoteRef map[string]*vpcv1.SecurityGroupRuleRemoteSecurityGroupReference, idToSGIndex map[string]int) error {
+	subnetVPCs := make(map[string]*vpcv1.VPCReference, len(w.model.SubnetList))
+	for _, subnet := range w.model.SubnetList {
+		subnetVPCs[*subnet.ID] = subnet.VPC
+	}
+	for _, lb := range w.model.LBList {
+		if len(lb.Subnets) == 0 || subnetVPCs[*lb.Subnets[0].ID] == nil {
+			continue
+		}
+		vpc := subnetVPCs[*lb.Subnets[0].ID]
+		sgName := ScopingString(*vpc.Name, *lb.Name)
+		target := &vpcv1.SecurityGroupTargetReference{
+			Name:         lb.Name,
+			Href:         lb.Href,
+			ID:           lb.ID,
+			CRN:          lb.CRN,
+			ResourceType: utils.Ptr(ResourceTypeLoadBalancer),
+		}
+		sgRefs, err := w.addSGItems(collection.AttachedSGs(*vpc.Name, sgName), vpc, lb.ResourceGroup,
+			[]vpcv1.SecurityGroupTargetReferenceIntf{target}, nameToSGRemoteRef)
 		if err != nil {
 			return err
 		}
+
+		for j := range lb.SecurityGroups {
+			findAndDeleteTargetFromSG(w.model, idToSGIndex[*lb.SecurityGroups[j].ID], lb.ID)
+		}
+		lb.SecurityGroups = sgRefs
+	}
+	return nil
+}
+
+// addSGItems adds the SGs attached to a resource to the model (an SG that exceeds the rules quota is split into several SGs),
+// and returns references to them
+func (w *Writer) addSGItems(sgs []*ir.SG, vpc *vpcv1.VPCReference,
+	resourceGroup *vpcv1.ResourceGroupReference, targets []vpcv1.SecurityGroupTargetReferenceIntf,
+	nameToSGRemoteRef map[string]*vpcv1.SecurityGroupRuleRemoteSecurityGroupReference) ([]vpcv1.SecurityGroupReference, error) {
+	sgRefs := make([]vpcv1.SecurityGroupReference, len(sgs))
+	for i, sg := range sgs {
+		sgItemName := utils.Ptr(ir.ChangeScoping(string(sg.SGName)))
+		sgRules, err := w.makeSGRules(nameToSGRemoteRef, sg)
+		if err != nil {
+			return nil, err
+		}
+		ref := w.lookupOrCreate(nameToSGRemoteRef, *sgItemName)
+
 		sgItem := configModel.NewSecurityGroup(&vpcv1.SecurityGroup{
 			CRN:           ref.CRN,
 			Href:          ref.Href,
 			ID:            ref.ID,
 			Name:          sgItemName,
-			ResourceGroup: endpointGW.ResourceGroup,
+			ResourceGroup: resourceGroup,
 			Rules:         sgRules,
-			Targets:       []vpcv1.SecurityGroupTargetReferenceIntf{target},
+			Targets:       targets,
 			VPC:           vpc,
 		})
 		sgItem.Tags = []string{}
-		model.SecurityGroupList = append(model.SecurityGroupList, sgItem)
+		w.model.SecurityGroupList = append(w.model.SecurityGroupList, sgItem)
 
-		sgRef := vpcv1.SecurityGroupReference{
+		sgRefs[i] = vpcv1.SecurityGroupReference{
 			CRN:  ref.CRN,
 			Href: ref.Href,
 			ID:   ref.ID,
 			Name: sgItemName,
 		}
-
-		for j := range endpointGW.SecurityGroups {
-			sgID := endpointGW.SecurityGroups[j].ID
-			endpointGatewayID := endpointGW.ID
-			findAndDeleteTargetFromSG(model, idToSGIndex[*sgID], endpointGatewayID)
-		}
-		endpointGW.SecurityGroups = []vpcv1.SecurityGroupReference{sgRef}
 	}
-	return nil
+	return sgRefs, nil
 }
 
-func writeSGs(model *configModel.ResourcesContainerModel, collection *ir.SGCollection) error {
+func (w *Writer) writeSGs(collection *ir.SGCollection) error {
 	nameToSGRemoteRef := make(map[string]*vpcv1.SecurityGroupRuleRemoteSecurityGroupReference)
-	idToSGIndex := make(map[string]int, len(model.SecurityGroupList))
-	for i := range model.SecurityGroupList {
-		idToSGIndex[*model.SecurityGroupList[i].ID] = i
+	idToSGIndex := make(map[string]int, len(w.model.SecurityGroupList))
+	for i := range w.model.SecurityGroupList {
+		idToSGIndex[*w.model.SecurityGroupList[i].ID] = i
 	}
 
-	err1 := updateSGInstances(model, collection, nameToSGRemoteRef, idToSGIndex)
-	err2 := updateSGEndpointGW(model, collection, nameToSGRemoteRef, idToSGIndex)
-	return errors.Join(err1, err2)
+	err1 := w.updateSGInstances(collection, nameToSGRemoteRef, idToSGIndex)
+	err2 := w.updateSGEndpointGW(collection, nameToSGRemoteRef, idToSGIndex)
+	err3 := w.updateSGLoadBalancers(collection, nameToSGRemoteRef, idToSGIndex)
+	return errors.Join(err1, err2, err3)
 }
 
 func (w *Writer) WriteSG(collection *ir.SGCollection, _ string, isSynth bool) error {
 	var err error
 	if isSynth {
-		err = writeSGs(w.model, collection)
+		err = w.writeSGs(collection)
 	} else {
-		err = updateSGs(w.model, collection)
+		err = w.updateSGs(collection)
 	}
 	if err != nil {
 		return err
 	}
-	globalIndex = 0 // making test results more predictable
+	w.refIndex = 0 // making test results more predictable
 	return w.writeModel()
 }
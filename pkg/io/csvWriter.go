@@ -37,3 +37,16 @@ func (w *CSVWriter) WriteACL(collection *ir.ACLCollection, vpc string, _ bool) e
 	}
 	return w.w.WriteAll(slices.Concat(makeACLHeader(), aclTable))
 }
+
+// WriteLayered writes the table of the nACLs and the table of the SGs, separated by an empty line
+func (w *CSVWriter) WriteLayered(collection *ir.LayeredCollection, vpc string, _ bool) error {
+	aclTable, err := WriteACL(collection.ACLs, vpc)
+	if err != nil {
+		return err
+	}
+	sgTable, err := WriteSG(collection.SGs, vpc)
+	if err != nil {
+		return err
+	}
+	return w.w.WriteAll(slices.Concat(makeACLHeader(), aclTable, [][]string{{}}, makeSGHeader(), sgTable))
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jsonio
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/spec"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/utils"
+)
+
+const (
+	requiredConnectionsKey  = "required-connections"
+	forbiddenConnectionsKey = "forbidden-connections"
+	segmentsKey             = "segments"
+	externalsKey            = "externals"
+	srcKey                  = "src"
+	dstKey                  = "dst"
+	allowedProtocolsKey     = "allowed-protocols"
+	typeKey                 = "type"
+	itemsKey                = "items"
+
+	yamlMergeKey = "<<"
+)
+
+type (
+	position struct {
+		line   int
+		column int
+	}
+
+	// locations holds the positions of the elements of a spec file by their path in the spec, e.g.,
+	// required-connections[3].dst or segments.my-segment.items[1]. The position of a field is the position of its key.
+	// Positions are missing if the file could not be parsed as YAML (JSON files are parsed as YAML for that purpose).
+	locations struct {
+		positions map[string]position
+	}
+)
+
+// newLocations returns the locations of the elements of a JSON or YAML spec file
+func newLocations(bytes []byte) *locations {
+	res := &locations{positions: map[string]position{}}
+	var root yaml.Node
+	if yaml.Unmarshal(bytes, &root) == nil {
+		res.addNode("", &root, map[*yaml.Node]bool{})
+	}
+	return res
+}
+
+// addNode adds the positions of the descendants of the node at the given path; inStack guards against recursive aliases
+func (l *locations) addNode(path string, node *yaml.Node, inStack map[*yaml.Node]bool) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if inStack[node] {
+		return
+	}
+	inStack[node] = true
+	defer delete(inStack, node)
+
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			l.addNode(path, child, inStack)
+		}
+	case yaml.SequenceNode:
+		for i, child := range node.Content {
+			l.setPosition(indexPath(path, i), child)
+			l.addNode(indexPath(path, i), child, inStack)
+		}
+	case yaml.MappingNode:
+		// explicit keys take precedence over merged keys, so they are added first
+		for _, entry := range mappingEntries(node) {
+			if entry[0].Value != yamlMergeKey {
+				l.setPosition(fieldPath(path, entry[0].Value), entry[0])
+				l.addNode(fieldPath(path, entry[0].Value), entry[1], inStack)
+			}
+		}
+		for _, entry := range mappingEntries(node) {
+			if entry[0].Value == yamlMergeKey {
+				l.addMerged(path, entry[1], inStack)
+			}
+		}
+	}
+}
+
+// addMerged adds the positions of the fields of the merged mappings (a mapping, or a sequence of mappings) that are
+// not set explicitly
+func (l *locations) addMerged(path string, node *yaml.Node, inStack map[*yaml.Node]bool) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.Kind == yaml.SequenceNode {
+		for _, child := range node.Content {
+			l.addMerged(path, child, inStack)
+		}
+		return
+	}
+	for _, entry := range mappingEntries(node) {
+		if _, ok := l.positions[fieldPath(path, entry[0].Value)]; !ok {
+			l.setPosition(fieldPath(path, entry[0].Value), entry[0])
+			l.addNode(fieldPath(path, entry[0].Value), entry[1], inStack)
+		}
+	}
+}
+
+func (l *locations) setPosition(path string, node *yaml.Node) {
+	l.positions[path] = position{line: node.Line, column: node.Column}
+}
+
+func mappingEntries(node *yaml.Node) [][2]*yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	res := make([][2]*yaml.Node, 0, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		res = append(res, [2]*yaml.Node{node.Content[i], node.Content[i+1]})
+	}
+	return res
+}
+
+func indexPath(path string, index int) string {
+	return fmt.Sprintf("%s[%d]", path, index)
+}
+
+func fieldPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
+
+// wrap adds the path of the element the error refers to, and its line and column if known, to the error
+func (l *locations) wrap(err error, path string) error {
+	if err == nil {
+		return nil
+	}
+	if p, ok := l.positions[path]; ok {
+		return fmt.Errorf("%s (line %d, column %d): %w", path, p.line, p.column, err)
+	}
+	return fmt.Errorf("%s: %w", path, err)
+}
+
+// locateSpecError finds the connections and segments that fail to unmarshal, so that the error points to their
+// locations. JSON syntax errors are given the line and column of their offset.
+func (l *locations) locateSpecError(bytes []byte, err error) error {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		line := strings.Count(string(bytes[:syntaxErr.Offset]), "\n") + 1
+		column := int(syntaxErr.Offset) - strings.LastIndex(string(bytes[:syntaxErr.Offset]), "\n") - 1
+		return fmt.Errorf("line %d, column %d: %w", line, column, err)
+	}
+	var top map[string]json.RawMessage
+	if json.Unmarshal(bytes, &top) != nil {
+		return err
+	}
+	var errs []error
+	for _, key := range []string{requiredConnectionsKey, forbiddenConnectionsKey} {
+		var conns []json.RawMessage
+		_ = json.Unmarshal(top[key], &conns)
+		for i, conn := range conns {
+			if connErr := json.Unmarshal(conn, new(spec.Connection)); connErr != nil {
+				errs = append(errs, l.locateConnectionError(conn, connErr, indexPath(key, i)))
+			}
+		}
+	}
+	var segments map[string]json.RawMessage
+	_ = json.Unmarshal(top[segmentsKey], &segments)
+	for _, name := range utils.SortedMapKeys(segments) {
+		if segmentErr := json.Unmarshal(segments[name], new(spec.Segment)); segmentErr != nil {
+			errs = append(errs, l.wrap(segmentErr, fieldPath(segmentsKey, name)))
+		}
+	}
+	if len(errs) == 0 {
+		return err
+	}
+	return errors.Join(errs...)
+}
+
+// locateConnectionError points to the src or dst of a connection if they fail to unmarshal, or to the connection otherwise
+func (l *locations) locateConnectionError(conn json.RawMessage, err error, path string) error {
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(conn, &fields) != nil {
+		return l.wrap(err, path)
+	}
+	var errs []error
+	for _, key := range []string{srcKey, dstKey} {
+		if resource, ok := fields[key]; ok {
+			if resourceErr := json.Unmarshal(resource, new(spec.Resource)); resourceErr != nil {
+				errs = append(errs, l.wrap(resourceErr, fieldPath(path, key)))
+			}
+		}
+	}
+	if len(errs) == 0 {
+		return l.wrap(err, path)
+	}
+	return errors.Join(errs...)
+}
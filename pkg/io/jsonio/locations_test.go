@@ -0,0 +1,45 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jsonio
+
+import (
+	"errors"
+	"testing"
+)
+
+const jsonSpec = `{
+    "required-connections": [
+        {
+            "src": {"name": "sub1", "type": "subnet"},
+            "dst": {"name": "dns", "type": "external"}
+        }
+    ]
+}`
+
+func TestLocations(t *testing.T) {
+	table := []struct {
+		spec     string
+		path     string
+		expected string
+	}{
+		// explicit fields
+		{yamlSpec, "externals.dns", "externals.dns (line 3, column 3): bad"},
+		{yamlSpec, "required-connections[1]", "required-connections[1] (line 8, column 5): bad"},
+		{yamlSpec, "required-connections[1].src", "required-connections[1].src (line 9, column 5): bad"},
+		// a merged field points to the anchored mapping
+		{yamlSpec, "required-connections[1].dst", "required-connections[1].dst (line 7, column 5): bad"},
+		// JSON spec files are located as well
+		{jsonSpec, "required-connections[0].dst", "required-connections[0].dst (line 5, column 13): bad"},
+		// missing paths are reported without a location
+		{jsonSpec, "required-connections[1]", "required-connections[1]: bad"},
+	}
+	for _, test := range table {
+		actual := newLocations([]byte(test.spec)).wrap(errors.New("bad"), test.path)
+		if actual.Error() != test.expected {
+			t.Fatalf(`wrap of %s returns %q instead of %q`, test.path, actual, test.expected)
+		}
+	}
+}
@@ -8,7 +8,7 @@ package jsonio
 import (
 	"fmt"
 
-	"github.com/np-guard/models/pkg/spec"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/spec"
 )
 
 type connectionOrigin struct {
@@ -16,14 +16,30 @@ type connectionOrigin struct {
 	srcName         string
 	dstName         string
 	inverse         bool
+	forbidden       bool
 }
 
 func resourceName(resource spec.Resource) string {
 	return fmt.Sprintf("(%v %v)", resource.Type, resource.Name)
 }
 
+func (o connectionOrigin) Kind() string {
+	if o.forbidden {
+		return "forbidden"
+	}
+	return "required"
+}
+
+func (o connectionOrigin) Index() int {
+	return o.connectionIndex
+}
+
+func (o connectionOrigin) Inverse() bool {
+	return o.inverse
+}
+
 func (o connectionOrigin) String() string {
-	res := fmt.Sprintf("required-connections[%v]: %v->%v", o.connectionIndex, o.srcName, o.dstName)
+	res := fmt.Sprintf("%v-connections[%v]: %v->%v", o.Kind(), o.connectionIndex, o.srcName, o.dstName)
 	if o.inverse {
 		return "inverse of " + res
 	}
@@ -34,6 +50,10 @@ type protocolOrigin struct {
 	protocolIndex int
 }
 
+func (p protocolOrigin) Index() int {
+	return p.protocolIndex
+}
+
 func (p protocolOrigin) String() string {
 	res := fmt.Sprintf("allowed-protocols[%v]", p.protocolIndex)
 	return res
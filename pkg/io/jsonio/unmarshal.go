@@ -3,7 +3,7 @@ Copyright 2023- IBM Inc. All Rights Reserved.
 SPDX-License-Identifier: Apache-2.0
 */
 
-// Package jsonio handles global specification written in a JSON file, as described by spec_schema.input
+// Package jsonio handles global specification written in a JSON or YAML file, as described by spec_schema.input
 package jsonio
 
 import (
@@ -11,50 +11,107 @@ import (
 	"errors"
 	"fmt"
 	"os"
-
-	"github.com/np-guard/models/pkg/spec"
+	"path/filepath"
+	"slices"
 
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/spec"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/utils"
+)
+
+const (
+	JSONSpecFormat = "json"
+	YAMLSpecFormat = "yaml"
 )
 
+// SpecFormats are the supported formats of spec files; YAML spec files have the same schema as JSON spec files
+var SpecFormats = []string{JSONSpecFormat, YAMLSpecFormat}
+
 // Reader implements ir.Reader
 type Reader struct {
+	specFormat string // if empty, the format is picked by the extension of the spec file
+
+	// if set, the forbidden connections of SG specs are skipped, rather than rejected
+	sgSkipsForbidden bool
 }
 
 func NewReader() *Reader {
 	return &Reader{}
 }
 
+// NewReaderWithFormat returns a Reader of spec files in the given format, regardless of their extension
+func NewReaderWithFormat(specFormat string) *Reader {
+	return &Reader{specFormat: specFormat}
+}
+
+// SkippingForbiddenForSGs returns a copy of the reader that reads SG specs without their forbidden connections, rather
+// than failing on them. Layered synthesis reads the spec of its SGs this way, since its nACLs deny the forbidden connections.
+func (r *Reader) SkippingForbiddenForSGs() *Reader {
+	return &Reader{specFormat: r.specFormat, sgSkipsForbidden: true}
+}
+
 func (r *Reader) ReadSpec(filename string, configDefs *ir.ConfigDefs, isSG bool) (*ir.Spec, error) {
-	jsonSpec, err := unmarshal(filename)
+	specFormat, err := r.format(filename)
 	if err != nil {
 		return nil, err
 	}
-	defs, blocked, err := r.readDefinitions(jsonSpec, configDefs)
+	bytes, err := os.ReadFile(filename)
 	if err != nil {
 		return nil, err
 	}
+	return r.readSpec(bytes, specFormat, configDefs, isSG)
+}
 
-	// replace to fully qualified name
-	jsonSpec, defs, err = replaceResourcesName(jsonSpec, defs)
+// ReadSpecFromBytes is ReadSpec for the contents of a spec file, in the format of the reader (JSON if it is not set)
+func (r *Reader) ReadSpecFromBytes(data []byte, configDefs *ir.ConfigDefs, isSG bool) (*ir.Spec, error) {
+	specFormat, err := r.format("")
 	if err != nil {
 		return nil, err
 	}
+	return r.readSpec(data, specFormat, configDefs, isSG)
+}
 
-	connections, err := r.translateConnections(jsonSpec.RequiredConnections, defs, blocked, isSG)
+func (r *Reader) readSpec(data []byte, specFormat string, configDefs *ir.ConfigDefs, isSG bool) (*ir.Spec, error) {
+	jsonSpec, locs, err := unmarshal(data, specFormat)
 	if err != nil {
 		return nil, err
 	}
+	if isSG && len(jsonSpec.ForbiddenConnections) > 0 {
+		if !r.sgSkipsForbidden {
+			return nil, locs.wrap(fmt.Errorf("forbidden connections are not supported for SGs, since SGs cannot deny traffic"),
+				forbiddenConnectionsKey)
+		}
+		jsonSpec.ForbiddenConnections = nil
+	}
+
+	// all the errors of each stage are reported together; a stage runs only if the previous stages succeeded
+	defs, blocked, err1 := r.readDefinitions(jsonSpec, configDefs, locs)
+
+	// replace to fully qualified name
+	err2 := replaceResourcesName(jsonSpec, defs, locs)
+	err3 := replaceConnectionsResourcesName(jsonSpec.ForbiddenConnections, defs, locs, forbiddenConnectionsKey)
+	if err := errors.Join(err1, err2, err3); err != nil {
+		return nil, err
+	}
+
+	connections, err1 := r.translateConnections(jsonSpec.RequiredConnections, defs, blocked, isSG, locs)
+	forbidden, err2 := r.translateForbiddenConnections(jsonSpec.ForbiddenConnections, defs, locs)
+	if err := errors.Join(err1, err2); err != nil {
+		return nil, err
+	}
 
 	return &ir.Spec{
 		Connections:      connections,
+		Forbidden:        forbidden,
 		Defs:             defs,
 		BlockedResources: blocked,
+		Diagnostics: slices.Concat(redundantProtocolsWarnings(jsonSpec.RequiredConnections, locs, requiredConnectionsKey),
+			redundantProtocolsWarnings(jsonSpec.ForbiddenConnections, locs, forbiddenConnectionsKey)),
 	}, nil
 }
 
 // replace all resources names to fully qualified name
-func replaceResourcesName(jsonSpec *spec.Spec, defs *ir.Definitions) (*spec.Spec, *ir.Definitions, error) {
+func replaceResourcesName(jsonSpec *spec.Spec, defs *ir.Definitions, locs *locations) error {
 	config := defs.ConfigDefs
 
 	// calculate distinct and ambiguous names for every endpoint type
@@ -62,74 +119,77 @@ func replaceResourcesName(jsonSpec *spec.Spec, defs *ir.Definitions) (*spec.Spec
 	distinctNifs, ambiguousNifs := detectDistinctAndAmbiguousNames(config.NIFs)
 	distinctInstances, ambiguousInstances := detectDistinctAndAmbiguousNames(config.Instances)
 	distinctVpes, ambiguousVpes := detectDistinctAndAmbiguousNames(config.VPEs)
+	distinctLBs, ambiguousLBs := detectDistinctAndAmbiguousNames(config.LoadBalancers)
 
 	// translate segments to fully qualified names
-	nifSegments, err1 := replaceSegmentNames(defs.NifSegments, distinctNifs, ambiguousNifs, spec.ResourceType(spec.SegmentTypeNif))
-	vpeSegments, err2 := replaceSegmentNames(defs.VpeSegments, distinctVpes, ambiguousVpes, spec.ResourceType(spec.SegmentTypeVpe))
+	nifSegments, err1 := replaceSegmentNames(defs.NifSegments, distinctNifs, ambiguousNifs, spec.ResourceType(spec.SegmentTypeNif), locs)
+	vpeSegments, err2 := replaceSegmentNames(defs.VpeSegments, distinctVpes, ambiguousVpes, spec.ResourceType(spec.SegmentTypeVpe), locs)
 	subnetSegments, err3 := replaceSegmentNames(defs.SubnetSegments, distinctSubnets, ambiguousSubnets,
-		spec.ResourceType(spec.SegmentTypeSubnet))
+		spec.ResourceType(spec.SegmentTypeSubnet), locs)
 	instanceSegments, err4 := replaceSegmentNames(defs.InstanceSegments, distinctInstances, ambiguousInstances,
-		spec.ResourceType(spec.SegmentTypeInstance))
-	if err := errors.Join(err1, err2, err3, err4); err != nil {
-		return nil, nil, err
-	}
+		spec.ResourceType(spec.SegmentTypeInstance), locs)
+	lbSegments, err5 := replaceSegmentNames(defs.LoadBalancerSegments, distinctLBs, ambiguousLBs,
+		spec.ResourceType(spec.SegmentTypeLoadBalancer), locs)
 	defs.SubnetSegments = subnetSegments
 	defs.NifSegments = nifSegments
 	defs.InstanceSegments = instanceSegments
 	defs.VpeSegments = vpeSegments
+	defs.LoadBalancerSegments = lbSegments
 
 	// translate connections resources to fully qualified names
-	var err error
-	for i := range jsonSpec.RequiredConnections {
-		conn := &jsonSpec.RequiredConnections[i]
-		fullyQualifiedSrc := conn.Src.Name
-		switch conn.Src.Type {
-		case spec.ResourceTypeSubnet:
-			fullyQualifiedSrc, err = replaceResourceName(distinctSubnets, ambiguousSubnets, conn.Src.Name, spec.ResourceTypeSubnet)
-		case spec.ResourceTypeNif:
-			fullyQualifiedSrc, err = replaceResourceName(distinctNifs, ambiguousNifs, conn.Src.Name, spec.ResourceTypeNif)
-		case spec.ResourceTypeInstance:
-			fullyQualifiedSrc, err = replaceResourceName(distinctInstances, ambiguousInstances, conn.Src.Name, spec.ResourceTypeInstance)
-		case spec.ResourceTypeVpe:
-			fullyQualifiedSrc, err = replaceResourceName(distinctVpes, ambiguousVpes, conn.Src.Name, spec.ResourceTypeVpe)
-		}
-		if err != nil {
-			return nil, nil, err
-		}
-		conn.Src.Name = fullyQualifiedSrc
+	err6 := replaceConnectionsResourcesName(jsonSpec.RequiredConnections, defs, locs, requiredConnectionsKey)
+	return errors.Join(err1, err2, err3, err4, err5, err6)
+}
 
-		fullyQualifiedDst := conn.Dst.Name
-		switch conn.Dst.Type {
+// replace the names of the connections' resources to fully qualified names; key is the spec field of the connections
+func replaceConnectionsResourcesName(conns []spec.Connection, defs *ir.Definitions, locs *locations,
+	key string) error {
+	config := defs.ConfigDefs
+	distinctSubnets, ambiguousSubnets := detectDistinctAndAmbiguousNames(config.Subnets)
+	distinctNifs, ambiguousNifs := detectDistinctAndAmbiguousNames(config.NIFs)
+	distinctInstances, ambiguousInstances := detectDistinctAndAmbiguousNames(config.Instances)
+	distinctVpes, ambiguousVpes := detectDistinctAndAmbiguousNames(config.VPEs)
+	distinctLBs, ambiguousLBs := detectDistinctAndAmbiguousNames(config.LoadBalancers)
+
+	replace := func(resource *spec.Resource) (err error) {
+		fullyQualified := resource.Name
+		switch resource.Type {
 		case spec.ResourceTypeSubnet:
-			fullyQualifiedDst, err = replaceResourceName(distinctSubnets, ambiguousSubnets, conn.Dst.Name, spec.ResourceTypeSubnet)
+			fullyQualified, err = replaceResourceName(distinctSubnets, ambiguousSubnets, resource.Name, spec.ResourceTypeSubnet)
 		case spec.ResourceTypeNif:
-			fullyQualifiedDst, err = replaceResourceName(distinctNifs, ambiguousNifs, conn.Dst.Name, spec.ResourceTypeNif)
+			fullyQualified, err = replaceResourceName(distinctNifs, ambiguousNifs, resource.Name, spec.ResourceTypeNif)
 		case spec.ResourceTypeInstance:
-			fullyQualifiedDst, err = replaceResourceName(distinctInstances, ambiguousInstances, conn.Dst.Name, spec.ResourceTypeInstance)
+			fullyQualified, err = replaceResourceName(distinctInstances, ambiguousInstances, resource.Name, spec.ResourceTypeInstance)
 		case spec.ResourceTypeVpe:
-			fullyQualifiedDst, err = replaceResourceName(distinctVpes, ambiguousVpes, conn.Dst.Name, spec.ResourceTypeVpe)
+			fullyQualified, err = replaceResourceName(distinctVpes, ambiguousVpes, resource.Name, spec.ResourceTypeVpe)
+		case spec.ResourceTypeLoadBalancer:
+			fullyQualified, err = replaceResourceName(distinctLBs, ambiguousLBs, resource.Name, spec.ResourceTypeLoadBalancer)
 		}
-		if err != nil {
-			return nil, nil, err
-		}
-		conn.Dst.Name = fullyQualifiedDst
+		resource.Name = fullyQualified
+		return err
+	}
+	var errs []error
+	for i := range conns {
+		errs = append(errs, locs.wrap(replace(&conns[i].Src), fieldPath(indexPath(key, i), srcKey)),
+			locs.wrap(replace(&conns[i].Dst), fieldPath(indexPath(key, i), dstKey)))
 	}
-	return jsonSpec, defs, nil
+	return errors.Join(errs...)
 }
 
 func replaceSegmentNames(segments map[ir.ID]*ir.SegmentDetails, distinctNames map[string]ir.ID, ambiguousNames map[string]struct{},
-	resourceType spec.ResourceType) (map[ir.ID]*ir.SegmentDetails, error) {
-	for i, segmentDetails := range segments {
-		for j, el := range segmentDetails.Elements {
+	resourceType spec.ResourceType, locs *locations) (map[ir.ID]*ir.SegmentDetails, error) {
+	var errs []error
+	for _, segmentName := range utils.SortedMapKeys(segments) {
+		for j, el := range segments[segmentName].Elements {
 			fullName, err := replaceResourceName(distinctNames, ambiguousNames, el, resourceType)
 			if err != nil {
-				return nil, err
+				errs = append(errs, locs.wrap(err, indexPath(fieldPath(fieldPath(segmentsKey, segmentName), itemsKey), j)))
+				continue
 			}
-			segmentDetails.Elements[j] = fullName
+			segments[segmentName].Elements[j] = fullName
 		}
-		segments[i] = segmentDetails
 	}
-	return segments, nil
+	return segments, errors.Join(errs...)
 }
 
 func replaceResourceName(distinctNames map[string]ir.ID, ambiguousNames map[string]struct{}, resourceName string,
@@ -169,49 +229,84 @@ func detectDistinctAndAmbiguousNames[T any](m map[ir.ID]T) (distinctNames map[st
 	return distinctNames, ambiguousNames
 }
 
-// unmarshal returns a Spec struct given a file adhering to spec_schema.input
-func unmarshal(filename string) (*spec.Spec, error) {
-	bytes, err := os.ReadFile(filename)
-	if err != nil {
-		return nil, err
+// format returns the format of the given spec file: the format of the reader if set, otherwise by the file extension
+func (r *Reader) format(filename string) (string, error) {
+	if r.specFormat != "" {
+		if !slices.Contains(SpecFormats, r.specFormat) {
+			return "", fmt.Errorf("unsupported spec format %q", r.specFormat)
+		}
+		return r.specFormat, nil
+	}
+	switch filepath.Ext(filename) {
+	case ".yaml", ".yml":
+		return YAMLSpecFormat, nil
+	}
+	return JSONSpecFormat, nil
+}
+
+// unmarshal returns a Spec struct given the contents of a file adhering to spec_schema.input, and the locations of the
+// spec elements if the file is a YAML file
+func unmarshal(bytes []byte, specFormat string) (*spec.Spec, *locations, error) {
+	var err error
+	locs := newLocations(bytes)
+	if specFormat == YAMLSpecFormat {
+		if bytes, err = yamlToJSON(bytes); err != nil {
+			return nil, nil, err
+		}
 	}
 	jsonSpec := new(spec.Spec)
-	err = json.Unmarshal(bytes, jsonSpec)
-	if err != nil {
-		return nil, err
+	if err := json.Unmarshal(bytes, jsonSpec); err != nil {
+		return nil, nil, locs.locateSpecError(bytes, err)
 	}
-	for i := range jsonSpec.RequiredConnections {
-		conn := &jsonSpec.RequiredConnections[i]
+	err1 := unmarshalProtocols(jsonSpec.RequiredConnections, locs, requiredConnectionsKey)
+	err2 := unmarshalProtocols(jsonSpec.ForbiddenConnections, locs, forbiddenConnectionsKey)
+	if err := errors.Join(err1, err2); err != nil {
+		return nil, nil, err
+	}
+	return jsonSpec, locs, nil
+}
+
+// unmarshalProtocols replaces the generic protocols of the connections with the concrete spec protocol types
+func unmarshalProtocols(conns []spec.Connection, locs *locations, key string) error {
+	var errs []error
+	for i := range conns {
+		conn := &conns[i]
 		if conn.AllowedProtocols == nil {
 			conn.AllowedProtocols = spec.ProtocolList{spec.AnyProtocol{}}
-		} else {
-			for j := range conn.AllowedProtocols {
-				p := conn.AllowedProtocols[j].(map[string]interface{})
-				bytes, err = json.Marshal(p)
-				if err != nil {
-					return nil, err
-				}
-				switch p["protocol"] {
-				case "ANY":
-					var result spec.AnyProtocol
-					err = json.Unmarshal(bytes, &result)
-					conn.AllowedProtocols[j] = result
-				case "TCP", "UDP":
-					var result spec.TcpUdp
-					err = json.Unmarshal(bytes, &result)
-					conn.AllowedProtocols[j] = result
-				case "ICMP":
-					var result spec.Icmp
-					err = json.Unmarshal(bytes, &result)
-					conn.AllowedProtocols[j] = result
-				default:
-					return nil, fmt.Errorf("invalid protocol type %q", p["protocol"])
-				}
-				if err != nil {
-					return nil, err
-				}
-			}
+			continue
+		}
+		for j := range conn.AllowedProtocols {
+			errs = append(errs, locs.wrap(unmarshalProtocol(conn, j), indexPath(fieldPath(indexPath(key, i), allowedProtocolsKey), j)))
 		}
 	}
-	return jsonSpec, err
+	return errors.Join(errs...)
+}
+
+// unmarshalProtocol replaces the j-th generic protocol of the connection with the concrete spec protocol type
+func unmarshalProtocol(conn *spec.Connection, j int) error {
+	p, ok := conn.AllowedProtocols[j].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("invalid protocol %v", conn.AllowedProtocols[j])
+	}
+	bytes, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	switch p["protocol"] {
+	case "ANY":
+		var result spec.AnyProtocol
+		err = json.Unmarshal(bytes, &result)
+		conn.AllowedProtocols[j] = result
+	case "TCP", "UDP":
+		var result spec.TcpUdp
+		err = json.Unmarshal(bytes, &result)
+		conn.AllowedProtocols[j] = result
+	case "ICMP":
+		var result spec.Icmp
+		err = json.Unmarshal(bytes, &result)
+		conn.AllowedProtocols[j] = result
+	default:
+		return fmt.Errorf("invalid protocol type %q", p["protocol"])
+	}
+	return err
 }
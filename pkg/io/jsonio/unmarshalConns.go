@@ -3,52 +3,65 @@ Copyright 2023- IBM Inc. All Rights Reserved.
 SPDX-License-Identifier: Apache-2.0
 */
 
-// Package jsonio handles global specification written in a JSON file, as described by spec_schema.input
+// Package jsonio handles global specification written in a JSON or YAML file, as described by spec_schema.input
 package jsonio
 
 import (
 	"errors"
 	"fmt"
-	"log"
 	"slices"
 
 	"github.com/np-guard/models/pkg/netp"
-	"github.com/np-guard/models/pkg/spec"
 
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/spec"
 )
 
 // translateConnections translate required connections from spec.Spec to []*ir.Connection
-func (r *Reader) translateConnections(conns []spec.SpecRequiredConnectionsElem, defs *ir.Definitions,
-	blockedResources *ir.BlockedResources, isSG bool) ([]*ir.Connection, error) {
+func (r *Reader) translateConnections(conns []spec.Connection, defs *ir.Definitions,
+	blockedResources *ir.BlockedResources, isSG bool, locs *locations) ([]*ir.Connection, error) {
 	var res []*ir.Connection
+	var errs []error
 	for i := range conns {
-		connections, err := translateConnection(defs, blockedResources, &conns[i], i, isSG)
-		if err != nil {
-			return nil, err
-		}
+		connections, err := translateConnection(defs, blockedResources, &conns[i], connectionOrigin{connectionIndex: i}, isSG,
+			locs, indexPath(requiredConnectionsKey, i))
+		errs = append(errs, err)
 		res = slices.Concat(res, connections)
 	}
-	return res, nil
+	return res, errors.Join(errs...)
 }
 
-func translateConnection(defs *ir.Definitions, blockedResources *ir.BlockedResources, conn *spec.SpecRequiredConnectionsElem,
-	connIdx int, isSG bool) ([]*ir.Connection, error) {
-	protocols, err1 := translateProtocols(conn.AllowedProtocols)
+// translateForbiddenConnections translate forbidden connections to []*ir.Connection; these are used for nACLs only,
+// and do not affect the blocked resources
+func (r *Reader) translateForbiddenConnections(conns []spec.Connection, defs *ir.Definitions,
+	locs *locations) ([]*ir.Connection, error) {
+	var res []*ir.Connection
+	var errs []error
+	for i := range conns {
+		connections, err := translateConnection(defs, nil, &conns[i], connectionOrigin{connectionIndex: i, forbidden: true}, false,
+			locs, indexPath(forbiddenConnectionsKey, i))
+		errs = append(errs, err)
+		res = slices.Concat(res, connections)
+	}
+	return res, errors.Join(errs...)
+}
+
+// translateConnection translates a single connection; path is the path of the connection in the spec, used in errors
+func translateConnection(defs *ir.Definitions, blockedResources *ir.BlockedResources, conn *spec.Connection,
+	origin connectionOrigin, isSG bool, locs *locations, path string) ([]*ir.Connection, error) {
+	protocols, err1 := translateProtocols(conn.AllowedProtocols, locs, fieldPath(path, allowedProtocolsKey))
 	srcResource, isSrcExternal, err2 := translateConnectionResource(defs, blockedResources, &conn.Src, isSG)
 	dstResource, isDstExternal, err3 := translateConnectionResource(defs, blockedResources, &conn.Dst, isSG)
-	if err := errors.Join(err1, err2, err3); err != nil {
+	if err := errors.Join(err1, locs.wrap(err2, fieldPath(path, srcKey)), locs.wrap(err3, fieldPath(path, dstKey))); err != nil {
 		return nil, err
 	}
 	if isSrcExternal && isDstExternal {
-		return nil, fmt.Errorf("both source (%s) and destination (%s) are external in required connection", conn.Src.Name, conn.Dst.Name)
+		return nil, locs.wrap(fmt.Errorf("both source (%s) and destination (%s) are external in %s connection", conn.Src.Name,
+			conn.Dst.Name, origin.Kind()), path)
 	}
 
-	origin := connectionOrigin{
-		connectionIndex: connIdx,
-		srcName:         resourceName(conn.Src),
-		dstName:         resourceName(conn.Dst),
-	}
+	origin.srcName = resourceName(conn.Src)
+	origin.dstName = resourceName(conn.Dst)
 	out := &ir.Connection{Src: srcResource, Dst: dstResource, TrackedProtocols: protocols, Origin: origin}
 	if conn.Bidirectional {
 		backOrigin := origin
@@ -76,37 +89,58 @@ func translateConnectionResource(defs *ir.Definitions, blockedResources *ir.Bloc
 	return res, resourceType == ir.ResourceTypeExternal, err
 }
 
-func translateProtocols(protocols spec.ProtocolList) ([]*ir.TrackedProtocol, error) {
+func translateProtocols(protocols spec.ProtocolList, locs *locations, path string) ([]*ir.TrackedProtocol, error) {
 	var result = make([]*ir.TrackedProtocol, len(protocols))
-	for i, _p := range protocols {
-		res := &ir.TrackedProtocol{Origin: protocolOrigin{protocolIndex: i}}
-		switch p := _p.(type) {
-		case spec.AnyProtocol:
-			if len(protocols) != 1 {
-				log.Println("when allowing any protocol, there is no need in other protocols")
-			}
-			res.Protocol = netp.AnyProtocol{}
-		case spec.Icmp:
-			icmp, err := netp.ICMPFromTypeAndCode(p.Type, p.Code)
-			if err != nil {
-				return nil, err
-			}
-			res.Protocol = icmp
-		case spec.TcpUdp:
-			tcpudp, err := netp.NewTCPUDP(p.Protocol == spec.TcpUdpProtocolTCP, p.MinSourcePort, p.MaxSourcePort,
-				p.MinDestinationPort, p.MaxDestinationPort)
-			if err != nil {
-				return nil, err
-			}
-			res.Protocol = tcpudp
-		default:
-			return nil, fmt.Errorf("unsupported protocol: %v", p)
+	var errs []error
+	for i, p := range protocols {
+		protocol, err := translateProtocol(p)
+		if err != nil {
+			errs = append(errs, locs.wrap(err, indexPath(path, i)))
+			continue
 		}
-		result[i] = res
+		result[i] = &ir.TrackedProtocol{Protocol: protocol, Origin: protocolOrigin{protocolIndex: i}}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
 	}
 	return result, nil
 }
 
+func translateProtocol(protocol spec.Protocol) (netp.Protocol, error) {
+	switch p := protocol.(type) {
+	case spec.AnyProtocol:
+		return netp.AnyProtocol{}, nil
+	case spec.Icmp:
+		return netp.ICMPFromTypeAndCode(p.Type, p.Code)
+	case spec.TcpUdp:
+		return netp.NewTCPUDP(p.Protocol == spec.TcpUdpProtocolTCP, p.MinSourcePort, p.MaxSourcePort,
+			p.MinDestinationPort, p.MaxDestinationPort)
+	}
+	return nil, fmt.Errorf("unsupported protocol: %v", protocol)
+}
+
+// redundantProtocolsWarnings returns a warning for each connection that allows any protocol together with other protocols
+func redundantProtocolsWarnings(conns []spec.Connection, locs *locations, key string) ir.Diagnostics {
+	var res ir.Diagnostics
+	for i := range conns {
+		protocols := conns[i].AllowedProtocols
+		if len(protocols) == 1 || !slices.ContainsFunc(protocols, isAnyProtocol) {
+			continue
+		}
+		path := fieldPath(indexPath(key, i), allowedProtocolsKey)
+		warning := ir.NewDiagnostic(ir.SeverityWarning, ir.CodeRedundantProtocols,
+			locs.wrap(errors.New("when allowing any protocol, there is no need in other protocols"), path).Error())
+		warning.Origins = []string{path}
+		res = append(res, warning)
+	}
+	return res
+}
+
+func isAnyProtocol(p any) bool {
+	_, ok := p.(spec.AnyProtocol)
+	return ok
+}
+
 func translateResourceType(defs *ir.Definitions, resource *spec.Resource) (ir.ResourceType, error) {
 	switch resource.Type {
 	case spec.ResourceTypeExternal:
@@ -119,6 +153,8 @@ func translateResourceType(defs *ir.Definitions, resource *spec.Resource) (ir.Re
 		return ir.ResourceTypeInstance, nil
 	case spec.ResourceTypeVpe:
 		return ir.ResourceTypeVPE, nil
+	case spec.ResourceTypeLoadBalancer:
+		return ir.ResourceTypeLoadBalancer, nil
 	case spec.ResourceTypeSegment:
 		if _, ok := defs.SubnetSegments[resource.Name]; ok {
 			return ir.ResourceTypeSubnetSegment, nil
@@ -135,12 +171,18 @@ func translateResourceType(defs *ir.Definitions, resource *spec.Resource) (ir.Re
 		if _, ok := defs.VpeSegments[resource.Name]; ok {
 			return ir.ResourceTypeVpeSegment, nil
 		}
+		if _, ok := defs.LoadBalancerSegments[resource.Name]; ok {
+			return ir.ResourceTypeLoadBalancerSegment, nil
+		}
 		return ir.ResourceTypeSubnet, fmt.Errorf("could not find segment %v", resource.Name)
 	}
 	return ir.ResourceTypeSubnet, fmt.Errorf("unsupported resource type %v (%v)", resource.Type, resource.Name)
 }
 
 func updateBlockedResourcesSGSynth(blockedResources *ir.BlockedResources, resource *ir.ConnectedResource) {
+	if blockedResources == nil {
+		return
+	}
 	for _, namedAddrs := range resource.CidrsWhenLocal {
 		// should check also resource type
 		if _, ok := blockedResources.BlockedInstances[namedAddrs.Name]; ok {
@@ -149,10 +191,16 @@ func updateBlockedResourcesSGSynth(blockedResources *ir.BlockedResources, resour
 		if _, ok := blockedResources.BlockedVPEs[namedAddrs.Name]; ok {
 			blockedResources.BlockedVPEs[namedAddrs.Name] = false
 		}
+		if _, ok := blockedResources.BlockedLoadBalancers[namedAddrs.Name]; ok {
+			blockedResources.BlockedLoadBalancers[namedAddrs.Name] = false
+		}
 	}
 }
 
 func updateBlockedResourcesACLSynth(blockedResources *ir.BlockedResources, resource *ir.ConnectedResource) {
+	if blockedResources == nil {
+		return
+	}
 	for _, namedAddrs := range resource.CidrsWhenLocal {
 		if _, ok := blockedResources.BlockedSubnets[namedAddrs.Name]; ok {
 			blockedResources.BlockedSubnets[namedAddrs.Name] = false
@@ -3,16 +3,16 @@ Copyright 2023- IBM Inc. All Rights Reserved.
 SPDX-License-Identifier: Apache-2.0
 */
 
-// Package jsonio handles global specification written in a JSON file, as described by spec_schema.input
+// Package jsonio handles global specification written in a JSON or YAML file, as described by spec_schema.input
 package jsonio
 
 import (
+	"errors"
 	"fmt"
 
-	"github.com/np-guard/models/pkg/netset"
-	"github.com/np-guard/models/pkg/spec"
-
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ipset"
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/spec"
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/utils"
 )
 
@@ -22,47 +22,48 @@ type segmentsTypes struct {
 	nifSegment      map[string][]string
 	instanceSegment map[string][]string
 	vpeSegment      map[string][]string
+	lbSegment       map[string][]string
 }
 
-// ReadDefinitions translates segments and externals
-func (r *Reader) readDefinitions(jsonSpec *spec.Spec, configDefs *ir.ConfigDefs) (*ir.Definitions, *ir.BlockedResources, error) {
-	if err := validateSegments(&jsonSpec.Segments); err != nil {
-		return nil, nil, err
-	}
+// ReadDefinitions translates segments and externals; the definitions exclude the invalid segments and externals,
+// which are reported together in the returned error
+func (r *Reader) readDefinitions(jsonSpec *spec.Spec, configDefs *ir.ConfigDefs, locs *locations) (*ir.Definitions,
+	*ir.BlockedResources, error) {
+	err1 := validateSegments(&jsonSpec.Segments, locs)
 	segments := divideSegmentsByType(&jsonSpec.Segments)
 	subnetSegments := parseSegments(segments.subnetSegment)
 	nifSegments := parseSegments(segments.nifSegment)
 	instanceSegments := parseSegments(segments.instanceSegment)
 	vpeSegments := parseSegments(segments.vpeSegment)
-	cidrSegments, err := parseCidrSegments(segments.cidrSegment, configDefs)
-	if err != nil {
-		return nil, nil, err
-	}
-	externals, err := translateExternals(jsonSpec.Externals)
-	if err != nil {
-		return nil, nil, err
-	}
+	lbSegments := parseSegments(segments.lbSegment)
+	cidrSegments, err2 := parseCidrSegments(segments.cidrSegment, configDefs, locs)
+	externals, err3 := translateExternals(jsonSpec.Externals, locs)
 	return &ir.Definitions{
-		ConfigDefs:       *configDefs,
-		SubnetSegments:   subnetSegments,
-		CidrSegments:     cidrSegments,
-		NifSegments:      nifSegments,
-		InstanceSegments: instanceSegments,
-		VpeSegments:      vpeSegments,
-		Externals:        externals,
-	}, prepareBlockedResources(configDefs), nil
+		ConfigDefs:           *configDefs,
+		SubnetSegments:       subnetSegments,
+		CidrSegments:         cidrSegments,
+		NifSegments:          nifSegments,
+		InstanceSegments:     instanceSegments,
+		VpeSegments:          vpeSegments,
+		LoadBalancerSegments: lbSegments,
+		Externals:            externals,
+	}, prepareBlockedResources(configDefs), errors.Join(err1, err2, err3)
 }
 
 // validateSegments validates that all segments are supported
-func validateSegments(jsonSegments *spec.SpecSegments) error {
-	for _, v := range *jsonSegments {
+func validateSegments(jsonSegments *spec.SpecSegments, locs *locations) error {
+	var errs []error
+	for _, k := range utils.SortedMapKeys(*jsonSegments) {
+		v := (*jsonSegments)[k]
 		if v.Type != spec.SegmentTypeSubnet && v.Type != spec.SegmentTypeCidr &&
 			v.Type != spec.SegmentTypeInstance && v.Type != spec.SegmentTypeNif &&
-			v.Type != spec.SegmentTypeVpe {
-			return fmt.Errorf("only subnet, cidr, instance, nif and vpe segments are supported, not %q", v.Type)
+			v.Type != spec.SegmentTypeVpe && v.Type != spec.SegmentTypeLoadBalancer {
+			errs = append(errs, locs.wrap(fmt.Errorf("only subnet, cidr, instance, nif, vpe and load_balancer segments are supported, not %q",
+				v.Type),
+				fieldPath(fieldPath(segmentsKey, k), typeKey)))
 		}
 	}
-	return nil
+	return errors.Join(errs...)
 }
 
 // translates segment to ir ds
@@ -75,43 +76,56 @@ func parseSegments(segments map[string][]string) map[ir.ID]*ir.SegmentDetails {
 }
 
 // parseCidrSegments translates cidr segments
-func parseCidrSegments(cidrSegments map[string][]string, configDefs *ir.ConfigDefs) (map[ir.ID]*ir.CidrSegmentDetails, error) {
+func parseCidrSegments(cidrSegments map[string][]string, configDefs *ir.ConfigDefs,
+	locs *locations) (map[ir.ID]*ir.CidrSegmentDetails, error) {
 	result := make(map[ir.ID]*ir.CidrSegmentDetails)
-	for segmentName, segment := range cidrSegments {
-		cidrs := netset.NewIPBlock()
-		for _, cidr := range segment {
-			c, err := netset.IPBlockFromCidr(cidr)
+	var errs []error
+	for _, segmentName := range utils.SortedMapKeys(cidrSegments) {
+		segmentPath := fieldPath(segmentsKey, segmentName)
+		cidrs := ipset.NewIPBlock()
+		var segmentErrs []error
+		for i, cidr := range cidrSegments[segmentName] {
+			c, err := ipset.IPBlockFromCidr(cidr)
 			if err != nil {
-				return nil, err
+				segmentErrs = append(segmentErrs, locs.wrap(err, indexPath(fieldPath(segmentPath, itemsKey), i)))
+				continue
 			}
 			cidrs = cidrs.Union(c)
 		}
+		if len(segmentErrs) > 0 {
+			errs = append(errs, segmentErrs...)
+			continue
+		}
 		if !internalCidr(configDefs, cidrs) {
-			return nil, fmt.Errorf("only internal cidrs are supported in cidr segment resource type (segment name: %v)", segmentName)
+			errs = append(errs, locs.wrap(fmt.Errorf("only internal cidrs are supported in cidr segment resource type (segment name: %v)",
+				segmentName), segmentPath))
+			continue
 		}
 		cidrSegmentDetails := ir.CidrSegmentDetails{Cidrs: cidrs}
 		result[segmentName] = &cidrSegmentDetails
 	}
-	return result, nil
+	return result, errors.Join(errs...)
 }
 
 // translateExternals reads externals from spec file
-func translateExternals(m map[string]string) (map[ir.ID]*ir.ExternalDetails, error) {
+func translateExternals(m map[string]string, locs *locations) (map[ir.ID]*ir.ExternalDetails, error) {
 	result := make(map[ir.ID]*ir.ExternalDetails)
-	for k, v := range m {
-		address, err := netset.IPBlockFromCidrOrAddress(v)
+	var errs []error
+	for _, k := range utils.SortedMapKeys(m) {
+		address, err := ipset.IPBlockFromCidrOrAddress(m[k])
 		if err != nil {
-			return nil, err
+			errs = append(errs, locs.wrap(err, fieldPath(externalsKey, k)))
+			continue
 		}
 		result[k] = &ir.ExternalDetails{ExternalAddrs: address}
 	}
-	return result, nil
+	return result, errors.Join(errs...)
 }
 
 func divideSegmentsByType(jsonSegments *spec.SpecSegments) segmentsTypes {
 	res := segmentsTypes{subnetSegment: make(map[string][]string), nifSegment: make(map[string][]string),
 		instanceSegment: make(map[string][]string), vpeSegment: make(map[string][]string),
-		cidrSegment: make(map[string][]string)}
+		cidrSegment: make(map[string][]string), lbSegment: make(map[string][]string)}
 	for k, v := range *jsonSegments {
 		switch v.Type {
 		case spec.SegmentTypeSubnet:
@@ -124,12 +138,14 @@ func divideSegmentsByType(jsonSegments *spec.SpecSegments) segmentsTypes {
 			res.nifSegment[k] = v.Items
 		case spec.SegmentTypeVpe:
 			res.vpeSegment[k] = v.Items
+		case spec.SegmentTypeLoadBalancer:
+			res.lbSegment[k] = v.Items
 		}
 	}
 	return res
 }
 
-func internalCidr(configDefs *ir.ConfigDefs, cidr *netset.IPBlock) bool {
+func internalCidr(configDefs *ir.ConfigDefs, cidr *ipset.IPBlock) bool {
 	res := cidr
 	for _, vpcDetails := range configDefs.VPCs {
 		res = res.Subtract(vpcDetails.AddressPrefixes)
@@ -139,8 +155,9 @@ func internalCidr(configDefs *ir.ConfigDefs, cidr *netset.IPBlock) bool {
 
 func prepareBlockedResources(configDefs *ir.ConfigDefs) *ir.BlockedResources {
 	return &ir.BlockedResources{BlockedSubnets: sliceToMap(utils.SortedMapKeys(configDefs.Subnets)),
-		BlockedInstances: sliceToMap(utils.SortedMapKeys(configDefs.Instances)),
-		BlockedVPEs:      sliceToMap(utils.SortedMapKeys(configDefs.VPEs))}
+		BlockedInstances:     sliceToMap(utils.SortedMapKeys(configDefs.Instances)),
+		BlockedVPEs:          sliceToMap(utils.SortedMapKeys(configDefs.VPEs)),
+		BlockedLoadBalancers: sliceToMap(utils.SortedMapKeys(configDefs.LoadBalancers))}
 }
 
 func sliceToMap(slice []string) map[string]bool {
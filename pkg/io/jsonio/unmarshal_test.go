@@ -11,8 +11,7 @@ import (
 	"reflect"
 	"testing"
 
-	"github.com/np-guard/models/pkg/spec"
-
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/spec"
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/utils"
 )
 
@@ -86,8 +85,8 @@ func TestAnyProtocol_UnmarshalJSON(t *testing.T) {
 }
 
 func TestResource_UnmarshalJSON(t *testing.T) {
-	table := make([]TestItem[*spec.Resource], 7)
-	for i, tp := range []string{"external", "segment", "subnet", "instance", "nif", "cidr", "vpe"} {
+	table := make([]TestItem[*spec.Resource], 8)
+	for i, tp := range []string{"external", "segment", "subnet", "instance", "nif", "cidr", "vpe", "load_balancer"} {
 		name := fmt.Sprintf("ep-%v", i)
 		js := fmt.Sprintf(`{"name": "%v", "type": "%v"}`, name, tp)
 		resource := spec.Resource{Name: name, Type: spec.ResourceType(tp)}
@@ -104,3 +103,39 @@ func TestResource_UnmarshalJSON(t *testing.T) {
 		}
 	}
 }
+
+const loadBalancerSpec = `{
+    "segments": {
+        "lbs": {"type": "load_balancer", "items": ["lb1"]}
+    },
+    "required-connections": [
+        {
+            "src": {"name": "sub1", "type": "subnet"},
+            "dst": {"name": "lb1", "type": "load_balancer"}
+        }
+    ],
+    "forbidden-connections": [
+        {
+            "src": {"name": "lbs", "type": "segment"},
+            "dst": {"name": "sub1", "type": "subnet"}
+        }
+    ]
+}`
+
+func TestLoadBalancerTypes(t *testing.T) {
+	jsonSpec, _, err := unmarshal([]byte(loadBalancerSpec), JSONSpecFormat)
+	if err != nil {
+		t.Fatalf("spec with load balancers does not unmarshal: %v", err)
+	}
+	conn := jsonSpec.RequiredConnections[0]
+	if conn.Src.Type != spec.ResourceTypeSubnet || conn.Dst.Type != spec.ResourceTypeLoadBalancer {
+		t.Fatalf("required connection types are %q and %q", conn.Src.Type, conn.Dst.Type)
+	}
+	forbidden := jsonSpec.ForbiddenConnections[0]
+	if forbidden.Src.Type != spec.ResourceTypeSegment || forbidden.Dst.Type != spec.ResourceTypeSubnet {
+		t.Fatalf("forbidden connection types are %q and %q", forbidden.Src.Type, forbidden.Dst.Type)
+	}
+	if jsonSpec.Segments["lbs"].Type != spec.SegmentTypeLoadBalancer {
+		t.Fatalf("segment type is %q", jsonSpec.Segments["lbs"].Type)
+	}
+}
@@ -0,0 +1,29 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jsonio
+
+import (
+	"encoding/json"
+
+	"gopkg.in/yaml.v3"
+)
+
+// yamlToJSON translates a YAML spec to JSON, resolving anchors and aliases
+func yamlToJSON(bytes []byte) ([]byte, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(bytes, &root); err != nil {
+		return nil, err
+	}
+	var content any
+	if err := root.Decode(&content); err != nil {
+		return nil, err
+	}
+	jsonBytes, err := json.Marshal(content)
+	if err != nil {
+		return nil, err
+	}
+	return jsonBytes, nil
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jsonio
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+const yamlSpec = `# a comment
+externals:
+  dns: 8.8.8.8
+required-connections:
+  - &conn
+    src: {name: sub1, type: subnet}
+    dst: {name: dns, type: external}
+  - <<: *conn
+    src: {name: sub2, type: subnet}
+`
+
+func TestYAMLToJSON(t *testing.T) {
+	jsonBytes, err := yamlToJSON([]byte(yamlSpec))
+	if err != nil {
+		t.Fatalf(`yamlToJSON returns %v`, err)
+	}
+	var actual, expected any
+	if err := json.Unmarshal(jsonBytes, &actual); err != nil {
+		t.Fatalf(`yamlToJSON returns invalid JSON %s`, jsonBytes)
+	}
+	_ = json.Unmarshal([]byte(`{"externals": {"dns": "8.8.8.8"}, "required-connections": [
+		{"src": {"name": "sub1", "type": "subnet"}, "dst": {"name": "dns", "type": "external"}},
+		{"src": {"name": "sub2", "type": "subnet"}, "dst": {"name": "dns", "type": "external"}}]}`), &expected)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf(`yamlToJSON returns %v instead of %v`, actual, expected)
+	}
+}
@@ -46,6 +46,17 @@ func (w *MDWriter) WriteACL(collection *ir.ACLCollection, vpc string, _ bool) er
 	return w.writeAll(slices.Concat(aclHeader, addAligns(len(aclHeader[0])), aclTable))
 }
 
+// WriteLayered writes the table of the nACLs and the table of the SGs, separated by an empty line
+func (w *MDWriter) WriteLayered(collection *ir.LayeredCollection, vpc string, isSynth bool) error {
+	if err := w.WriteACL(collection.ACLs, vpc, isSynth); err != nil {
+		return err
+	}
+	if _, err := w.w.WriteString("\n"); err != nil {
+		return err
+	}
+	return w.WriteSG(collection.SGs, vpc, isSynth)
+}
+
 func (w *MDWriter) writeAll(rows [][]string) error {
 	for _, row := range rows {
 		finalString := separator + strings.Join(row, separator) + separator + "\n"
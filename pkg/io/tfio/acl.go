@@ -19,17 +19,18 @@ import (
 
 // WriteACL prints an entire collection of acls as a sequence of terraform resources.
 func (w *Writer) WriteACL(c *ir.ACLCollection, vpc string, _ bool) error {
-	collection, err := aclCollection(c, vpc)
+	a := newAttachments(w.defs)
+	resources, err := aclCollection(c, vpc, a, w.imports)
 	if err != nil {
 		return err
 	}
-	if _, err := w.w.WriteString(collection.Print()); err != nil {
+	if _, err := w.w.WriteString(a.configFile(resources).Print()); err != nil {
 		return err
 	}
 	return w.w.Flush()
 }
 
-func aclCollection(collection *ir.ACLCollection, vpc string) (*tf.ConfigFile, error) {
+func aclCollection(collection *ir.ACLCollection, vpc string, a *attachments, m *imports) ([]tf.Block, error) {
 	res := make([]tf.Block, 0)
 	for _, vpcName := range collection.VpcNames() {
 		if vpc != vpcName && vpc != "" {
@@ -42,11 +43,18 @@ func aclCollection(collection *ir.ACLCollection, vpc string) (*tf.ConfigFile, er
 				return nil, err
 			}
 			res = append(res, aclBlock)
+			res = append(res, m.aclBlocks(vpcName, aclName)...)
+			if a == nil {
+				continue
+			}
+			attachments, err := a.aclAttachments(acl, vpcName)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, attachments...)
 		}
 	}
-	return &tf.ConfigFile{
-		Resources: res,
-	}, nil
+	return res, nil
 }
 
 func singleACL(acl *ir.ACL, vpcName string) (tf.Block, error) {
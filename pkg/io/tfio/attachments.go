@@ -0,0 +1,161 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tfio
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/io/tfio/tf"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/utils"
+)
+
+const dataConst = "data"
+
+// attachments generates the resources that attach nACLs to their subnets and SGs to their targets. The attached
+// resources are looked up by name in the config definitions, and are referenced through data sources.
+type attachments struct {
+	defs        *ir.ConfigDefs
+	dataSources []tf.Block
+	dataLabels  map[string]bool
+}
+
+func newAttachments(defs *ir.ConfigDefs) *attachments {
+	if defs == nil {
+		return nil
+	}
+	return &attachments{defs: defs, dataLabels: map[string]bool{}}
+}
+
+// aclAttachments returns an ibm_is_subnet_network_acl_attachment resource for each subnet of the nACL
+func (a *attachments) aclAttachments(acl *ir.ACL, vpcName string) ([]tf.Block, error) {
+	res := []tf.Block{}
+	for _, subnet := range acl.Subnets {
+		subnetID := scopedName(vpcName, subnet)
+		if _, ok := a.defs.Subnets[subnetID]; !ok {
+			return nil, fmt.Errorf("could not find subnet %s of nACL %s in the config", subnet, acl.Name)
+		}
+		label := ir.ChangeScoping(subnetID)
+		if err := verifyName(label); err != nil {
+			return nil, err
+		}
+		subnetRef := a.dataSource("ibm_is_subnet", label, []tf.Argument{
+			{Name: nameConst, Value: quote(ir.UnscopedName(subnetID))},
+			{Name: "vpc", Value: fmt.Sprintf("local.acl_synth_%s_id", vpcName)},
+		})
+		res = append(res, tf.Block{
+			Name:   resourceConst,
+			Labels: []string{quote("ibm_is_subnet_network_acl_attachment"), quote(label)},
+			Arguments: []tf.Argument{
+				{Name: "subnet", Value: subnetRef},
+				{Name: "network_acl", Value: fmt.Sprintf("ibm_is_network_acl.%s.id", ir.ChangeScoping(acl.Name))},
+			},
+		})
+	}
+	return res, nil
+}
+
+// sgAttachments returns an ibm_is_security_group_target resource for each target of the SG. An SG that targets an
+// instance is attached to all of its network interfaces.
+func (a *attachments) sgAttachments(sg *ir.SG, vpcName string) ([]tf.Block, error) {
+	res := []tf.Block{}
+	sgName := ir.ChangeScoping(sg.SGName.String())
+	for _, target := range sg.Targets {
+		targetIDs, err := a.sgTargets(vpcName, target)
+		if err != nil {
+			return nil, fmt.Errorf("%w of SG %s", err, sg.SGName)
+		}
+		for _, targetID := range targetIDs {
+			label := sgName + "--" + ir.ChangeScoping(strings.TrimPrefix(targetID, vpcName+"/"))
+			if err := verifyName(label); err != nil {
+				return nil, err
+			}
+			res = append(res, tf.Block{
+				Name:   resourceConst,
+				Labels: []string{quote("ibm_is_security_group_target"), quote(label)},
+				Arguments: []tf.Argument{
+					{Name: "security_group", Value: fmt.Sprintf("ibm_is_security_group.%s.id", sgName)},
+					{Name: "target", Value: a.sgTargetRef(targetID)},
+				},
+			})
+		}
+	}
+	return res, nil
+}
+
+// sgTargets returns the scoped names of the NIFs, VPEs and load balancers the target stands for. The targets of
+// synthesized SGs are instances, VPEs and load balancers, and the targets of SGs read from a config are their NIFs,
+// VPEs and load balancers, by their unscoped names
+func (a *attachments) sgTargets(vpcName string, target ir.ID) ([]ir.ID, error) {
+	targetID := scopedName(vpcName, target)
+	if instance, ok := a.defs.Instances[targetID]; ok {
+		return instance.Nifs, nil
+	}
+	if _, ok := a.defs.VPEs[targetID]; ok {
+		return []ir.ID{targetID}, nil
+	}
+	if _, ok := a.defs.LoadBalancers[targetID]; ok {
+		return []ir.ID{targetID}, nil
+	}
+	for _, nif := range utils.SortedMapKeys(a.defs.NIFs) {
+		if ir.VpcFromScopedResource(nif) == vpcName && ir.UnscopedName(nif) == target {
+			return []ir.ID{nif}, nil
+		}
+	}
+	return nil, fmt.Errorf("could not find target %s in the config", target)
+}
+
+// sgTargetRef returns a reference to the id of a NIF, a VPE or a load balancer, through a data source
+func (a *attachments) sgTargetRef(targetID ir.ID) string {
+	label := ir.ChangeScoping(targetID)
+	if nif, ok := a.defs.NIFs[targetID]; ok {
+		components := ir.ScopingComponents(targetID)
+		if nif.Virtual {
+			return a.dataSource("ibm_is_virtual_network_interface", label, []tf.Argument{{Name: nameConst, Value: quote(components[2])}})
+		}
+		return a.dataSource("ibm_is_instance_network_interface", label, []tf.Argument{
+			{Name: "instance_name", Value: quote(components[1])},
+			{Name: "network_interface_name", Value: quote(components[2])},
+		})
+	}
+	dataType := "ibm_is_lb"
+	if _, ok := a.defs.VPEs[targetID]; ok {
+		dataType = "ibm_is_virtual_endpoint_gateway"
+	}
+	return a.dataSource(dataType, label, []tf.Argument{{Name: nameConst, Value: quote(ir.UnscopedName(targetID))}})
+}
+
+// dataSource adds a data source, unless it was already added, and returns a reference to its id
+func (a *attachments) dataSource(dataType, label string, arguments []tf.Argument) string {
+	ref := fmt.Sprintf("data.%s.%s.id", dataType, label)
+	if !a.dataLabels[ref] {
+		a.dataLabels[ref] = true
+		a.dataSources = append(a.dataSources, tf.Block{
+			Name:      dataConst,
+			Labels:    []string{quote(dataType), quote(label)},
+			Arguments: arguments,
+		})
+	}
+	return ref
+}
+
+// configFile returns the resources, preceded by the data sources of the attached resources, if any
+func (a *attachments) configFile(resources []tf.Block) *tf.ConfigFile {
+	if a == nil {
+		return &tf.ConfigFile{Resources: resources}
+	}
+	return &tf.ConfigFile{Resources: slices.Concat(a.dataSources, resources)}
+}
+
+// scopedName returns the name scoped by the VPC, unless it is already scoped
+func scopedName(vpcName, name string) ir.ID {
+	if strings.HasPrefix(name, vpcName+"/") {
+		return name
+	}
+	return vpcName + "/" + name
+}
@@ -23,17 +23,32 @@ import (
 const (
 	resourceConst = "resource"
 	nameConst     = "name"
+	ipv6Const     = "ipv6"
 )
 
 // Writer implements ir.Writer
 type Writer struct {
-	w *bufio.Writer
+	w       *bufio.Writer
+	defs    *ir.ConfigDefs // if set, the nACLs and the SGs are attached to the resources of the config
+	imports *imports       // if set, the resources of an original collection are updated in place
 }
 
 func NewWriter(w io.Writer) *Writer {
 	return &Writer{w: bufio.NewWriter(w)}
 }
 
+// NewWriterWithAttachments creates a writer that also generates the resources attaching the nACLs to their subnets
+// and the SGs to their targets, which are looked up in the given config definitions
+func NewWriterWithAttachments(w io.Writer, defs *ir.ConfigDefs) *Writer {
+	return &Writer{w: bufio.NewWriter(w), defs: defs}
+}
+
+// NewWriterWithImports creates a writer that also generates the import and moved blocks that update the nACLs or the
+// SGs of the original collection in place; defs, if not nil, are used as in NewWriterWithAttachments
+func NewWriterWithImports(w io.Writer, defs *ir.ConfigDefs, original ir.Collection) *Writer {
+	return &Writer{w: bufio.NewWriter(w), defs: defs, imports: newImports(original)}
+}
+
 func portRange(r interval.Interval, prefix string) []tf.Argument {
 	var arguments []tf.Argument
 	if r.Start() != netp.MinPort {
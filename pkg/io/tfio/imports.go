@@ -0,0 +1,179 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tfio
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/io/tfio/tf"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
+)
+
+const (
+	importConst = "import"
+	movedConst  = "moved"
+
+	aclResourceType    = "ibm_is_network_acl"
+	sgResourceType     = "ibm_is_security_group"
+	sgRuleResourceType = "ibm_is_security_group_rule"
+)
+
+// imports generates the blocks that update the resources of an original collection in place, rather than replacing
+// them with the resources of its optimization: import blocks for the resources that have ids, as read from a config
+// object, and moved blocks for the resources that have other addresses, as read from a terraform state.
+// SG rules are matched to the original rules they are equal to; the other original rules are not imported.
+type imports struct {
+	acls *ir.ACLCollection
+	sgs  *ir.SGCollection
+}
+
+func newImports(original ir.Collection) *imports {
+	switch c := original.(type) {
+	case *ir.ACLCollection:
+		return &imports{acls: c}
+	case *ir.SGCollection:
+		return &imports{sgs: c}
+	}
+	return nil
+}
+
+// aclBlocks returns the import or moved block of the nACL, if it is in the original collection
+func (m *imports) aclBlocks(vpcName, aclName string) []tf.Block {
+	if m == nil || m.acls == nil || m.acls.ACLs[vpcName][aclName] == nil {
+		return nil
+	}
+	original := m.acls.ACLs[vpcName][aclName]
+	return inPlaceBlocks(aclResourceType+"."+ir.ChangeScoping(aclName), original.ID, original.Address)
+}
+
+// sgBlocks returns the import or moved block of the SG, if it is in the original collection
+func (m *imports) sgBlocks(vpcName string, sgName ir.SGName) []tf.Block {
+	original := m.originalSG(vpcName, sgName)
+	if original == nil {
+		return nil
+	}
+	return inPlaceBlocks(sgResourceType+"."+ir.ChangeScoping(sgName.String()), original.ID, original.Address)
+}
+
+// sgRuleNames returns the resource name of each rule of the SG, with the import and moved blocks of the rules that
+// are equal to original rules. Rules are named by the SG and their index, unless such a name is the address of another
+// original rule: terraform reads a move to an address that is moved as well as a chain of moves, so the rules that
+// are equal to original rules then keep their names, and the other rules are named by indices no original rule uses.
+func (m *imports) sgRuleNames(vpcName string, sg *ir.SG) (names []string, blocks []tf.Block) {
+	rules := sg.AllRules()
+	prefix := ir.ChangeScoping(sg.SGName.String())
+	names = make([]string, len(rules))
+	for i := range rules {
+		names[i] = fmt.Sprintf("%s-%v", prefix, i)
+	}
+	original := m.originalSG(vpcName, sg.SGName)
+	if original == nil {
+		return names, nil
+	}
+	originalRules := original.AllRules()
+	kept := keptRules(rules, originalRules)
+	if movesToOriginal(names, kept, originalRules) {
+		names = keptNames(prefix, len(rules), kept, originalRules)
+	}
+	for i := range rules {
+		j, ok := kept[i]
+		if !ok {
+			continue
+		}
+		id := ""
+		if original.ID != "" && originalRules[j].ID != "" {
+			id = original.ID + "." + originalRules[j].ID
+		}
+		blocks = append(blocks, inPlaceBlocks(sgRuleResourceType+"."+names[i], id, originalRules[j].Address)...)
+	}
+	return names, blocks
+}
+
+func (m *imports) originalSG(vpcName string, sgName ir.SGName) *ir.SG {
+	if m == nil || m.sgs == nil {
+		return nil
+	}
+	return m.sgs.SGs[vpcName][sgName]
+}
+
+// keptRules maps the index of each rule that is equal to an original rule to the index of the original rule
+func keptRules(rules, originalRules []*ir.SGRule) map[int]int {
+	res := map[int]int{}
+	used := make([]bool, len(originalRules))
+	for i, rule := range rules {
+		for j, originalRule := range originalRules {
+			if !used[j] && rule.Equal(originalRule) {
+				res[i] = j
+				used[j] = true
+				break
+			}
+		}
+	}
+	return res
+}
+
+// movesToOriginal returns true if a kept rule would be moved to the address of an original rule
+func movesToOriginal(names []string, kept map[int]int, originalRules []*ir.SGRule) bool {
+	addresses := map[string]bool{}
+	for _, rule := range originalRules {
+		addresses[rule.Address] = rule.Address != ""
+	}
+	for i, j := range kept {
+		to := sgRuleResourceType + "." + names[i]
+		if originalRules[j].Address != "" && originalRules[j].Address != to && addresses[to] {
+			return true
+		}
+	}
+	return false
+}
+
+// keptNames names the kept rules by the names of their original rules, if these are in the root module, and the
+// other rules by the SG and the lowest indices that are not names of original rules
+func keptNames(prefix string, n int, kept map[int]int, originalRules []*ir.SGRule) []string {
+	names := make([]string, n)
+	taken := map[string]bool{}
+	for _, rule := range originalRules {
+		if name, ok := strings.CutPrefix(rule.Address, sgRuleResourceType+"."); ok {
+			taken[name] = true
+		}
+	}
+	for i, j := range kept {
+		if name, ok := strings.CutPrefix(originalRules[j].Address, sgRuleResourceType+"."); ok && verifyName(name) == nil {
+			names[i] = name
+		}
+	}
+	index := 0
+	for i := range names {
+		if names[i] != "" {
+			continue
+		}
+		for taken[fmt.Sprintf("%s-%v", prefix, index)] {
+			index++
+		}
+		names[i] = fmt.Sprintf("%s-%v", prefix, index)
+		index++
+	}
+	return names
+}
+
+// inPlaceBlocks returns an import block if the original resource has an id, and a moved block if it has another address
+func inPlaceBlocks(to, id, address string) []tf.Block {
+	var res []tf.Block
+	if id != "" {
+		res = append(res, tf.Block{Name: importConst, Arguments: []tf.Argument{
+			{Name: "to", Value: to},
+			{Name: "id", Value: quote(id)},
+		}})
+	}
+	if address != "" && address != to {
+		res = append(res, tf.Block{Name: movedConst, Arguments: []tf.Argument{
+			{Name: "from", Value: address},
+			{Name: "to", Value: to},
+		}})
+	}
+	return res
+}
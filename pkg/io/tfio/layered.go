@@ -0,0 +1,29 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tfio
+
+import (
+	"slices"
+
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
+)
+
+// WriteLayered prints the nACLs and the SGs of a collection as a single sequence of terraform resources.
+func (w *Writer) WriteLayered(c *ir.LayeredCollection, vpc string, _ bool) error {
+	a := newAttachments(w.defs)
+	acls, err := aclCollection(c.ACLs, vpc, a, nil)
+	if err != nil {
+		return err
+	}
+	sgs, err := sgCollection(c.SGs, vpc, a, nil)
+	if err != nil {
+		return err
+	}
+	if _, err := w.w.WriteString(a.configFile(slices.Concat(acls, sgs)).Print()); err != nil {
+		return err
+	}
+	return w.w.Flush()
+}
@@ -11,25 +11,26 @@ import (
 	"strings"
 
 	"github.com/np-guard/models/pkg/netp"
-	"github.com/np-guard/models/pkg/netset"
 
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/io/tfio/tf"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ipset"
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
 )
 
 // WriteSG prints an entire collection of Security Groups as a sequence of terraform resources.
 func (w *Writer) WriteSG(c *ir.SGCollection, vpc string, _ bool) error {
-	collection, err := sgCollection(c, vpc)
+	a := newAttachments(w.defs)
+	resources, err := sgCollection(c, vpc, a, w.imports)
 	if err != nil {
 		return err
 	}
-	if _, err := w.w.WriteString(collection.Print()); err != nil {
+	if _, err := w.w.WriteString(a.configFile(resources).Print()); err != nil {
 		return err
 	}
 	return w.w.Flush()
 }
 
-func sgCollection(collection *ir.SGCollection, vpc string) (*tf.ConfigFile, error) {
+func sgCollection(collection *ir.SGCollection, vpc string, a *attachments, m *imports) ([]tf.Block, error) {
 	var resources []tf.Block
 
 	for _, vpcName := range collection.VpcNames() {
@@ -43,18 +44,27 @@ func sgCollection(collection *ir.SGCollection, vpc string) (*tf.ConfigFile, erro
 				return nil, err
 			}
 			resources = append(resources, sgTf)
+			resources = append(resources, m.sgBlocks(vpcName, sgName)...)
+			names, blocks := m.sgRuleNames(vpcName, sgObject)
 			for i, rule := range sgObject.AllRules() {
-				rule, err := sgRule(rule, sgName, i)
+				rule, err := sgRule(rule, sgName, names[i])
 				if err != nil {
 					return nil, err
 				}
 				resources = append(resources, rule)
 			}
+			resources = append(resources, blocks...)
+			if a == nil {
+				continue
+			}
+			attachments, err := a.sgAttachments(sgObject, vpcName)
+			if err != nil {
+				return nil, err
+			}
+			resources = append(resources, attachments...)
 		}
 	}
-	return &tf.ConfigFile{
-		Resources: resources,
-	}, nil
+	return resources, nil
 }
 
 func sg(sG *ir.SG, vpcName string) (tf.Block, error) {
@@ -78,8 +88,7 @@ func sg(sG *ir.SG, vpcName string) (tf.Block, error) {
 	}, nil
 }
 
-func sgRule(rule *ir.SGRule, sgName ir.SGName, i int) (tf.Block, error) {
-	ruleName := fmt.Sprintf("%s-%v", ir.ChangeScoping(sgName.String()), i)
+func sgRule(rule *ir.SGRule, sgName ir.SGName, ruleName string) (tf.Block, error) {
 	if err := verifyName(ruleName); err != nil {
 		return tf.Block{}, err
 	}
@@ -95,17 +104,22 @@ func sgRule(rule *ir.SGRule, sgName ir.SGName, i int) (tf.Block, error) {
 		comment = fmt.Sprintf("# %v", rule.Explanation)
 	}
 
+	arguments := []tf.Argument{
+		{Name: "group", Value: group},
+		{Name: "direction", Value: quote(direction(rule.Direction))},
+		{Name: "local", Value: quote(rule.Local.String())},
+		{Name: "remote", Value: remote},
+	}
+	if rule.Local.IsIPv6() { // the default ip_version is ipv4
+		arguments = append(arguments, tf.Argument{Name: "ip_version", Value: quote(ipv6Const)})
+	}
+
 	return tf.Block{
-		Name:    resourceConst,
-		Labels:  []string{quote("ibm_is_security_group_rule"), ir.ChangeScoping(quote(ruleName))},
-		Comment: comment,
-		Arguments: []tf.Argument{
-			{Name: "group", Value: group},
-			{Name: "direction", Value: quote(direction(rule.Direction))},
-			{Name: "local", Value: quote(rule.Local.String())},
-			{Name: "remote", Value: remote},
-		},
-		Blocks: sgProtocol(rule.Protocol),
+		Name:      resourceConst,
+		Labels:    []string{quote("ibm_is_security_group_rule"), ir.ChangeScoping(quote(ruleName))},
+		Comment:   comment,
+		Arguments: arguments,
+		Blocks:    sgProtocol(rule.Protocol),
 	}, nil
 }
 
@@ -129,7 +143,7 @@ func sgProtocol(t netp.Protocol) []tf.Block {
 
 func value(x interface{}) (string, error) {
 	switch v := x.(type) {
-	case *netset.IPBlock:
+	case *ipset.IPBlock:
 		return quote(v.String()), nil
 	case ir.SGName:
 		return ir.ChangeScoping(fmt.Sprintf("ibm_is_security_group.%v.id", v)), nil
@@ -0,0 +1,133 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tfstateio
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/np-guard/models/pkg/netp"
+
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ipset"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
+)
+
+const (
+	actionAllow = "allow"
+	actionDeny  = "deny"
+)
+
+// ReadACLs translates the ibm_is_network_acl resources of a terraform state or plan to ir.ACLCollection.
+// The subnets attached to each nACL are taken from the network_acl attribute of the subnets
+func ReadACLs(filename string) (*ir.ACLCollection, ir.Diagnostics, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ReadACLsFromBytes(data)
+}
+
+// ReadACLsFromBytes is ReadACLs for the contents of the output of `terraform show -json`
+func ReadACLsFromBytes(data []byte) (*ir.ACLCollection, ir.Diagnostics, error) {
+	m, err := unmarshalModel(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := ir.NewACLCollection()
+	var diagnostics ir.Diagnostics
+	for _, acl := range m.acls {
+		vpcName, err := m.vpcName(acl.VPC)
+		if err != nil {
+			return nil, nil, fmt.Errorf("network acl %s: %w", acl.Name, err)
+		}
+		inbound, outbound, err := translateACLRules(acl)
+		if err != nil {
+			return nil, nil, fmt.Errorf("network acl %s: %w", acl.Name, err)
+		}
+		subnets := m.attachedSubnets(acl)
+		if len(subnets) == 0 {
+			diagnostics = append(diagnostics, ir.NewDiagnostic(ir.SeverityInfo, ir.CodeUnattachedFirewall,
+				fmt.Sprintf("nACL %s does not have attached subnets", acl.Name), acl.Name))
+		}
+		if result.ACLs[vpcName] == nil {
+			result.ACLs[vpcName] = make(map[string]*ir.ACL)
+		}
+		result.ACLs[vpcName][acl.Name] = &ir.ACL{Name: acl.Name,
+			Address:  acl.Address,
+			Subnets:  subnets,
+			Inbound:  inbound,
+			Outbound: outbound,
+		}
+	}
+	return result, diagnostics, nil
+}
+
+func translateACLRules(acl *networkACL) (inbound, outbound []*ir.ACLRule, err error) {
+	for index, r := range acl.Rules {
+		rule, err := translateACLRule(r)
+		if err != nil {
+			return nil, nil, fmt.Errorf("rule number %d: %w", index, err)
+		}
+		if rule.Direction == ir.Inbound {
+			inbound = append(inbound, rule)
+		} else {
+			outbound = append(outbound, rule)
+		}
+	}
+	return inbound, outbound, nil
+}
+
+func translateACLRule(rule *aclRule) (*ir.ACLRule, error) {
+	action, err1 := translateAction(rule.Action)
+	direction, err2 := translateDirection(rule.Direction)
+	src, err3 := ipset.IPBlockFromCidrOrAddress(rule.Source)
+	dst, err4 := ipset.IPBlockFromCidrOrAddress(rule.Destination)
+	protocol, err5 := rule.translateProtocol()
+	if err := errors.Join(err1, err2, err3, err4, err5); err != nil {
+		return nil, err
+	}
+	return &ir.ACLRule{
+		Action:      action,
+		Direction:   direction,
+		Source:      src,
+		Destination: dst,
+		Protocol:    protocol,
+	}, nil
+}
+
+func (r *aclRule) translateProtocol() (netp.Protocol, error) {
+	switch {
+	case len(r.TCP) > 0:
+		return translateProtocolTCPUDP(true, r.TCP[0].SourcePortMin, r.TCP[0].SourcePortMax, r.TCP[0].PortMin, r.TCP[0].PortMax)
+	case len(r.UDP) > 0:
+		return translateProtocolTCPUDP(false, r.UDP[0].SourcePortMin, r.UDP[0].SourcePortMax, r.UDP[0].PortMin, r.UDP[0].PortMax)
+	case len(r.ICMP) > 0:
+		return netp.ICMPFromTypeAndCode64WithoutRFCValidation(r.ICMP[0].Type, r.ICMP[0].Code)
+	}
+	return netp.AnyProtocol{}, nil
+}
+
+func translateAction(action string) (ir.Action, error) {
+	if action == actionAllow {
+		return ir.Allow, nil
+	} else if action == actionDeny {
+		return ir.Deny, nil
+	}
+	return ir.Deny, fmt.Errorf("an nACL rule action must be either allow or deny")
+}
+
+// attachedSubnets returns the unscoped names of the subnets attached to the nACL
+func (m *model) attachedSubnets(acl *networkACL) []string {
+	res := make([]string, 0)
+	for _, subnet := range m.subnets {
+		if subnet.NetworkACL != "" && (subnet.NetworkACL == acl.ID || subnet.NetworkACL == acl.Name) {
+			res = append(res, subnet.Name)
+		}
+	}
+	return res
+}
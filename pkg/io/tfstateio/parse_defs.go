@@ -0,0 +1,408 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tfstateio
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ipset"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/utils"
+)
+
+const (
+	networkTypeVPC = "vpc"
+	lbTypePublic   = "public"
+)
+
+// ReadDefs translates the VPCs, subnets, instances, VPEs, load balancers and transit gateways of a terraform state or plan to ir.ConfigDefs
+func ReadDefs(filename string) (*ir.ConfigDefs, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return ReadDefsFromBytes(data)
+}
+
+// ReadDefsFromBytes is ReadDefs for the contents of the output of `terraform show -json`
+func ReadDefsFromBytes(data []byte) (*ir.ConfigDefs, error) {
+	m, err := unmarshalModel(data)
+	if err != nil {
+		return nil, err
+	}
+
+	subnets, err1 := m.parseSubnets()
+	instances, nifs, err2 := m.parseInstancesNifs()
+	vpes, vpeReservedIPs, err3 := m.parseVPEs()
+	lbs, lbIPs, err4 := m.parseLoadBalancers(subnets)
+	if err := errors.Join(err1, err2, err3, err4); err != nil {
+		return nil, err
+	}
+	vpcs, err := m.parseVPCs(subnets)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateVPCs(vpcs); err != nil {
+		return nil, err
+	}
+	tgws, err := m.parseTransitGateways(vpcs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ir.ConfigDefs{
+		VPCs:            vpcs,
+		Subnets:         subnets,
+		NIFs:            nifs,
+		Instances:       instances,
+		VPEReservedIPs:  vpeReservedIPs,
+		VPEs:            vpes,
+		LoadBalancerIPs: lbIPs,
+		LoadBalancers:   lbs,
+		TransitGateways: tgws,
+	}, nil
+}
+
+// parseVPCs collects the address prefixes of each VPC from its default address prefixes and from the
+// ibm_is_vpc_address_prefix resources; a VPC without any is assumed to span the CIDRs of its subnets
+func (m *model) parseVPCs(subnets map[ir.ID]*ir.SubnetDetails) (map[ir.ID]*ir.VPCDetails, error) {
+	res := make(map[ir.ID]*ir.VPCDetails, len(m.vpcs))
+	for _, vpc := range m.vpcs {
+		res[vpc.Name] = &ir.VPCDetails{AddressPrefixes: ipset.NewIPBlock()}
+		for _, zone := range utils.SortedMapKeys(vpc.DefaultAddressPrefixes) {
+			if err := addAddressPrefix(res[vpc.Name], vpc.DefaultAddressPrefixes[zone]); err != nil {
+				return nil, fmt.Errorf("vpc %s: %w", vpc.Name, err)
+			}
+		}
+	}
+	for _, prefix := range m.addressPrefixes {
+		vpcName, err := m.vpcName(prefix.VPC)
+		if err != nil {
+			return nil, fmt.Errorf("address prefix %s: %w", prefix.CIDR, err)
+		}
+		if err := addAddressPrefix(res[vpcName], prefix.CIDR); err != nil {
+			return nil, fmt.Errorf("vpc %s: %w", vpcName, err)
+		}
+	}
+	subnetCIDRs := map[ir.ID]*ipset.IPBlock{}
+	for _, subnetName := range utils.SortedMapKeys(subnets) {
+		vpcName := ir.VpcFromScopedResource(subnetName)
+		if subnetCIDRs[vpcName] == nil {
+			subnetCIDRs[vpcName] = ipset.NewIPBlock()
+		}
+		subnetCIDRs[vpcName] = subnetCIDRs[vpcName].Union(subnets[subnetName].CIDR)
+	}
+	for vpcName, cidrs := range subnetCIDRs {
+		if res[vpcName].AddressPrefixes.IsEmpty() {
+			res[vpcName].AddressPrefixes = cidrs
+		}
+	}
+	return res, nil
+}
+
+// parseTransitGateways translates the VPC connections of the transit gateways to the address prefixes each VPC
+// advertises through each transit gateway. The prefix filters of a connection are its prefix_filters, followed by
+// the ibm_tg_connection_prefix_filter resources of the connection
+func (m *model) parseTransitGateways(vpcs map[ir.ID]*ir.VPCDetails) (map[ir.ID]*ir.TransitGatewayDetails, error) {
+	res := make(map[ir.ID]*ir.TransitGatewayDetails, len(m.tgws))
+	for _, conn := range m.tgwConnections {
+		if conn.NetworkType != networkTypeVPC {
+			continue
+		}
+		tgwName, err := m.tgwName(conn.Gateway)
+		if err != nil {
+			return nil, fmt.Errorf("transit gateway connection %s: %w", conn.Name, err)
+		}
+		vpc := m.vpcByCRN(conn.NetworkID)
+		if vpc == nil {
+			continue // a VPC that is not part of the state
+		}
+		filters, err := m.connectionPrefixFilters(conn)
+		if err != nil {
+			return nil, fmt.Errorf("transit gateway connection %s: %w", conn.Name, err)
+		}
+		if res[tgwName] == nil {
+			res[tgwName] = &ir.TransitGatewayDetails{VPCPrefixes: make(map[ir.ID]*ipset.IPBlock)}
+		}
+		res[tgwName].VPCPrefixes[vpc.Name] = ir.AdvertisedPrefixes(m.addressPrefixList(vpc, vpcs[vpc.Name]), filters,
+			ir.PrefixFilterAction(conn.PrefixFiltersDefault))
+	}
+	return res, nil
+}
+
+func (m *model) connectionPrefixFilters(conn *tgwConnection) ([]*ir.PrefixFilter, error) {
+	filters := slices.Clone(conn.PrefixFilters)
+	for _, filter := range m.prefixFilters {
+		if filter.ConnectionID == conn.ConnectionID && filter.ConnectionID != "" {
+			filters = append(filters, filter)
+		}
+	}
+	res := make([]*ir.PrefixFilter, len(filters))
+	for i, filter := range filters {
+		prefix, err := ipset.IPBlockFromCidr(filter.Prefix)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = &ir.PrefixFilter{Action: ir.PrefixFilterAction(filter.Action), Prefix: prefix, Ge: filter.Ge, Le: filter.Le}
+	}
+	return res, nil
+}
+
+func (m *model) vpcByCRN(crn string) *vpc {
+	for _, vpc := range m.vpcs {
+		if crn != "" && vpc.CRN == crn {
+			return vpc
+		}
+	}
+	return nil
+}
+
+// addressPrefixList returns the address prefixes of the VPC one by one, since prefix filters match single prefixes
+func (m *model) addressPrefixList(vpc *vpc, details *ir.VPCDetails) []*ipset.IPBlock {
+	var cidrs []string
+	for _, zone := range utils.SortedMapKeys(vpc.DefaultAddressPrefixes) {
+		cidrs = append(cidrs, vpc.DefaultAddressPrefixes[zone])
+	}
+	for _, prefix := range m.addressPrefixes {
+		if prefix.VPC == vpc.ID || prefix.VPC == vpc.Name {
+			cidrs = append(cidrs, prefix.CIDR)
+		}
+	}
+	if len(cidrs) == 0 { // the VPC spans the CIDRs of its subnets
+		return details.AddressPrefixes.SplitToCidrs()
+	}
+	res := make([]*ipset.IPBlock, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		if address, err := ipset.IPBlockFromCidr(cidr); err == nil { // already validated by parseVPCs
+			res = append(res, address)
+		}
+	}
+	return res
+}
+
+func addAddressPrefix(vpc *ir.VPCDetails, cidr string) error {
+	address, err := ipset.IPBlockFromCidr(cidr)
+	if err != nil {
+		return err
+	}
+	vpc.AddressPrefixes = vpc.AddressPrefixes.Union(address)
+	return nil
+}
+
+func (m *model) parseSubnets() (map[ir.ID]*ir.SubnetDetails, error) {
+	subnets := make(map[ir.ID]*ir.SubnetDetails, len(m.subnets))
+	for _, subnet := range m.subnets {
+		vpcName, err := m.vpcName(subnet.VPC)
+		if err != nil {
+			return nil, fmt.Errorf("subnet %s: %w", subnet.Name, err)
+		}
+		cidr, err := ir.SubnetCIDR(subnet.IPv4CIDRBlock, subnet.IPv6CIDRBlock)
+		if err != nil {
+			return nil, fmt.Errorf("subnet %s: %w", subnet.Name, err)
+		}
+		subnetDetails := ir.SubnetDetails{
+			CIDR: cidr,
+		}
+		if subnet.NetworkACL != "" {
+			if subnetDetails.NetworkACL, err = m.aclName(subnet.NetworkACL); err != nil {
+				return nil, fmt.Errorf("subnet %s: %w", subnet.Name, err)
+			}
+		}
+		if subnetDetails.PublicGateway, err = m.attachedPublicGateway(subnet); err != nil {
+			return nil, fmt.Errorf("subnet %s: %w", subnet.Name, err)
+		}
+		subnets[scopingString(vpcName, subnet.Name)] = &subnetDetails
+	}
+	return subnets, nil
+}
+
+// attachedPublicGateway returns the name of the public gateway attached to the subnet, either by its public_gateway
+// attribute or by an ibm_is_subnet_public_gateway_attachment, or "" if there is none
+func (m *model) attachedPublicGateway(subnet *subnet) (string, error) {
+	ref := subnet.PublicGateway
+	for _, attachment := range m.pgwAttachments {
+		if attachment.Subnet != "" && (attachment.Subnet == subnet.ID || attachment.Subnet == subnet.Name) {
+			ref = attachment.PublicGateway
+		}
+	}
+	if ref == "" {
+		return "", nil
+	}
+	return m.publicGatewayName(ref)
+}
+
+func (m *model) parseInstancesNifs() (instances map[ir.ID]*ir.InstanceDetails, nifs map[ir.ID]*ir.NifDetails, err error) {
+	instances = make(map[ir.ID]*ir.InstanceDetails, len(m.instances)+len(m.bareMetals))
+	nifs = make(map[ir.ID]*ir.NifDetails)
+	for _, instance := range m.servers() {
+		vpcName, err := m.vpcName(instance.VPC)
+		if err != nil {
+			return nil, nil, fmt.Errorf("instance %s: %w", instance.Name, err)
+		}
+		instanceUniqueName := scopingString(vpcName, instance.Name)
+		nifsOfInstance := m.nifs(instance)
+		instanceNifs := make([]ir.ID, len(nifsOfInstance))
+		for i, nif := range nifsOfInstance {
+			nifIP, err := ipset.IPBlockFromIPAddress(nif.address())
+			if err != nil {
+				return nil, nil, fmt.Errorf("network interface %s of instance %s: %w", nif.Name, instance.Name, err)
+			}
+			subnetName, err := m.subnetName(nif.Subnet)
+			if err != nil {
+				return nil, nil, fmt.Errorf("network interface %s of instance %s: %w", nif.Name, instance.Name, err)
+			}
+			floatingIP, err := m.floatingIP(nif)
+			if err != nil {
+				return nil, nil, fmt.Errorf("network interface %s of instance %s: %w", nif.Name, instance.Name, err)
+			}
+			secondaryIPs, err := m.secondaryIPs(nif, nifIP)
+			if err != nil {
+				return nil, nil, fmt.Errorf("network interface %s of instance %s: %w", nif.Name, instance.Name, err)
+			}
+			nifUniqueName := scopingString(instanceUniqueName, nif.Name)
+			nifs[nifUniqueName] = &ir.NifDetails{
+				Instance:     instanceUniqueName,
+				IP:           nifIP,
+				SecondaryIPs: secondaryIPs,
+				FloatingIP:   floatingIP,
+				Subnet:       scopingString(vpcName, subnetName),
+				Virtual:      nif.virtual,
+			}
+			instanceNifs[i] = nifUniqueName
+		}
+		instances[instanceUniqueName] = &ir.InstanceDetails{Nifs: instanceNifs}
+	}
+	return instances, nifs, nil
+}
+
+// floatingIP returns the address of the floating IP whose target is the network interface, or nil if there is none
+func (m *model) floatingIP(nif *networkInterface) (*ipset.IPBlock, error) {
+	for _, fip := range m.floatingIPs {
+		if fip.Target != "" && fip.Target == nif.ID {
+			return ipset.IPBlockFromIPAddress(fip.Address)
+		}
+	}
+	return nil, nil
+}
+
+// secondaryIPs returns the addresses bound to the network interface other than its primary address: the IPs of a
+// virtual network interface, its ibm_is_virtual_network_interface_ip resources, and the ibm_is_subnet_reserved_ip
+// resources whose target is the network interface. It returns nil if there are none
+func (m *model) secondaryIPs(nif *networkInterface, primaryIP *ipset.IPBlock) (*ipset.IPBlock, error) {
+	var addresses []string
+	for _, ip := range nif.IPs {
+		addresses = append(addresses, ip.Address)
+	}
+	for _, ip := range m.virtualNifIPs {
+		if nif.ID != "" && ip.VirtualNetworkInterface == nif.ID {
+			addresses = append(addresses, ip.Address)
+		}
+	}
+	for _, ip := range m.reservedIPs {
+		if nif.ID != "" && ip.Target == nif.ID {
+			addresses = append(addresses, ip.Address)
+		}
+	}
+	res := ipset.NewIPBlock()
+	for _, address := range addresses {
+		ip, err := ipset.IPBlockFromIPAddress(address)
+		if err != nil {
+			return nil, err
+		}
+		res = res.Union(ip)
+	}
+	if res = res.Subtract(primaryIP); res.IsEmpty() {
+		return nil, nil
+	}
+	return res, nil
+}
+
+func (m *model) parseVPEs() (vpes map[ir.ID]*ir.VPEDetails, vpeReservedIPs map[ir.ID]*ir.VPEReservedIPsDetails, err error) {
+	vpes = make(map[ir.ID]*ir.VPEDetails, len(m.vpes))
+	vpeReservedIPs = make(map[ir.ID]*ir.VPEReservedIPsDetails)
+	for _, vpe := range m.vpes {
+		vpcName, err := m.vpcName(vpe.VPC)
+		if err != nil {
+			return nil, nil, fmt.Errorf("vpe %s: %w", vpe.Name, err)
+		}
+		vpeName := scopingString(vpcName, vpe.Name)
+		vpeDetails := &ir.VPEDetails{VPEReservedIPs: []ir.ID{}}
+		for _, ip := range vpe.IPs {
+			vpeIP, err := ipset.IPBlockFromIPAddress(ip.Address)
+			if err != nil {
+				return nil, nil, fmt.Errorf("reserved ip %s of vpe %s: %w", ip.Name, vpe.Name, err)
+			}
+			subnetName, err := m.subnetName(ip.Subnet)
+			if err != nil {
+				return nil, nil, fmt.Errorf("reserved ip %s of vpe %s: %w", ip.Name, vpe.Name, err)
+			}
+			uniqueVpeReservedIPName := scopingString(vpeName, ip.Name)
+			vpeReservedIPs[uniqueVpeReservedIPName] = &ir.VPEReservedIPsDetails{
+				VPEName: vpeName,
+				Subnet:  scopingString(vpcName, subnetName),
+				IP:      vpeIP,
+			}
+			vpeDetails.VPEReservedIPs = append(vpeDetails.VPEReservedIPs, uniqueVpeReservedIPName)
+		}
+		vpes[vpeName] = vpeDetails
+	}
+	return vpes, vpeReservedIPs, nil
+}
+
+// parseLoadBalancers translates the load balancers and their private IPs, which are named by their addresses; the VPC of
+// a load balancer is the VPC of its subnets, and each private IP is in one of them
+func (m *model) parseLoadBalancers(subnets map[ir.ID]*ir.SubnetDetails) (lbs map[ir.ID]*ir.LoadBalancerDetails,
+	lbIPs map[ir.ID]*ir.LoadBalancerIPDetails, err error) {
+	lbs = make(map[ir.ID]*ir.LoadBalancerDetails, len(m.loadBalancers))
+	lbIPs = make(map[ir.ID]*ir.LoadBalancerIPDetails)
+	for _, lb := range m.loadBalancers {
+		lbSubnets := make([]ir.ID, len(lb.Subnets))
+		for i, ref := range lb.Subnets {
+			if lbSubnets[i], err = m.scopedSubnetName(ref); err != nil {
+				return nil, nil, fmt.Errorf("load balancer %s: %w", lb.Name, err)
+			}
+		}
+		if len(lbSubnets) == 0 {
+			return nil, nil, fmt.Errorf("load balancer %s has no subnets", lb.Name)
+		}
+		lbName := scopingString(ir.VpcFromScopedResource(lbSubnets[0]), lb.Name)
+		lbDetails := &ir.LoadBalancerDetails{PrivateIPs: []ir.ID{}, Public: lb.Type == lbTypePublic}
+		for _, privateIP := range lb.PrivateIP {
+			ip, err := ipset.IPBlockFromIPAddress(privateIP.Address)
+			if err != nil {
+				return nil, nil, fmt.Errorf("load balancer %s: %w", lb.Name, err)
+			}
+			subnetIndex := slices.IndexFunc(lbSubnets, func(subnet ir.ID) bool {
+				return subnets[subnet] != nil && ip.IsSubset(subnets[subnet].CIDR)
+			})
+			if subnetIndex < 0 {
+				return nil, nil, fmt.Errorf("private IP %s of load balancer %s is not in any of its subnets", privateIP.Address, lb.Name)
+			}
+			ipName := scopingString(lbName, privateIP.Address)
+			lbIPs[ipName] = &ir.LoadBalancerIPDetails{IP: ip, LoadBalancer: lbName, Subnet: lbSubnets[subnetIndex]}
+			lbDetails.PrivateIPs = append(lbDetails.PrivateIPs, ipName)
+		}
+		lbs[lbName] = lbDetails
+	}
+	return lbs, lbIPs, nil
+}
+
+func validateVPCs(vpcs map[ir.ID]*ir.VPCDetails) error {
+	for _, vpcName1 := range utils.SortedMapKeys(vpcs) {
+		for _, vpcName2 := range utils.SortedMapKeys(vpcs) {
+			if vpcName1 >= vpcName2 {
+				continue
+			}
+			if vpcs[vpcName1].AddressPrefixes.Overlap(vpcs[vpcName2].AddressPrefixes) {
+				return fmt.Errorf("vpcs %s and %s have overlapping IP address spaces", vpcName1, vpcName2)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,274 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tfstateio
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/np-guard/models/pkg/netp"
+
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ipset"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/utils"
+)
+
+const (
+	protocolAll  = "all"
+	protocolTCP  = "tcp"
+	protocolUDP  = "udp"
+	protocolICMP = "icmp"
+	ipv4         = "ipv4"
+	ipv6         = "ipv6"
+)
+
+// ReadSGs translates the ibm_is_security_group and ibm_is_security_group_rule resources of a terraform state or plan
+// to ir.SGCollection. SG targets are collected from the instances, the VPEs and the ibm_is_security_group_target resources
+func ReadSGs(filename string) (*ir.SGCollection, ir.Diagnostics, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ReadSGsFromBytes(data)
+}
+
+// ReadSGsFromBytes is ReadSGs for the contents of the output of `terraform show -json`
+func ReadSGsFromBytes(data []byte) (*ir.SGCollection, ir.Diagnostics, error) {
+	m, err := unmarshalModel(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	targets, err := m.sgTargetNames()
+	if err != nil {
+		return nil, nil, err
+	}
+	result := ir.NewSGCollection()
+	var diagnostics ir.Diagnostics
+	sgs := make(map[string]*ir.SG, len(m.sgs)) // by name
+	for _, sg := range m.sgs {
+		vpcName, err := m.vpcName(sg.VPC)
+		if err != nil {
+			return nil, nil, fmt.Errorf("security group %s: %w", sg.Name, err)
+		}
+		sgName := ir.SGName(sg.Name)
+		if result.SGs[vpcName] == nil {
+			result.SGs[vpcName] = make(map[ir.SGName]*ir.SG)
+		}
+		result.SGs[vpcName][sgName] = ir.NewSG(sgName)
+		result.SGs[vpcName][sgName].Targets = targets[sg.Name]
+		result.SGs[vpcName][sgName].Address = sg.Address
+		if len(targets[sg.Name]) == 0 {
+			diagnostics = append(diagnostics, ir.NewDiagnostic(ir.SeverityInfo, ir.CodeUnattachedFirewall,
+				fmt.Sprintf("security group %s does not have attached resources", sg.Name), sg.Name))
+		}
+		sgs[sg.Name] = result.SGs[vpcName][sgName]
+		if err := m.addSGRules(result.SGs[vpcName][sgName], sg.Rules); err != nil {
+			return nil, nil, fmt.Errorf("security group %s: %w", sg.Name, err)
+		}
+	}
+	// the rules of a state also list the rules of ibm_is_security_group_rule resources, which SG.Add drops as redundant
+	for i, rule := range m.sgRules {
+		sgName, err := m.sgName(rule.Group)
+		if err != nil {
+			return nil, nil, fmt.Errorf("security group rule %d: %w", i, err)
+		}
+		if err := m.addSGRules(sgs[sgName], []*sgRule{rule}); err != nil {
+			return nil, nil, fmt.Errorf("security group %s: %w", sgName, err)
+		}
+	}
+	return result, diagnostics, nil
+}
+
+// sgTargetAdder adds a target, by its unscoped name, to the SG of the given reference
+type sgTargetAdder func(sgRef, target string) error
+
+// sgTargetNames returns the unscoped names of the NIFs, VPEs and load balancers each SG is attached to, by SG name
+func (m *model) sgTargetNames() (map[string][]string, error) {
+	res := map[string][]string{}
+	add := func(sgRef, target string) error {
+		sgName, err := m.sgName(sgRef)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(res[sgName], target) {
+			res[sgName] = append(res[sgName], target)
+		}
+		return nil
+	}
+	// the names of the targets by their ids, to which SG target resources refer
+	targetNames := map[string]string{}
+	for _, addTargets := range []func(map[string]string, sgTargetAdder) error{m.addNifTargets, m.addVPETargets,
+		m.addLoadBalancerTargets, m.addSGTargetResources} {
+		if err := addTargets(targetNames, add); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (m *model) addNifTargets(targetNames map[string]string, add sgTargetAdder) error {
+	for _, instance := range m.servers() {
+		for _, nif := range m.nifs(instance) {
+			targetNames[nif.ID] = nif.Name
+			for _, sg := range nif.SecurityGroups {
+				if err := add(sg, nif.Name); err != nil {
+					return fmt.Errorf("network interface %s of instance %s: %w", nif.Name, instance.Name, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (m *model) addVPETargets(targetNames map[string]string, add sgTargetAdder) error {
+	for _, vpe := range m.vpes {
+		targetNames[vpe.ID] = vpe.Name
+		for _, sg := range vpe.SecurityGroups {
+			if err := add(sg, vpe.Name); err != nil {
+				return fmt.Errorf("vpe %s: %w", vpe.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (m *model) addLoadBalancerTargets(targetNames map[string]string, add sgTargetAdder) error {
+	for _, lb := range m.loadBalancers {
+		targetNames[lb.ID] = lb.Name
+		for _, sg := range lb.SecurityGroups {
+			if err := add(sg, lb.Name); err != nil {
+				return fmt.Errorf("load balancer %s: %w", lb.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// addSGTargetResources adds the targets of ibm_is_security_group_target resources, which refer to the targets by id
+func (m *model) addSGTargetResources(targetNames map[string]string, add sgTargetAdder) error {
+	for _, target := range m.sgTargets {
+		name, ok := targetNames[target.Target]
+		if !ok {
+			return fmt.Errorf("security group target %s: unknown target %s", target.SecurityGroup, target.Target)
+		}
+		if err := add(target.SecurityGroup, name); err != nil {
+			return fmt.Errorf("security group target %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func (m *model) addSGRules(sg *ir.SG, rules []*sgRule) error {
+	for index, rule := range rules {
+		r, err := m.translateSGRule(rule)
+		if err != nil {
+			return fmt.Errorf("rule number %d: %w", index, err)
+		}
+		sg.Add(r)
+	}
+	return nil
+}
+
+func (m *model) translateSGRule(rule *sgRule) (*ir.SGRule, error) {
+	anyAddress, err5 := rule.anyAddress()
+	direction, err1 := translateDirection(rule.Direction)
+	remote, err2 := m.translateRemote(rule.Remote, anyAddress)
+	local, err3 := ipset.IPBlockFromCidrOrAddress(valueOrDefault(rule.Local, anyAddress))
+	protocol, err4 := rule.translateProtocol()
+	if err := errors.Join(err1, err2, err3, err4, err5); err != nil {
+		return nil, err
+	}
+	return &ir.SGRule{Direction: direction, Remote: remote, Protocol: protocol, Local: local, Address: rule.Address}, nil
+}
+
+// translateProtocol reads the protocol either from the protocol attribute or from the tcp/udp/icmp blocks
+func (r *sgRule) translateProtocol() (netp.Protocol, error) {
+	switch {
+	case len(r.TCP) > 0:
+		return translateProtocolTCPUDP(true, nil, nil, r.TCP[0].PortMin, r.TCP[0].PortMax)
+	case len(r.UDP) > 0:
+		return translateProtocolTCPUDP(false, nil, nil, r.UDP[0].PortMin, r.UDP[0].PortMax)
+	case len(r.ICMP) > 0:
+		return netp.ICMPFromTypeAndCode64WithoutRFCValidation(r.ICMP[0].Type, r.ICMP[0].Code)
+	}
+	switch r.Protocol {
+	case "", protocolAll:
+		return netp.AnyProtocol{}, nil
+	case protocolTCP, protocolUDP:
+		return translateProtocolTCPUDP(r.Protocol == protocolTCP, nil, nil, r.PortMin, r.PortMax)
+	case protocolICMP:
+		return netp.ICMPFromTypeAndCode64WithoutRFCValidation(r.Type, r.Code)
+	}
+	return nil, fmt.Errorf("unsupported protocol %s", r.Protocol)
+}
+
+// translateRemote translates a CIDR, an IP address or a reference to an SG; an empty remote stands for any address
+// of the IP version of the rule
+func (m *model) translateRemote(remote, anyAddress string) (ir.RemoteType, error) {
+	if remote == "" {
+		return ipset.IPBlockFromCidr(anyAddress)
+	}
+	if sgName, err := m.sgName(remote); err == nil {
+		return ir.SGName(sgName), nil
+	}
+	return ipset.IPBlockFromCidrOrAddress(remote)
+}
+
+// anyAddress returns the CIDR of all the addresses of the IP version of the rule, which is set by its ip_version or,
+// if it has none, by the addresses of its remote and local
+func (r *sgRule) anyAddress() (string, error) {
+	ipVersion := r.IPVersion
+	if ipVersion == "" {
+		ipVersion = ipv4
+		for _, addrs := range []string{r.Remote, r.Local} {
+			if ipBlock, err := ipset.IPBlockFromCidrOrAddress(addrs); err == nil && ipBlock.IsIPv6() {
+				ipVersion = ipv6
+			}
+		}
+	}
+	switch ipVersion {
+	case ipv4:
+		return ipset.CidrAll, nil
+	case ipv6:
+		return ipset.IPv6CidrAll, nil
+	}
+	return "", fmt.Errorf("unsupported IP version %s", ipVersion)
+}
+
+func translateDirection(direction string) (ir.Direction, error) {
+	if direction == string(ir.Inbound) {
+		return ir.Inbound, nil
+	} else if direction == string(ir.Outbound) {
+		return ir.Outbound, nil
+	}
+	return ir.Inbound, fmt.Errorf("a firewall rule direction must be either inbound or outbound")
+}
+
+// translateProtocolTCPUDP treats a missing or zero port as unset, since terraform reports unset numbers as zero
+func translateProtocolTCPUDP(isTCP bool, srcPortMin, srcPortMax, dstPortMin, dstPortMax *int64) (netp.Protocol, error) {
+	minSrcPort := utils.GetProperty(nonZero(srcPortMin), netp.MinPort)
+	maxSrcPort := utils.GetProperty(nonZero(srcPortMax), netp.MaxPort)
+	minDstPort := utils.GetProperty(nonZero(dstPortMin), netp.MinPort)
+	maxDstPort := utils.GetProperty(nonZero(dstPortMax), netp.MaxPort)
+	return netp.NewTCPUDP(isTCP, int(minSrcPort), int(maxSrcPort), int(minDstPort), int(maxDstPort))
+}
+
+func nonZero(p *int64) *int64 {
+	if p == nil || *p == 0 {
+		return nil
+	}
+	return p
+}
+
+func valueOrDefault(s, defaultValue string) string {
+	if s == "" {
+		return defaultValue
+	}
+	return s
+}
@@ -0,0 +1,490 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package tfstateio reads existing VPC resources from the output of `terraform show -json`, for either a state or a plan
+package tfstateio
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+)
+
+const (
+	resourceTypeVPC           = "ibm_is_vpc"
+	resourceTypeAddressPrefix = "ibm_is_vpc_address_prefix"
+	resourceTypeSubnet        = "ibm_is_subnet"
+	resourceTypeInstance      = "ibm_is_instance"
+	resourceTypeBareMetal     = "ibm_is_bare_metal_server"
+	resourceTypeVirtualNif    = "ibm_is_virtual_network_interface"
+	resourceTypeVirtualNifIP  = "ibm_is_virtual_network_interface_ip"
+	resourceTypeReservedIP    = "ibm_is_subnet_reserved_ip"
+	resourceTypeVPE           = "ibm_is_virtual_endpoint_gateway"
+	resourceTypeLoadBalancer  = "ibm_is_lb"
+	resourceTypeSG            = "ibm_is_security_group"
+	resourceTypeSGRule        = "ibm_is_security_group_rule"
+	resourceTypeSGTarget      = "ibm_is_security_group_target"
+	resourceTypeACL           = "ibm_is_network_acl"
+	resourceTypePublicGateway = "ibm_is_public_gateway"
+	resourceTypePGWAttachment = "ibm_is_subnet_public_gateway_attachment"
+	resourceTypeFloatingIP    = "ibm_is_floating_ip"
+	resourceTypeTGW           = "ibm_tg_gateway"
+	resourceTypeTGWConnection = "ibm_tg_connection"
+	resourceTypePrefixFilter  = "ibm_tg_connection_prefix_filter"
+
+	managedMode = "managed"
+)
+
+type (
+	// tfState is the subset of the output of `terraform show -json` that is used; a plan has planned values instead of values
+	tfState struct {
+		FormatVersion string    `json:"format_version"`
+		Values        *tfValues `json:"values"`
+		PlannedValues *tfValues `json:"planned_values"`
+	}
+
+	tfValues struct {
+		RootModule *tfModule `json:"root_module"`
+	}
+
+	tfModule struct {
+		Resources    []*tfResource `json:"resources"`
+		ChildModules []*tfModule   `json:"child_modules"`
+	}
+
+	tfResource struct {
+		Address string          `json:"address"`
+		Mode    string          `json:"mode"`
+		Type    string          `json:"type"`
+		Values  json.RawMessage `json:"values"`
+	}
+
+	// model holds the resources of the state by type, in the order they appear in the state
+	model struct {
+		vpcs            []*vpc
+		addressPrefixes []*addressPrefix
+		subnets         []*subnet
+		instances       []*instance
+		bareMetals      []*instance
+		virtualNifs     []*networkInterface
+		virtualNifIPs   []*virtualNifIP
+		reservedIPs     []*boundReservedIP
+		vpes            []*vpe
+		loadBalancers   []*loadBalancer
+		sgs             []*securityGroup
+		sgRules         []*sgRule
+		sgTargets       []*sgTarget
+		acls            []*networkACL
+		publicGateways  []*publicGateway
+		pgwAttachments  []*pgwAttachment
+		floatingIPs     []*floatingIP
+		tgws            []*transitGateway
+		tgwConnections  []*tgwConnection
+		prefixFilters   []*prefixFilter
+	}
+
+	vpc struct {
+		ID                     string            `json:"id"`
+		Name                   string            `json:"name"`
+		CRN                    string            `json:"crn"`
+		DefaultAddressPrefixes map[string]string `json:"default_address_prefixes"`
+	}
+
+	addressPrefix struct {
+		VPC  string `json:"vpc"`
+		CIDR string `json:"cidr"`
+	}
+
+	subnet struct {
+		ID            string `json:"id"`
+		Name          string `json:"name"`
+		VPC           string `json:"vpc"`
+		IPv4CIDRBlock string `json:"ipv4_cidr_block"`
+		IPv6CIDRBlock string `json:"ipv6_cidr_block"`
+		NetworkACL    string `json:"network_acl"`
+		PublicGateway string `json:"public_gateway"`
+	}
+
+	publicGateway struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+
+	pgwAttachment struct {
+		Subnet        string `json:"subnet"`
+		PublicGateway string `json:"public_gateway"`
+	}
+
+	// floatingIP is bound to the network interface that is its target
+	floatingIP struct {
+		Address string `json:"address"`
+		Target  string `json:"target"`
+	}
+
+	// instance is a virtual server instance or a bare metal server
+	instance struct {
+		ID                       string               `json:"id"`
+		Name                     string               `json:"name"`
+		VPC                      string               `json:"vpc"`
+		PrimaryNetworkInterface  []*networkInterface  `json:"primary_network_interface"`
+		NetworkInterfaces        []*networkInterface  `json:"network_interfaces"`
+		PrimaryNetworkAttachment []*networkAttachment `json:"primary_network_attachment"`
+		NetworkAttachments       []*networkAttachment `json:"network_attachments"`
+	}
+
+	// networkAttachment attaches a virtual network interface to an instance; the virtual network interface is either
+	// defined inline, or is an ibm_is_virtual_network_interface referenced by its id
+	networkAttachment struct {
+		Name                    string              `json:"name"`
+		VirtualNetworkInterface []*networkInterface `json:"virtual_network_interface"`
+	}
+
+	networkInterface struct {
+		ID                 string        `json:"id"`
+		Name               string        `json:"name"`
+		Subnet             string        `json:"subnet"`
+		PrimaryIPv4Address string        `json:"primary_ipv4_address"`
+		PrimaryIP          []*reservedIP `json:"primary_ip"`
+		IPs                []*reservedIP `json:"ips"` // the IPs of a virtual network interface, including its primary IP
+		SecurityGroups     []string      `json:"security_groups"`
+		virtual            bool          // the virtual network interface of a network attachment
+	}
+
+	// virtualNifIP binds a reserved IP to a virtual network interface, as a secondary IP
+	virtualNifIP struct {
+		VirtualNetworkInterface string `json:"virtual_network_interface"`
+		Address                 string `json:"address"`
+	}
+
+	// boundReservedIP is a reserved IP of a subnet, which may be bound to its target
+	boundReservedIP struct {
+		Address string `json:"address"`
+		Target  string `json:"target"`
+	}
+
+	reservedIP struct {
+		Address string `json:"address"`
+	}
+
+	vpe struct {
+		ID             string   `json:"id"`
+		Name           string   `json:"name"`
+		VPC            string   `json:"vpc"`
+		IPs            []*vpeIP `json:"ips"`
+		SecurityGroups []string `json:"security_groups"`
+	}
+
+	vpeIP struct {
+		Name    string `json:"name"`
+		Subnet  string `json:"subnet"`
+		Address string `json:"address"`
+	}
+
+	// loadBalancer is in the VPC of its subnets; its type is public, private or private_path
+	loadBalancer struct {
+		ID             string         `json:"id"`
+		Name           string         `json:"name"`
+		Type           string         `json:"type"`
+		Subnets        []string       `json:"subnets"`
+		PrivateIP      []*lbPrivateIP `json:"private_ip"`
+		SecurityGroups []string       `json:"security_groups"`
+	}
+
+	lbPrivateIP struct {
+		Address    string `json:"address"`
+		ReservedIP string `json:"reserved_ip"`
+	}
+
+	securityGroup struct {
+		ID      string    `json:"id"`
+		Name    string    `json:"name"`
+		VPC     string    `json:"vpc"`
+		Rules   []*sgRule `json:"rules"`
+		Address string    `json:"-"`
+	}
+
+	// sgRule is either an item of the rules of an ibm_is_security_group or an ibm_is_security_group_rule,
+	// whose protocol is given either by the protocol attribute or by a tcp/udp/icmp block
+	sgRule struct {
+		Group     string   `json:"group"`
+		Direction string   `json:"direction"`
+		IPVersion string   `json:"ip_version"`
+		Remote    string   `json:"remote"`
+		Local     string   `json:"local"`
+		Protocol  string   `json:"protocol"`
+		PortMin   *int64   `json:"port_min"`
+		PortMax   *int64   `json:"port_max"`
+		Type      *int64   `json:"type"`
+		Code      *int64   `json:"code"`
+		TCP       []*ports `json:"tcp"`
+		UDP       []*ports `json:"udp"`
+		ICMP      []*icmp  `json:"icmp"`
+		Address   string   `json:"-"` // empty for the items of the rules of an ibm_is_security_group
+	}
+
+	sgTarget struct {
+		SecurityGroup string `json:"security_group"`
+		Target        string `json:"target"`
+	}
+
+	networkACL struct {
+		ID      string     `json:"id"`
+		Name    string     `json:"name"`
+		VPC     string     `json:"vpc"`
+		Rules   []*aclRule `json:"rules"`
+		Address string     `json:"-"`
+	}
+
+	aclRule struct {
+		Action      string   `json:"action"`
+		Direction   string   `json:"direction"`
+		Source      string   `json:"source"`
+		Destination string   `json:"destination"`
+		TCP         []*ports `json:"tcp"`
+		UDP         []*ports `json:"udp"`
+		ICMP        []*icmp  `json:"icmp"`
+	}
+
+	transitGateway struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+
+	// tgwConnection is an ibm_tg_connection, whose id is the id of its gateway followed by its connection_id
+	tgwConnection struct {
+		ConnectionID         string          `json:"connection_id"`
+		Name                 string          `json:"name"`
+		Gateway              string          `json:"gateway"`
+		NetworkType          string          `json:"network_type"`
+		NetworkID            string          `json:"network_id"`
+		PrefixFilters        []*prefixFilter `json:"prefix_filters"`
+		PrefixFiltersDefault string          `json:"prefix_filters_default"`
+	}
+
+	// prefixFilter is either an item of the prefix_filters of an ibm_tg_connection or an ibm_tg_connection_prefix_filter
+	prefixFilter struct {
+		Gateway      string `json:"gateway"`
+		ConnectionID string `json:"connection_id"`
+		Action       string `json:"action"`
+		Prefix       string `json:"prefix"`
+		Ge           int64  `json:"ge"`
+		Le           int64  `json:"le"`
+	}
+
+	ports struct {
+		PortMin       *int64 `json:"port_min"`
+		PortMax       *int64 `json:"port_max"`
+		SourcePortMin *int64 `json:"source_port_min"`
+		SourcePortMax *int64 `json:"source_port_max"`
+	}
+
+	icmp struct {
+		Type *int64 `json:"type"`
+		Code *int64 `json:"code"`
+	}
+)
+
+// IsState returns true if the file holds the output of `terraform show -json` (a state or a plan)
+func IsState(filename string) bool {
+	data, err := os.ReadFile(filename)
+	return err == nil && IsStateBytes(data)
+}
+
+// IsStateBytes is IsState for the contents of a file
+func IsStateBytes(data []byte) bool {
+	state, err := unmarshalState(data)
+	return err == nil && state.FormatVersion != "" && (state.Values != nil || state.PlannedValues != nil)
+}
+
+func unmarshalState(data []byte) (*tfState, error) {
+	state := &tfState{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// unmarshalModel reads the managed resources of the state (or the planned values of a plan) from all modules
+func unmarshalModel(data []byte) (*model, error) {
+	state, err := unmarshalState(data)
+	if err != nil {
+		return nil, err
+	}
+	values := state.Values
+	if values == nil {
+		values = state.PlannedValues
+	}
+	if values == nil || values.RootModule == nil {
+		return nil, fmt.Errorf("the input does not hold terraform state or plan values")
+	}
+	res := &model{}
+	if err := res.addModule(values.RootModule); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (m *model) addModule(module *tfModule) error {
+	for _, r := range module.Resources {
+		if r.Mode != managedMode {
+			continue
+		}
+		if err := m.addResource(r); err != nil {
+			return fmt.Errorf("could not parse %s: %w", r.Address, err)
+		}
+	}
+	for _, child := range module.ChildModules {
+		if err := m.addModule(child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resourceAdders add the resources of each supported type to the model; resources of other types are ignored
+var resourceAdders = map[string]func(m *model, r *tfResource) error{
+	resourceTypeVPC:           func(m *model, r *tfResource) error { return appendValues(&m.vpcs, r.Values) },
+	resourceTypeAddressPrefix: func(m *model, r *tfResource) error { return appendValues(&m.addressPrefixes, r.Values) },
+	resourceTypeSubnet:        func(m *model, r *tfResource) error { return appendValues(&m.subnets, r.Values) },
+	resourceTypeInstance:      func(m *model, r *tfResource) error { return appendValues(&m.instances, r.Values) },
+	resourceTypeBareMetal:     func(m *model, r *tfResource) error { return appendValues(&m.bareMetals, r.Values) },
+	resourceTypeVirtualNif:    func(m *model, r *tfResource) error { return appendValues(&m.virtualNifs, r.Values) },
+	resourceTypeVirtualNifIP:  func(m *model, r *tfResource) error { return appendValues(&m.virtualNifIPs, r.Values) },
+	resourceTypeReservedIP:    func(m *model, r *tfResource) error { return appendValues(&m.reservedIPs, r.Values) },
+	resourceTypeVPE:           func(m *model, r *tfResource) error { return appendValues(&m.vpes, r.Values) },
+	resourceTypeLoadBalancer:  func(m *model, r *tfResource) error { return appendValues(&m.loadBalancers, r.Values) },
+	resourceTypeSG: func(m *model, r *tfResource) error {
+		return appendAddressedValues(&m.sgs, r, func(sg *securityGroup) *string { return &sg.Address })
+	},
+	resourceTypeSGRule: func(m *model, r *tfResource) error {
+		return appendAddressedValues(&m.sgRules, r, func(rule *sgRule) *string { return &rule.Address })
+	},
+	resourceTypeSGTarget: func(m *model, r *tfResource) error { return appendValues(&m.sgTargets, r.Values) },
+	resourceTypeACL: func(m *model, r *tfResource) error {
+		return appendAddressedValues(&m.acls, r, func(acl *networkACL) *string { return &acl.Address })
+	},
+	resourceTypePublicGateway: func(m *model, r *tfResource) error { return appendValues(&m.publicGateways, r.Values) },
+	resourceTypePGWAttachment: func(m *model, r *tfResource) error { return appendValues(&m.pgwAttachments, r.Values) },
+	resourceTypeFloatingIP:    func(m *model, r *tfResource) error { return appendValues(&m.floatingIPs, r.Values) },
+	resourceTypeTGW:           func(m *model, r *tfResource) error { return appendValues(&m.tgws, r.Values) },
+	resourceTypeTGWConnection: func(m *model, r *tfResource) error { return appendValues(&m.tgwConnections, r.Values) },
+	resourceTypePrefixFilter:  func(m *model, r *tfResource) error { return appendValues(&m.prefixFilters, r.Values) },
+}
+
+func (m *model) addResource(r *tfResource) error {
+	if add, ok := resourceAdders[r.Type]; ok {
+		return add(m, r)
+	}
+	return nil
+}
+
+func appendValues[T any](list *[]*T, values json.RawMessage) error {
+	item := new(T)
+	if err := json.Unmarshal(values, item); err != nil {
+		return err
+	}
+	*list = append(*list, item)
+	return nil
+}
+
+// appendAddressedValues is appendValues for resources that keep their address, to which moved blocks may refer
+func appendAddressedValues[T any](list *[]*T, r *tfResource, address func(*T) *string) error {
+	if err := appendValues(list, r.Values); err != nil {
+		return err
+	}
+	*address((*list)[len(*list)-1]) = r.Address
+	return nil
+}
+
+// lookupName returns the name of the resource with the given ID (or name, since references may use either)
+func lookupName[T any](list []*T, ref string, id, name func(*T) string) (string, error) {
+	if ref == "" {
+		return "", fmt.Errorf("missing reference; unknown values of a plan are not supported")
+	}
+	for _, item := range list {
+		if id(item) == ref || name(item) == ref {
+			return name(item), nil
+		}
+	}
+	return "", fmt.Errorf("unknown reference %s", ref)
+}
+
+func (m *model) vpcName(ref string) (string, error) {
+	return lookupName(m.vpcs, ref, func(v *vpc) string { return v.ID }, func(v *vpc) string { return v.Name })
+}
+
+func (m *model) subnetName(ref string) (string, error) {
+	return lookupName(m.subnets, ref, func(s *subnet) string { return s.ID }, func(s *subnet) string { return s.Name })
+}
+
+// scopedSubnetName returns the name of the subnet with the given ID or name, scoped by the name of its VPC
+func (m *model) scopedSubnetName(ref string) (string, error) {
+	if _, err := m.subnetName(ref); err != nil {
+		return "", err
+	}
+	subnet := m.subnets[slices.IndexFunc(m.subnets, func(s *subnet) bool { return s.ID == ref || s.Name == ref })]
+	vpcName, err := m.vpcName(subnet.VPC)
+	if err != nil {
+		return "", err
+	}
+	return scopingString(vpcName, subnet.Name), nil
+}
+
+func (m *model) sgName(ref string) (string, error) {
+	return lookupName(m.sgs, ref, func(s *securityGroup) string { return s.ID }, func(s *securityGroup) string { return s.Name })
+}
+
+func (m *model) aclName(ref string) (string, error) {
+	return lookupName(m.acls, ref, func(a *networkACL) string { return a.ID }, func(a *networkACL) string { return a.Name })
+}
+
+func (m *model) publicGatewayName(ref string) (string, error) {
+	return lookupName(m.publicGateways, ref, func(p *publicGateway) string { return p.ID }, func(p *publicGateway) string { return p.Name })
+}
+
+func (m *model) tgwName(ref string) (string, error) {
+	return lookupName(m.tgws, ref, func(t *transitGateway) string { return t.ID }, func(t *transitGateway) string { return t.Name })
+}
+
+// servers returns the instances followed by the bare metal servers
+func (m *model) servers() []*instance {
+	return slices.Concat(m.instances, m.bareMetals)
+}
+
+// nifs returns the primary network interface of the instance followed by its other network interfaces, and then the
+// virtual network interfaces of its primary network attachment and of its other network attachments
+func (m *model) nifs(i *instance) []*networkInterface {
+	res := slices.Concat(i.PrimaryNetworkInterface, i.NetworkInterfaces)
+	for _, attachment := range slices.Concat(i.PrimaryNetworkAttachment, i.NetworkAttachments) {
+		for _, vni := range attachment.VirtualNetworkInterface {
+			virtualNif := *m.virtualNif(vni)
+			virtualNif.virtual = true
+			res = append(res, &virtualNif)
+		}
+	}
+	return res
+}
+
+// virtualNif returns the ibm_is_virtual_network_interface with the id of the given virtual network interface, if any
+func (m *model) virtualNif(vni *networkInterface) *networkInterface {
+	for _, v := range m.virtualNifs {
+		if vni.ID != "" && v.ID == vni.ID {
+			return v
+		}
+	}
+	return vni
+}
+
+func (n *networkInterface) address() string {
+	if len(n.PrimaryIP) > 0 && n.PrimaryIP[0].Address != "" {
+		return n.PrimaryIP[0].Address
+	}
+	return n.PrimaryIPv4Address
+}
+
+func scopingString(s1, s2 string) string {
+	return s1 + "/" + s2
+}
@@ -0,0 +1,424 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package ipset implements sets of IPv4 and IPv6 addresses, and the traffic sets built on them.
+// netset.IPBlock of np-guard/models only holds IPv4 addresses; the IPv4 addresses of an IPBlock are held by a
+// netset.IPBlock, so that IPv4-only blocks behave (and hash, print and compare) exactly as netset.IPBlock does.
+package ipset
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/np-guard/models/pkg/netset"
+)
+
+const (
+	// CidrAll represents the CIDR for all IPv4 addresses "0.0.0.0/0"
+	CidrAll = netset.CidrAll
+
+	// IPv6CidrAll represents the CIDR for all IPv6 addresses "::/0"
+	IPv6CidrAll = "::/0"
+
+	cidrSeparator  = "/"
+	ipv6Separator  = ":"
+	commaSeparator = ", "
+	comma          = ","
+)
+
+// IPBlock captures a set of IPv4 and IPv6 addresses
+type IPBlock struct {
+	ipv4 *netset.IPBlock
+	ipv6 []ipv6Range // sorted, disjoint and non-touching; nil if there are no IPv6 addresses
+}
+
+// NewIPBlock returns a new empty IPBlock object
+func NewIPBlock() *IPBlock {
+	return &IPBlock{ipv4: netset.NewIPBlock()}
+}
+
+func fromIPv4(ipv4 *netset.IPBlock) *IPBlock {
+	return &IPBlock{ipv4: ipv4}
+}
+
+func fromIPv6(ipv6 []ipv6Range) *IPBlock {
+	return &IPBlock{ipv4: netset.NewIPBlock(), ipv6: ipv6}
+}
+
+// IPBlockFromCidr returns a new IPBlock object from an IPv4 or IPv6 CIDR string
+func IPBlockFromCidr(cidr string) (*IPBlock, error) {
+	if !strings.Contains(cidr, ipv6Separator) {
+		ipv4, err := netset.IPBlockFromCidr(cidr)
+		if err != nil {
+			return nil, err
+		}
+		return fromIPv4(ipv4), nil
+	}
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return nil, err
+	}
+	prefix = prefix.Masked()
+	return fromIPv6([]ipv6Range{{start: prefix.Addr(), end: lastAddr(prefix)}}), nil
+}
+
+// IPBlockFromIPAddress returns a new IPBlock object from an IPv4 or IPv6 address string
+func IPBlockFromIPAddress(ipAddress string) (*IPBlock, error) {
+	if !strings.Contains(ipAddress, ipv6Separator) {
+		ipv4, err := netset.IPBlockFromIPAddress(ipAddress)
+		if err != nil {
+			return nil, err
+		}
+		return fromIPv4(ipv4), nil
+	}
+	addr, err := netip.ParseAddr(ipAddress)
+	if err != nil {
+		return nil, err
+	}
+	if addr.Zone() != "" {
+		return nil, fmt.Errorf("%v is an IPv6 address with a zone", ipAddress)
+	}
+	return fromIPv6([]ipv6Range{{start: addr, end: addr}}), nil
+}
+
+// IPBlockFromCidrOrAddress returns a new IPBlock object from a CIDR or an IP address string
+func IPBlockFromCidrOrAddress(s string) (*IPBlock, error) {
+	if strings.Contains(s, cidrSeparator) {
+		return IPBlockFromCidr(s)
+	}
+	return IPBlockFromIPAddress(s)
+}
+
+// IPBlockFromCidrList returns an IPBlock object from multiple CIDRs given as list of strings
+func IPBlockFromCidrList(cidrsList []string) (*IPBlock, error) {
+	res := NewIPBlock()
+	for _, cidr := range cidrsList {
+		block, err := IPBlockFromCidr(cidr)
+		if err != nil {
+			return nil, err
+		}
+		res = res.Union(block)
+	}
+	return res, nil
+}
+
+// IPBlockFromIPRange returns a new IPBlock object that contains startIP-endIP, which must be of the same IP version
+func IPBlockFromIPRange(startIP, endIP *IPBlock) (*IPBlock, error) {
+	if !startIP.IsSingleIPAddress() || !endIP.IsSingleIPAddress() {
+		return nil, fmt.Errorf("both startIP and endIP should be a single IP address")
+	}
+	if startIP.IsIPv6() != endIP.IsIPv6() {
+		return nil, fmt.Errorf("startIP %v and endIP %v are of different IP versions", startIP, endIP)
+	}
+	if !startIP.IsIPv6() {
+		ipv4, err := netset.IPBlockFromIPRange(startIP.ipv4, endIP.ipv4)
+		if err != nil {
+			return nil, err
+		}
+		return fromIPv4(ipv4), nil
+	}
+	return fromIPv6(normalize([]ipv6Range{{start: startIP.ipv6[0].start, end: endIP.ipv6[0].start}})), nil
+}
+
+// GetCidrAll returns IPBlock object of all the IPv4 addresses 0.0.0.0/0
+func GetCidrAll() *IPBlock {
+	return fromIPv4(netset.GetCidrAll())
+}
+
+// GetIPv6CidrAll returns IPBlock object of all the IPv6 addresses ::/0
+func GetIPv6CidrAll() *IPBlock {
+	res, _ := IPBlockFromCidr(IPv6CidrAll)
+	return res
+}
+
+// CidrAllOf returns all the addresses of the IP versions of the given IPBlock: 0.0.0.0/0, ::/0 or both.
+// An empty IPBlock is taken as an IPv4 one.
+func CidrAllOf(b *IPBlock) *IPBlock {
+	res := NewIPBlock()
+	if !b.ipv4.IsEmpty() || b.ipv6 == nil {
+		res = res.Union(GetCidrAll())
+	}
+	if b.ipv6 != nil {
+		res = res.Union(GetIPv6CidrAll())
+	}
+	return res
+}
+
+// SplitByIPVersion returns the IPv4 addresses of the IPBlock, followed by its IPv6 addresses, omitting empty ones
+func (b *IPBlock) SplitByIPVersion() []*IPBlock {
+	var res []*IPBlock
+	if !b.ipv4.IsEmpty() {
+		res = append(res, fromIPv4(b.ipv4.Copy()))
+	}
+	if b.ipv6 != nil {
+		res = append(res, fromIPv6(copyRanges(b.ipv6)))
+	}
+	return res
+}
+
+// IsIPv6 returns true if the IPBlock is a non-empty set of IPv6 addresses only
+func (b *IPBlock) IsIPv6() bool {
+	return b.ipv4.IsEmpty() && b.ipv6 != nil
+}
+
+// IsSubset checks if this IP block is contained within another IP block.
+func (b *IPBlock) IsSubset(other *IPBlock) bool {
+	if b == other {
+		return true
+	}
+	return b.ipv4.IsSubset(other.ipv4) && subtract(b.ipv6, other.ipv6) == nil
+}
+
+// Intersect returns a new IPBlock from intersection of this IPBlock with input IPBlock
+func (b *IPBlock) Intersect(c *IPBlock) *IPBlock {
+	return &IPBlock{ipv4: b.ipv4.Intersect(c.ipv4), ipv6: intersect(b.ipv6, c.ipv6)}
+}
+
+// Equal returns true if this IPBlock equals the input IPBlock
+func (b *IPBlock) Equal(c *IPBlock) bool {
+	if b == c {
+		return true
+	}
+	if !b.ipv4.Equal(c.ipv4) || len(b.ipv6) != len(c.ipv6) {
+		return false
+	}
+	for i := range b.ipv6 {
+		if b.ipv6[i] != c.ipv6[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Hash returns the hash of the IPBlock, which is the hash of netset.IPBlock for IPv4-only blocks
+func (b *IPBlock) Hash() int {
+	res := b.ipv4.Hash()
+	if b.ipv6 != nil {
+		h := fnv.New64a()
+		for _, r := range b.ipv6 {
+			start, end := r.start.As16(), r.end.As16()
+			h.Write(start[:])
+			h.Write(end[:])
+		}
+		res ^= int(h.Sum64() & math.MaxInt)
+	}
+	return res
+}
+
+// Size returns the number of addresses in the IPBlock, up to math.MaxInt
+func (b *IPBlock) Size() int {
+	res := b.ipv4.Size()
+	for _, r := range b.ipv6 {
+		res = addCapped(res, r.size())
+	}
+	return res
+}
+
+// Subtract returns a new IPBlock from subtraction of input IPBlock from this IPBlock
+func (b *IPBlock) Subtract(c *IPBlock) *IPBlock {
+	if b == c {
+		return NewIPBlock()
+	}
+	return &IPBlock{ipv4: b.ipv4.Subtract(c.ipv4), ipv6: subtract(b.ipv6, c.ipv6)}
+}
+
+// Overlap returns whether the two IPBlocks have at least one IP address in common
+func (b *IPBlock) Overlap(c *IPBlock) bool {
+	return !b.Intersect(c).IsEmpty()
+}
+
+// Union returns a new IPBlock from union of input IPBlock with this IPBlock
+func (b *IPBlock) Union(c *IPBlock) *IPBlock {
+	return &IPBlock{ipv4: b.ipv4.Union(c.ipv4), ipv6: union(b.ipv6, c.ipv6)}
+}
+
+// IsEmpty returns true if this IPBlock is empty
+func (b *IPBlock) IsEmpty() bool {
+	return b.ipv4.IsEmpty() && b.ipv6 == nil
+}
+
+// Copy returns a new copy of IPBlock object
+func (b *IPBlock) Copy() *IPBlock {
+	return &IPBlock{ipv4: b.ipv4.Copy(), ipv6: copyRanges(b.ipv6)}
+}
+
+// IsSingleIPAddress returns true if this IPBlock is a single IP address
+func (b *IPBlock) IsSingleIPAddress() bool {
+	if b.ipv6 == nil {
+		return b.ipv4.IsSingleIPAddress()
+	}
+	return b.ipv4.IsEmpty() && len(b.ipv6) == 1 && b.ipv6[0].start == b.ipv6[0].end
+}
+
+// Compare returns -1 if this<other, 1 if this>other, 0 o.w.; IPv4 addresses precede IPv6 addresses
+func (b *IPBlock) Compare(other *IPBlock) int {
+	if b.ipv6 == nil && other.ipv6 == nil {
+		return b.ipv4.Compare(other.ipv4)
+	}
+	if res := b.FirstIPAddressObject().compareSingle(other.FirstIPAddressObject()); res != 0 {
+		return res
+	}
+	return b.LastIPAddressObject().compareSingle(other.LastIPAddressObject())
+}
+
+// compareSingle compares two single IP addresses
+func (b *IPBlock) compareSingle(other *IPBlock) int {
+	switch {
+	case b.IsIPv6() && other.IsIPv6():
+		return b.ipv6[0].start.Compare(other.ipv6[0].start)
+	case b.IsIPv6():
+		return 1
+	case other.IsIPv6():
+		return -1
+	}
+	return b.ipv4.Compare(other.ipv4)
+}
+
+// Split returns a set of IPBlock objects, each with a single range of ips
+func (b *IPBlock) Split() []*IPBlock {
+	var res []*IPBlock
+	for _, ipv4 := range b.ipv4.Split() {
+		res = append(res, fromIPv4(ipv4))
+	}
+	for _, r := range b.ipv6 {
+		res = append(res, fromIPv6([]ipv6Range{r}))
+	}
+	return res
+}
+
+// SplitToCidrs returns a slice of IPBlocks, each representing a single CIDR
+func (b *IPBlock) SplitToCidrs() []*IPBlock {
+	res := make([]*IPBlock, 0)
+	for _, ipv4 := range b.ipv4.SplitToCidrs() {
+		res = append(res, fromIPv4(ipv4))
+	}
+	for _, r := range b.ipv6 {
+		for _, prefix := range r.prefixes() {
+			res = append(res, fromIPv6([]ipv6Range{{start: prefix.Addr(), end: lastAddr(prefix)}}))
+		}
+	}
+	return res
+}
+
+// ToCidrList returns a list of CIDR strings for this IPBlock object
+func (b *IPBlock) ToCidrList() []string {
+	res := b.ipv4.ToCidrList()
+	for _, r := range b.ipv6 {
+		for _, prefix := range r.prefixes() {
+			res = append(res, prefix.String())
+		}
+	}
+	return res
+}
+
+// ToCidrListString returns a string with all CIDRs within the IPBlock object
+func (b *IPBlock) ToCidrListString() string {
+	return strings.Join(b.ToCidrList(), commaSeparator)
+}
+
+// ToIPAddressString returns the IP Address string for this IPBlock
+func (b *IPBlock) ToIPAddressString() string {
+	if b.IsSingleIPAddress() {
+		return b.FirstIPAddress()
+	}
+	return ""
+}
+
+// FirstIPAddress returns the first IP Address string for this IPBlock
+func (b *IPBlock) FirstIPAddress() string {
+	if b.IsIPv6() {
+		return b.ipv6[0].start.String()
+	}
+	return b.ipv4.FirstIPAddress()
+}
+
+// FirstIPAddressObject returns the first IP Address for this IPBlock
+func (b *IPBlock) FirstIPAddressObject() *IPBlock {
+	if b.IsIPv6() {
+		return fromIPv6([]ipv6Range{{start: b.ipv6[0].start, end: b.ipv6[0].start}})
+	}
+	return fromIPv4(b.ipv4.FirstIPAddressObject())
+}
+
+// LastIPAddressObject returns the last IP Address for this IPBlock
+func (b *IPBlock) LastIPAddressObject() *IPBlock {
+	if b.ipv6 != nil {
+		last := b.ipv6[len(b.ipv6)-1].end
+		return fromIPv6([]ipv6Range{{start: last, end: last}})
+	}
+	return fromIPv4(b.ipv4.LastIPAddressObject())
+}
+
+// NextIP returns the next ip address after this IPBlock, of the IP version of its last address
+func (b *IPBlock) NextIP() (*IPBlock, error) {
+	if b.ipv6 == nil {
+		ipv4, err := b.ipv4.NextIP()
+		if err != nil {
+			return nil, err
+		}
+		return fromIPv4(ipv4), nil
+	}
+	next := b.ipv6[len(b.ipv6)-1].end.Next()
+	if !next.IsValid() {
+		return nil, fmt.Errorf("the last IPv6 address is contained in IPBlock")
+	}
+	return fromIPv6([]ipv6Range{{start: next, end: next}}), nil
+}
+
+// PreviousIP returns the previous ip address before this IPBlock, of the IP version of its first address
+func (b *IPBlock) PreviousIP() (*IPBlock, error) {
+	if !b.IsIPv6() {
+		ipv4, err := b.ipv4.PreviousIP()
+		if err != nil {
+			return nil, err
+		}
+		return fromIPv4(ipv4), nil
+	}
+	prev := b.ipv6[0].start.Prev()
+	if !prev.IsValid() {
+		return nil, fmt.Errorf("the first IPv6 address is contained in IPBlock")
+	}
+	return fromIPv6([]ipv6Range{{start: prev, end: prev}}), nil
+}
+
+// PrefixLength returns the cidr's prefix length, assuming the ipBlock is exactly one cidr.
+func (b *IPBlock) PrefixLength() (int64, error) {
+	cidrs := b.ToCidrList()
+	if len(cidrs) != 1 {
+		return 0, errors.New("prefixLength err: ipBlock is not a single cidr")
+	}
+	lenStr := strings.Split(cidrs[0], cidrSeparator)[1]
+	return strconv.ParseInt(lenStr, 10, 64)
+}
+
+// MaxPrefixLength returns the length of the addresses of the IPBlock: 128 for IPv6 blocks, 32 otherwise
+func (b *IPBlock) MaxPrefixLength() int64 {
+	if b.IsIPv6() {
+		return ipv6Bits
+	}
+	return ipv4Bits
+}
+
+// TouchingIPRanges returns true if this and other ipblocks objects are touching.
+// assumption: both IPBlocks represent a single IP range
+func (b *IPBlock) TouchingIPRanges(other *IPBlock) (bool, error) {
+	if len(b.Split()) != 1 || len(other.Split()) != 1 {
+		return false, fmt.Errorf("both ipblocks should be a single IP range")
+	}
+	return !b.Overlap(other) && len(b.Union(other).Split()) == 1, nil
+}
+
+// String returns an IPBlock's string -- either single IP address, or list of CIDR strings
+func (b *IPBlock) String() string {
+	if b.IsSingleIPAddress() {
+		return b.FirstIPAddress()
+	}
+	return b.ToCidrListString()
+}
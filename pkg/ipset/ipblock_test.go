@@ -0,0 +1,70 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ipset
+
+import (
+	"slices"
+	"testing"
+)
+
+func mustCidrs(t *testing.T, cidrs ...string) *IPBlock {
+	t.Helper()
+	res, err := IPBlockFromCidrList(cidrs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+func TestDualStack(t *testing.T) {
+	block := mustCidrs(t, "10.0.0.0/24", "2001:db8::/65", "2001:db8:0:0:8000::/65")
+	if got, want := block.ToCidrList(), []string{"10.0.0.0/24", "2001:db8::/64"}; !slices.Equal(got, want) {
+		t.Errorf("ToCidrList() = %v, want %v", got, want)
+	}
+	parts := block.SplitByIPVersion()
+	if len(parts) != 2 || parts[0].IsIPv6() || !parts[1].IsIPv6() {
+		t.Fatalf("SplitByIPVersion() = %v", parts)
+	}
+	if !CidrAllOf(block).Equal(mustCidrs(t, CidrAll, IPv6CidrAll)) {
+		t.Errorf("CidrAllOf(%v) = %v", block, CidrAllOf(block))
+	}
+	ipv6 := mustCidrs(t, "2001:db8::/64")
+	if !block.Subtract(ipv6).Equal(mustCidrs(t, "10.0.0.0/24")) {
+		t.Errorf("Subtract(%v) = %v", ipv6, block.Subtract(ipv6))
+	}
+	if block.Intersect(GetIPv6CidrAll()).String() != "2001:db8::/64" {
+		t.Errorf("Intersect(%s) = %v", IPv6CidrAll, block.Intersect(GetIPv6CidrAll()))
+	}
+}
+
+func TestIPv6Ranges(t *testing.T) {
+	start, err := IPBlockFromIPAddress("2001:db8::1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	end, err := IPBlockFromIPAddress("2001:db8::6")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := IPBlockFromIPRange(start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"2001:db8::1/128", "2001:db8::2/127", "2001:db8::4/127", "2001:db8::6/128"}
+	if got := r.ToCidrList(); !slices.Equal(got, want) {
+		t.Errorf("ToCidrList() = %v, want %v", got, want)
+	}
+	next, err := r.NextIP()
+	if err != nil || next.String() != "2001:db8::7" {
+		t.Errorf("NextIP() = %v, %v", next, err)
+	}
+	if _, err := IPBlockFromIPRange(mustCidrs(t, "10.0.0.1/32"), end); err == nil {
+		t.Error("IPBlockFromIPRange() of different IP versions should fail")
+	}
+	if _, err := GetIPv6CidrAll().NextIP(); err == nil {
+		t.Error("NextIP() of the last IPv6 address should fail")
+	}
+}
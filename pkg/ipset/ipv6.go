@@ -0,0 +1,162 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ipset
+
+import (
+	"math"
+	"math/big"
+	"net/netip"
+	"slices"
+)
+
+const (
+	ipv4Bits = 32
+	ipv6Bits = 128
+	byteBits = 8
+)
+
+// ipv6Range is the range of IPv6 addresses from start to end, inclusive
+type ipv6Range struct {
+	start, end netip.Addr
+}
+
+func (r ipv6Range) size() int {
+	start, end := r.start.As16(), r.end.As16()
+	size := new(big.Int).Sub(new(big.Int).SetBytes(end[:]), new(big.Int).SetBytes(start[:]))
+	size.Add(size, big.NewInt(1))
+	if !size.IsInt64() || size.Int64() > math.MaxInt {
+		return math.MaxInt
+	}
+	return int(size.Int64())
+}
+
+// prefixes returns the minimal list of CIDRs that make up the range
+func (r ipv6Range) prefixes() []netip.Prefix {
+	var res []netip.Prefix
+	start := r.start
+	for {
+		prefix := largestPrefix(start, r.end)
+		res = append(res, prefix)
+		last := lastAddr(prefix)
+		if last == r.end {
+			return res
+		}
+		start = last.Next()
+	}
+}
+
+// largestPrefix returns the largest CIDR that starts with start and does not exceed end
+func largestPrefix(start, end netip.Addr) netip.Prefix {
+	for bits := 0; bits < ipv6Bits; bits++ {
+		prefix := netip.PrefixFrom(start, bits)
+		if prefix.Masked().Addr() == start && lastAddr(prefix).Compare(end) <= 0 {
+			return prefix
+		}
+	}
+	return netip.PrefixFrom(start, ipv6Bits)
+}
+
+// lastAddr returns the last address of a CIDR
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	addr := prefix.Masked().Addr().As16()
+	for i := prefix.Bits(); i < ipv6Bits; i++ {
+		addr[i/byteBits] |= 1 << (byteBits - 1 - i%byteBits)
+	}
+	return netip.AddrFrom16(addr)
+}
+
+// touches returns true if the ranges, sorted by their start addresses, overlap or are adjacent
+func touches(r1, r2 ipv6Range) bool {
+	return r2.start.Compare(r1.end) <= 0 || r1.end.Next() == r2.start
+}
+
+// normalize sorts the ranges and merges the ranges that touch; it returns nil if there are no ranges
+func normalize(ranges []ipv6Range) []ipv6Range {
+	ranges = slices.DeleteFunc(ranges, func(r ipv6Range) bool { return r.end.Less(r.start) })
+	slices.SortFunc(ranges, func(r1, r2 ipv6Range) int { return r1.start.Compare(r2.start) })
+	var res []ipv6Range
+	for _, r := range ranges {
+		if len(res) > 0 && touches(res[len(res)-1], r) {
+			if res[len(res)-1].end.Less(r.end) {
+				res[len(res)-1].end = r.end
+			}
+			continue
+		}
+		res = append(res, r)
+	}
+	return res
+}
+
+func copyRanges(ranges []ipv6Range) []ipv6Range {
+	if ranges == nil {
+		return nil
+	}
+	return slices.Clone(ranges)
+}
+
+func union(ranges1, ranges2 []ipv6Range) []ipv6Range {
+	return normalize(slices.Concat(ranges1, ranges2))
+}
+
+func intersect(ranges1, ranges2 []ipv6Range) []ipv6Range {
+	var res []ipv6Range
+	for i, j := 0, 0; i < len(ranges1) && j < len(ranges2); {
+		start, end := maxAddr(ranges1[i].start, ranges2[j].start), minAddr(ranges1[i].end, ranges2[j].end)
+		if !end.Less(start) {
+			res = append(res, ipv6Range{start: start, end: end})
+		}
+		if ranges1[i].end.Less(ranges2[j].end) {
+			i++
+		} else {
+			j++
+		}
+	}
+	return res
+}
+
+func subtract(ranges1, ranges2 []ipv6Range) []ipv6Range {
+	var res []ipv6Range
+	for _, r := range ranges1 {
+		for _, hole := range ranges2 {
+			if hole.end.Less(r.start) || r.end.Less(hole.start) {
+				continue
+			}
+			if r.start.Less(hole.start) {
+				res = append(res, ipv6Range{start: r.start, end: hole.start.Prev()})
+			}
+			if !hole.end.Less(r.end) {
+				r.start = netip.Addr{}
+				break
+			}
+			r.start = hole.end.Next()
+		}
+		if r.start.IsValid() {
+			res = append(res, r)
+		}
+	}
+	return res
+}
+
+func maxAddr(a1, a2 netip.Addr) netip.Addr {
+	if a1.Less(a2) {
+		return a2
+	}
+	return a1
+}
+
+func minAddr(a1, a2 netip.Addr) netip.Addr {
+	if a1.Less(a2) {
+		return a1
+	}
+	return a2
+}
+
+func addCapped(a, b int) int {
+	if a > math.MaxInt-b {
+		return math.MaxInt
+	}
+	return a + b
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ipset
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/np-guard/models/pkg/ds"
+	"github.com/np-guard/models/pkg/netset"
+)
+
+// EndpointsTrafficSet captures a set of traffic attributes for tuples of (source IP range, destination IP range,
+// TransportSet), as netset.EndpointsTrafficSet does for IPv4 addresses only
+type EndpointsTrafficSet struct {
+	props ds.TripleSet[*IPBlock, *IPBlock, *netset.TransportSet]
+}
+
+// EmptyEndpointsTrafficSet returns an empty EndpointsTrafficSet
+func EmptyEndpointsTrafficSet() *EndpointsTrafficSet {
+	return &EndpointsTrafficSet{props: ds.NewLeftTripleSet[*IPBlock, *IPBlock, *netset.TransportSet]()}
+}
+
+// NewEndpointsTrafficSet returns a new EndpointsTrafficSet object from input src, dst IP-ranges sets and
+// TransportSet connections. Traffic flows between addresses of the same IP version only, so the cartesian product
+// is taken per IP version.
+func NewEndpointsTrafficSet(src, dst *IPBlock, conn *netset.TransportSet) *EndpointsTrafficSet {
+	res := EmptyEndpointsTrafficSet()
+	for _, all := range []*IPBlock{GetCidrAll(), GetIPv6CidrAll()} {
+		srcOfVersion, dstOfVersion := src.Intersect(all), dst.Intersect(all)
+		if !srcOfVersion.IsEmpty() && !dstOfVersion.IsEmpty() {
+			res = res.Union(&EndpointsTrafficSet{props: ds.CartesianLeftTriple(srcOfVersion, dstOfVersion, conn)})
+		}
+	}
+	return res
+}
+
+// Equal returns true is this EndpointsTrafficSet captures the exact same set of connections as `other` does.
+func (c *EndpointsTrafficSet) Equal(other *EndpointsTrafficSet) bool {
+	return c.props.Equal(other.props)
+}
+
+// Copy returns new EndpointsTrafficSet object with same set of connections as current one
+func (c *EndpointsTrafficSet) Copy() *EndpointsTrafficSet {
+	return &EndpointsTrafficSet{props: c.props.Copy()}
+}
+
+// Intersect returns a EndpointsTrafficSet object with connection tuples that result from intersection of
+// this and `other` sets
+func (c *EndpointsTrafficSet) Intersect(other *EndpointsTrafficSet) *EndpointsTrafficSet {
+	return &EndpointsTrafficSet{props: c.props.Intersect(other.props)}
+}
+
+// IsEmpty returns true of the EndpointsTrafficSet is empty
+func (c *EndpointsTrafficSet) IsEmpty() bool {
+	return c.props.IsEmpty()
+}
+
+// Union returns a EndpointsTrafficSet object with connection tuples that result from union of
+// this and `other` sets
+func (c *EndpointsTrafficSet) Union(other *EndpointsTrafficSet) *EndpointsTrafficSet {
+	if other.IsEmpty() {
+		return c.Copy()
+	}
+	if c.IsEmpty() {
+		return other.Copy()
+	}
+	return &EndpointsTrafficSet{props: c.props.Union(other.props)}
+}
+
+// Subtract returns a EndpointsTrafficSet object with connection tuples that result from subtraction of
+// `other` from this set
+func (c *EndpointsTrafficSet) Subtract(other *EndpointsTrafficSet) *EndpointsTrafficSet {
+	if other.IsEmpty() {
+		return c.Copy()
+	}
+	return &EndpointsTrafficSet{props: c.props.Subtract(other.props)}
+}
+
+// IsSubset returns true if c is subset of other
+func (c *EndpointsTrafficSet) IsSubset(other *EndpointsTrafficSet) bool {
+	return c.props.IsSubset(other.props)
+}
+
+func (c *EndpointsTrafficSet) Partitions() []ds.Triple[*IPBlock, *IPBlock, *netset.TransportSet] {
+	return c.props.Partitions()
+}
+
+func (c *EndpointsTrafficSet) String() string {
+	return strings.Join(c.partitionStrings(), comma)
+}
+
+// Lines returns the partitions of the set in sorted order, a line each, indented by the given prefix
+func (c *EndpointsTrafficSet) Lines(indent string) string {
+	var sb strings.Builder
+	for _, s := range c.partitionStrings() {
+		sb.WriteString(indent + s + "\n")
+	}
+	return sb.String()
+}
+
+func (c *EndpointsTrafficSet) partitionStrings() []string {
+	cubes := c.Partitions()
+	var resStrings = make([]string, len(cubes))
+	for i, cube := range cubes {
+		resStrings[i] = fmt.Sprintf("src: %s, dst: %s, conns: %s", cube.S1.String(), cube.S2.String(), cube.S3.String())
+	}
+	sort.Strings(resStrings)
+	return resStrings
+}
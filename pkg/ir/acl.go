@@ -12,8 +12,8 @@ import (
 	"strings"
 
 	"github.com/np-guard/models/pkg/netp"
-	"github.com/np-guard/models/pkg/netset"
 
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ipset"
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/utils"
 )
 
@@ -23,19 +23,28 @@ type (
 	ACLRule struct {
 		Action      Action
 		Direction   Direction
-		Source      *netset.IPBlock
-		Destination *netset.IPBlock
+		Source      *ipset.IPBlock
+		Destination *ipset.IPBlock
 		Protocol    netp.Protocol
 		Explanation string
+		Origins     []*RuleOrigin // the spec connections a synthesized rule serves
 	}
 
 	ACL struct {
 		Name    string
 		Subnets []string
+		ID      string // the id of an nACL read from a config object
+		Address string // the address of an nACL read from a terraform state
 
-		// Internal and External are used for synthesis
-		Internal []*ACLRule
-		External []*ACLRule
+		// Forbidden, Internal and External are used for synthesis
+		Forbidden []*ACLRule // deny rules of forbidden connections, preceding all other rules
+		Internal  []*ACLRule
+		External  []*ACLRule
+
+		// InternalAddrs is the address space that is denied between the Internal and the External rules,
+		// so that external rules do not allow internal traffic; nil means PrivateAddresses: the rfc1918 private address space
+		// and the IPv6 unique local addresses (fc00::/7)
+		InternalAddrs *ipset.IPBlock
 
 		// Inbound and Outbound are used for optimization
 		Inbound  []*ACLRule
@@ -56,9 +65,11 @@ const (
 	Deny  Action = "deny"
 )
 
+// isRedundant returns true if one of the rules supersedes the rule, and adds the origins of the rule to it
 func (r *ACLRule) isRedundant(rules []*ACLRule) bool {
 	for _, rule := range rules {
 		if rule.mustSupersede(r) {
+			rule.Origins = MergeOrigins(rule.Origins, r.Origins)
 			return true
 		}
 	}
@@ -66,14 +77,14 @@ func (r *ACLRule) isRedundant(rules []*ACLRule) bool {
 }
 
 func (r *ACLRule) mustSupersede(other *ACLRule) bool {
-	otherExplanation := other.Explanation
-	other.Explanation = r.Explanation
+	otherExplanation, otherOrigins := other.Explanation, other.Origins
+	other.Explanation, other.Origins = r.Explanation, r.Origins
 	res := reflect.DeepEqual(r, other)
-	other.Explanation = otherExplanation
+	other.Explanation, other.Origins = otherExplanation, otherOrigins
 	return res
 }
 
-func (r *ACLRule) Target() *netset.IPBlock {
+func (r *ACLRule) Target() *ipset.IPBlock {
 	if r.Direction == Inbound {
 		return r.Destination
 	}
@@ -81,16 +92,48 @@ func (r *ACLRule) Target() *netset.IPBlock {
 }
 
 func (a *ACL) Rules() []*ACLRule {
-	if a.Internal == nil && a.External == nil { // optimization mode
+	if a.Forbidden == nil && a.Internal == nil && a.External == nil { // optimization mode
 		return slices.Concat(a.Inbound, a.Outbound)
 	}
-	rules := a.Internal
+	rules := slices.Concat(a.Forbidden, a.Internal)
 	if len(a.External) != 0 {
-		rules = slices.Concat(rules, makeDenyInternal(), a.External)
+		rules = slices.Concat(rules, makeDenyInternal(a.InternalAddrs), a.External)
 	}
 	return rules
 }
 
+// DirectionRules returns the rules of the nACL in the given direction, in order, in both synthesis and optimization modes
+func (a *ACL) DirectionRules(direction Direction) []*ACLRule {
+	return slices.DeleteFunc(a.Rules(), func(rule *ACLRule) bool { return rule.Direction != direction })
+}
+
+// SetDirectionalRules moves the rules of synthesized nACLs to their Inbound and Outbound rules, keeping their order
+// within each direction, as required for optimization
+func (c *ACLCollection) SetDirectionalRules() {
+	for _, vpc := range utils.SortedMapKeys(c.ACLs) {
+		for _, aclName := range utils.SortedMapKeys(c.ACLs[vpc]) {
+			acl := c.ACLs[vpc][aclName]
+			if acl.Forbidden == nil && acl.Internal == nil && acl.External == nil {
+				continue
+			}
+			for _, rule := range acl.Rules() {
+				if rule.Direction == Inbound {
+					acl.Inbound = append(acl.Inbound, rule)
+				} else {
+					acl.Outbound = append(acl.Outbound, rule)
+				}
+			}
+			acl.Forbidden, acl.Internal, acl.External = nil, nil, nil
+		}
+	}
+}
+
+func (a *ACL) AppendForbidden(rule *ACLRule) {
+	if !rule.isRedundant(a.Forbidden) {
+		a.Forbidden = append(a.Forbidden, rule)
+	}
+}
+
 func (a *ACL) AppendInternal(rule *ACLRule) {
 	if !rule.isRedundant(a.Internal) {
 		a.Internal = append(a.Internal, rule)
@@ -116,7 +159,7 @@ func NewACL(aclName, subnetName string) *ACL {
 	return &ACL{Name: aclName, Subnets: []string{subnetName}}
 }
 
-func NewACLRule(action Action, direction Direction, src, dst *netset.IPBlock, p netp.Protocol, e string) *ACLRule {
+func NewACLRule(action Action, direction Direction, src, dst *ipset.IPBlock, p netp.Protocol, e string) *ACLRule {
 	return &ACLRule{Action: action, Direction: direction, Source: src, Destination: dst, Protocol: p, Explanation: e}
 }
 
@@ -144,6 +187,23 @@ func (c *ACLCollection) LookupOrCreate(subnetName string, singleACL bool) *ACL {
 	return c.ACLs[vpcName][aclName]
 }
 
+// AttachedACL returns the nACL attached to the given (scoped) subnet, or nil if there is no such nACL.
+// aclName, the nACL referenced by the subnet itself, takes precedence over the nACLs' lists of attached subnets, which
+// hold the subnet names of a config, or the scoped subnet names of a synthesized collection
+func (c *ACLCollection) AttachedACL(subnet ID, aclName string) *ACL {
+	components := ScopingComponents(subnet)
+	vpcName, subnetName := components[0], components[1]
+	if acl, ok := c.ACLs[vpcName][aclName]; ok {
+		return acl
+	}
+	for _, name := range utils.SortedMapKeys(c.ACLs[vpcName]) {
+		if acl := c.ACLs[vpcName][name]; slices.Contains(acl.Subnets, subnetName) || slices.Contains(acl.Subnets, subnet) {
+			return acl
+		}
+	}
+	return nil
+}
+
 func (c *ACLCollection) VpcNames() []string {
 	return utils.SortedMapKeys(c.ACLs)
 }
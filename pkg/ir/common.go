@@ -16,6 +16,7 @@ type (
 	Writer interface {
 		ACLWriter
 		SGWriter
+		LayeredWriter
 	}
 )
 
@@ -0,0 +1,69 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ir
+
+import (
+	"slices"
+	"strings"
+)
+
+type (
+	Severity string
+
+	// Diagnostic is a problem or a notice raised while reading, synthesizing, optimizing or verifying SGs and nACLs
+	Diagnostic struct {
+		Severity  Severity `json:"severity"`
+		Code      string   `json:"code"`
+		Message   string   `json:"message"`
+		Resources []ID     `json:"resources,omitempty"` // the affected resources
+		Origins   []string `json:"origins,omitempty"`   // the spec elements that caused the diagnostic
+	}
+
+	Diagnostics []*Diagnostic
+)
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// Diagnostic codes
+const (
+	CodeUnspecifiedSG         = "unspecified-sg"
+	CodeUnspecifiedACL        = "unspecified-acl"
+	CodeUnroutableConnections = "unroutable-connections"
+	CodeNoPublicPath          = "no-public-path"
+	CodeMissingName           = "missing-name"
+	CodeUnattachedFirewall    = "unattached-firewall"
+	CodeBadAttachment         = "bad-attachment"
+	CodeRulesReduced          = "rules-reduced"
+	CodeBlockedConnections    = "blocked-connections"
+	CodeExtraConnections      = "extra-connections"
+	CodeRedundantProtocols    = "redundant-protocols"
+)
+
+func NewDiagnostic(severity Severity, code, message string, resources ...ID) *Diagnostic {
+	return &Diagnostic{Severity: severity, Code: code, Message: message, Resources: resources}
+}
+
+func (d *Diagnostic) String() string {
+	return d.Message
+}
+
+// Filter returns the diagnostics of the given severities
+func (d Diagnostics) Filter(severities ...Severity) Diagnostics {
+	return slices.DeleteFunc(slices.Clone(d), func(diag *Diagnostic) bool { return !slices.Contains(severities, diag.Severity) })
+}
+
+// String returns the messages of the diagnostics, one per line
+func (d Diagnostics) String() string {
+	messages := make([]string, len(d))
+	for i, diag := range d {
+		messages[i] = diag.String()
+	}
+	return strings.Join(messages, "\n")
+}
@@ -0,0 +1,30 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ir
+
+import (
+	"slices"
+)
+
+type (
+	// LayeredCollection is a collection of nACLs and SGs that together filter the traffic of the same VPCs
+	LayeredCollection struct {
+		ACLs *ACLCollection
+		SGs  *SGCollection
+	}
+
+	LayeredWriter interface {
+		WriteLayered(c *LayeredCollection, vpc string, isSynth bool) error
+	}
+)
+
+func (c *LayeredCollection) VpcNames() []string {
+	return slices.Compact(slices.Sorted(slices.Values(slices.Concat(c.ACLs.VpcNames(), c.SGs.VpcNames()))))
+}
+
+func (c *LayeredCollection) Write(w Writer, vpc string, isSynth bool) error {
+	return w.WriteLayered(c, vpc, isSynth)
+}
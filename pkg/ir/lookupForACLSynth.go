@@ -10,7 +10,7 @@ import (
 	"fmt"
 	"sort"
 
-	"github.com/np-guard/models/pkg/netset"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ipset"
 )
 
 func (s *Definitions) LookupForACLSynth(t ResourceType, name string) (*ConnectedResource, error) {
@@ -25,6 +25,8 @@ func (s *Definitions) LookupForACLSynth(t ResourceType, name string) (*Connected
 		return lookupContainerForACLSynth(s.Instances, s, name, ResourceTypeInstance)
 	case ResourceTypeVPE:
 		return lookupContainerForACLSynth(s.VPEs, s, name, ResourceTypeVPE)
+	case ResourceTypeLoadBalancer:
+		return lookupContainerForACLSynth(s.LoadBalancers, s, name, ResourceTypeLoadBalancer)
 	case ResourceTypeSubnetSegment:
 		return s.lookupSegment(s.SubnetSegments, name, t, ResourceTypeSubnet, s.LookupForACLSynth)
 	case ResourceTypeCidrSegment:
@@ -35,6 +37,8 @@ func (s *Definitions) LookupForACLSynth(t ResourceType, name string) (*Connected
 		return s.lookupSegment(s.InstanceSegments, name, t, ResourceTypeInstance, s.LookupForACLSynth)
 	case ResourceTypeVpeSegment:
 		return s.lookupSegment(s.VpeSegments, name, t, ResourceTypeVPE, s.LookupForACLSynth)
+	case ResourceTypeLoadBalancerSegment:
+		return s.lookupSegment(s.LoadBalancerSegments, name, t, ResourceTypeLoadBalancer, s.LookupForACLSynth)
 	}
 	return nil, nil // should not get here
 }
@@ -106,7 +110,7 @@ func (s *Definitions) lookupCidrSegmentACL(name string) (*ConnectedResource, err
 	return res, nil
 }
 
-func (s *Definitions) containedSubnetsInCidr(cidr *netset.IPBlock) []*NamedAddrs {
+func (s *Definitions) containedSubnetsInCidr(cidr *ipset.IPBlock) []*NamedAddrs {
 	res := make([]*NamedAddrs, 0)
 	for subnet, subnetDetails := range s.Subnets {
 		if subnetDetails.CIDR.IsSubset(cidr) {
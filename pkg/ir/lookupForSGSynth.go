@@ -10,7 +10,7 @@ import (
 	"fmt"
 	"slices"
 
-	"github.com/np-guard/models/pkg/netset"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ipset"
 )
 
 func (s *Definitions) LookupForSGSynth(t ResourceType, name string) (*ConnectedResource, error) {
@@ -25,6 +25,8 @@ func (s *Definitions) LookupForSGSynth(t ResourceType, name string) (*ConnectedR
 		return lookupContainerForSGSynth(s.Instances, name, ResourceTypeInstance)
 	case ResourceTypeVPE:
 		return lookupContainerForSGSynth(s.VPEs, name, ResourceTypeVPE)
+	case ResourceTypeLoadBalancer:
+		return lookupContainerForSGSynth(s.LoadBalancers, name, ResourceTypeLoadBalancer)
 	case ResourceTypeSubnetSegment:
 		return s.lookupSegment(s.SubnetSegments, name, t, ResourceTypeSubnet, s.LookupForSGSynth)
 	case ResourceTypeCidrSegment:
@@ -35,6 +37,8 @@ func (s *Definitions) LookupForSGSynth(t ResourceType, name string) (*ConnectedR
 		return s.lookupSegment(s.InstanceSegments, name, t, ResourceTypeInstance, s.LookupForSGSynth)
 	case ResourceTypeVpeSegment:
 		return s.lookupSegment(s.VpeSegments, name, t, ResourceTypeVPE, s.LookupForSGSynth)
+	case ResourceTypeLoadBalancerSegment:
+		return s.lookupSegment(s.LoadBalancerSegments, name, t, ResourceTypeLoadBalancer, s.LookupForSGSynth)
 	}
 	return nil, nil // should not get here
 }
@@ -47,9 +51,15 @@ func (s *Definitions) lookupNIFForSGSynth(name string) (*ConnectedResource, erro
 	if details.ConnectedResource != nil {
 		return details.ConnectedResource, nil
 	}
+	// SGs are attached to all the NIFs of an instance; when the instance has other NIFs, rules for this NIF are
+	// restricted to its addresses by their local field
+	local := &NamedAddrs{Name: details.Instance}
+	if len(s.Instances[details.Instance].Nifs) > 1 {
+		local.IPAddrs = details.Address()
+	}
 	details.ConnectedResource = &ConnectedResource{
 		Name:            name,
-		CidrsWhenLocal:  []*NamedAddrs{{Name: details.Instance}},
+		CidrsWhenLocal:  []*NamedAddrs{local},
 		CidrsWhenRemote: []*NamedAddrs{{Name: details.Instance}},
 		ResourceType:    ResourceTypeInstance,
 	}
@@ -106,10 +116,12 @@ func (s *Definitions) lookupCidrSegmentForSGSynth(name string) (*ConnectedResour
 	return segmentDetails.ConnectedResource, nil
 }
 
-func (s *Definitions) containedResourcesInCidr(cidr *netset.IPBlock) []*NamedAddrs {
+// containedResourcesInCidr returns the instances, VPEs and load balancers with an address in the given CIDR; an instance
+// is in the CIDR if any address of any of its NIFs, primary or secondary, is in it
+func (s *Definitions) containedResourcesInCidr(cidr *ipset.IPBlock) []*NamedAddrs {
 	names := make([]string, 0)
 	for _, nifDetails := range s.NIFs {
-		if nifDetails.IP.IsSubset(cidr) {
+		if nifDetails.Address().Overlap(cidr) {
 			names = append(names, nifDetails.Instance)
 		}
 	}
@@ -118,10 +130,15 @@ func (s *Definitions) containedResourcesInCidr(cidr *netset.IPBlock) []*NamedAdd
 			names = append(names, reservedIPName.VPEName)
 		}
 	}
+	for _, privateIP := range s.LoadBalancerIPs {
+		if privateIP.IP.IsSubset(cidr) {
+			names = append(names, privateIP.LoadBalancer)
+		}
+	}
 	return namesToNamedAddrs(slices.Compact(slices.Sorted(slices.Values(names))))
 }
 
-func cidrToNamedAddrs(cidr *netset.IPBlock) []*NamedAddrs {
+func cidrToNamedAddrs(cidr *ipset.IPBlock) []*NamedAddrs {
 	cidrs := cidr.SplitToCidrs()
 	res := make([]*NamedAddrs, len(cidrs))
 	for i, c := range cidrs {
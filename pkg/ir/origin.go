@@ -0,0 +1,65 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ir
+
+import (
+	"fmt"
+	"slices"
+)
+
+type (
+	// ConnectionOrigin is the element of the spec a connection is translated from
+	ConnectionOrigin interface {
+		fmt.Stringer
+		Kind() string  // required or forbidden
+		Index() int    // the index of the connection in the required-connections or forbidden-connections list
+		Inverse() bool // the connection is the inverse direction of a bidirectional connection
+	}
+
+	// ProtocolOrigin is the element of the spec a tracked protocol is translated from
+	ProtocolOrigin interface {
+		fmt.Stringer
+		Index() int // the index of the protocol in the allowed-protocols list of its connection
+	}
+
+	// RuleOrigin is a connection of the spec, and one of its allowed protocols, that a synthesized rule serves
+	RuleOrigin struct {
+		Kind            string `json:"kind"`
+		ConnectionIndex int    `json:"connection_index"`
+		ProtocolIndex   int    `json:"protocol_index"`
+		Inverse         bool   `json:"inverse,omitempty"`
+		Response        bool   `json:"response,omitempty"` // the rule allows the responses of the connection
+	}
+)
+
+func NewRuleOrigin(connection ConnectionOrigin, protocol ProtocolOrigin, response bool) *RuleOrigin {
+	return &RuleOrigin{Kind: connection.Kind(), ConnectionIndex: connection.Index(), ProtocolIndex: protocol.Index(),
+		Inverse: connection.Inverse(), Response: response}
+}
+
+// MergeOrigins returns the distinct origins of the given lists, in order
+func MergeOrigins(origins ...[]*RuleOrigin) []*RuleOrigin {
+	var res []*RuleOrigin
+	for _, list := range origins {
+		for _, o := range list {
+			if !slices.ContainsFunc(res, func(r *RuleOrigin) bool { return *r == *o }) {
+				res = append(res, o)
+			}
+		}
+	}
+	return res
+}
+
+func (o *RuleOrigin) String() string {
+	res := fmt.Sprintf("%s-connections[%d], allowed-protocols[%d]", o.Kind, o.ConnectionIndex, o.ProtocolIndex)
+	if o.Inverse {
+		res = "inverse of " + res
+	}
+	if o.Response {
+		res = "response to " + res
+	}
+	return res
+}
@@ -9,13 +9,15 @@ import (
 	"fmt"
 
 	"github.com/np-guard/models/pkg/netp"
-	"github.com/np-guard/models/pkg/netset"
+
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ipset"
 )
 
 type Packet struct {
-	Src, Dst    *netset.IPBlock
+	Src, Dst    *ipset.IPBlock
 	Protocol    netp.Protocol
 	Explanation string
+	Origins     []*RuleOrigin
 }
 
 func AllowSend(packet *Packet) *ACLRule {
@@ -26,6 +28,14 @@ func AllowReceive(packet *Packet) *ACLRule {
 	return packetACLRule(packet, Inbound, Allow)
 }
 
+func DenySend(packet *Packet) *ACLRule {
+	return packetACLRule(packet, Outbound, Deny)
+}
+
+func DenyReceive(packet *Packet) *ACLRule {
+	return packetACLRule(packet, Inbound, Deny)
+}
+
 func packetACLRule(packet *Packet, direction Direction, action Action) *ACLRule {
 	return &ACLRule{
 		Action:      action,
@@ -34,37 +44,52 @@ func packetACLRule(packet *Packet, direction Direction, action Action) *ACLRule
 		Direction:   direction,
 		Protocol:    packet.Protocol,
 		Explanation: packet.Explanation,
+		Origins:     packet.Origins,
 	}
 }
 
-// makeDenyInternal prevents allowing external communications from accidentally allowing internal communications too
-func makeDenyInternal() []*ACLRule {
-	localCidrs := []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"} // https://datatracker.ietf.org/doc/html/rfc1918#section-3
-	localCidrsIPBlocks, _ := netset.IPBlockFromCidrList(localCidrs)
-	localCidrsList := localCidrsIPBlocks.SplitToCidrs()
+// PrivateAddresses returns the private address space, as defined in rfc1918, and the IPv6 unique local addresses, as
+// defined in rfc4193
+func PrivateAddresses() *ipset.IPBlock {
+	localCidrs := []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", // https://datatracker.ietf.org/doc/html/rfc1918#section-3
+		"fc00::/7"} // https://datatracker.ietf.org/doc/html/rfc4193#section-3.1
+	localCidrsIPBlocks, _ := ipset.IPBlockFromCidrList(localCidrs)
+	return localCidrsIPBlocks
+}
+
+// makeDenyInternal prevents allowing external communications from accidentally allowing internal communications too.
+// Outbound traffic of a subnet always originates in the subnet, and inbound traffic is always destined to it, so it is
+// enough to deny outbound traffic to the internal address space and inbound traffic from it, by rules of the IP version
+// of each internal CIDR; nil means the private address space
+func makeDenyInternal(internalAddrs *ipset.IPBlock) []*ACLRule {
+	if internalAddrs == nil {
+		internalAddrs = PrivateAddresses()
+	}
 	var denyInternal []*ACLRule
-	for i, localCidrSrc := range localCidrsList {
-		for j, localCidrDst := range localCidrsList {
-			explanation := fmt.Sprintf("Deny other internal communication; see rfc1918#3; item %v,%v", i, j)
-			denyInternal = append(denyInternal,
-				packetACLRule(&Packet{Src: localCidrSrc, Dst: localCidrDst, Protocol: netp.AnyProtocol{}, Explanation: explanation}, Outbound, Deny),
-				packetACLRule(&Packet{Src: localCidrDst, Dst: localCidrSrc, Protocol: netp.AnyProtocol{}, Explanation: explanation}, Inbound, Deny),
-			)
-		}
+	for i, internalCidr := range internalAddrs.SplitToCidrs() {
+		explanation := fmt.Sprintf("Deny other internal communication; internal address space item %v", i)
+		denyInternal = append(denyInternal,
+			DenySend(&Packet{Src: ipset.CidrAllOf(internalCidr), Dst: internalCidr, Protocol: netp.AnyProtocol{}, Explanation: explanation}),
+			DenyReceive(&Packet{Src: internalCidr, Dst: ipset.CidrAllOf(internalCidr), Protocol: netp.AnyProtocol{}, Explanation: explanation}),
+		)
 	}
 	return denyInternal
 }
 
-func DenyAllSend(subnetName ID, cidr *netset.IPBlock) *ACLRule {
+// DenyAllSend denies all the outbound traffic of a subnet CIDR, which must be of a single IP version
+func DenyAllSend(subnetName ID, cidr *ipset.IPBlock) *ACLRule {
 	explanation := DenyAllExplanation(subnetName, cidr)
-	return packetACLRule(&Packet{Src: cidr, Dst: netset.GetCidrAll(), Protocol: netp.AnyProtocol{}, Explanation: explanation}, Outbound, Deny)
+	all := ipset.CidrAllOf(cidr)
+	return packetACLRule(&Packet{Src: cidr, Dst: all, Protocol: netp.AnyProtocol{}, Explanation: explanation}, Outbound, Deny)
 }
 
-func DenyAllReceive(subnetName ID, cidr *netset.IPBlock) *ACLRule {
+// DenyAllReceive denies all the inbound traffic of a subnet CIDR, which must be of a single IP version
+func DenyAllReceive(subnetName ID, cidr *ipset.IPBlock) *ACLRule {
 	explanation := DenyAllExplanation(subnetName, cidr)
-	return packetACLRule(&Packet{Src: netset.GetCidrAll(), Dst: cidr, Protocol: netp.AnyProtocol{}, Explanation: explanation}, Inbound, Deny)
+	all := ipset.CidrAllOf(cidr)
+	return packetACLRule(&Packet{Src: all, Dst: cidr, Protocol: netp.AnyProtocol{}, Explanation: explanation}, Inbound, Deny)
 }
 
-func DenyAllExplanation(subnetName ID, cidr *netset.IPBlock) string {
+func DenyAllExplanation(subnetName ID, cidr *ipset.IPBlock) string {
 	return fmt.Sprintf("Deny all communication; subnet %s[%s] does not have required connections", subnetName, cidr.String())
 }
@@ -0,0 +1,97 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ir
+
+import (
+	"fmt"
+
+	"github.com/np-guard/models/pkg/interval"
+	"github.com/np-guard/models/pkg/netp"
+)
+
+type (
+	// ResponsePolicy sets which responses of a TCP or UDP connection stateless nACLs allow
+	ResponsePolicy string
+
+	// ResponsePolicies are the response policies of TCP and UDP connections; the zero value gives the defaults
+	ResponsePolicies struct {
+		TCP ResponsePolicy // default: ResponseExact
+		UDP ResponsePolicy // default: ResponseNone
+	}
+)
+
+const (
+	// ResponseExact allows the inverse of the request, namely from its destination ports to any of its source ports
+	ResponseExact ResponsePolicy = "exact"
+
+	// ResponseEphemeral allows the inverse of the request, restricted to the ephemeral ports 1024-65535 of the client
+	ResponseEphemeral ResponsePolicy = "ephemeral"
+
+	// ResponseNone allows no responses, as for one-way flows (UDP only)
+	ResponseNone ResponsePolicy = "none"
+)
+
+const (
+	minEphemeralPort = 1024
+	maxEphemeralPort = netp.MaxPort
+)
+
+var (
+	TCPResponsePolicies = []string{string(ResponseExact), string(ResponseEphemeral)}
+	UDPResponsePolicies = []string{string(ResponseExact), string(ResponseEphemeral), string(ResponseNone)}
+)
+
+// Policy returns the response policy of the given protocol; ICMP and any-protocol connections have exact responses
+func (r *ResponsePolicies) Policy(p netp.Protocol) ResponsePolicy {
+	t, ok := p.(netp.TCPUDP)
+	switch {
+	case !ok:
+		return ResponseExact
+	case t.ProtocolString() == netp.ProtocolStringTCP && r != nil && r.TCP != "":
+		return r.TCP
+	case t.ProtocolString() == netp.ProtocolStringUDP && r != nil && r.UDP != "":
+		return r.UDP
+	case t.ProtocolString() == netp.ProtocolStringUDP:
+		return ResponseNone
+	}
+	return ResponseExact
+}
+
+// Response returns the protocol of the responses of a connection with the given protocol, by its response policy,
+// or nil if responses are not allowed
+func (r *ResponsePolicies) Response(p netp.Protocol) netp.Protocol {
+	t, ok := p.(netp.TCPUDP)
+	if !ok {
+		return p.InverseDirection()
+	}
+	dstPorts := t.SrcPorts()
+	switch r.Policy(p) {
+	case ResponseNone:
+		return nil
+	case ResponseEphemeral:
+		dstPorts = dstPorts.Intersect(interval.New(minEphemeralPort, maxEphemeralPort))
+		if dstPorts.IsEmpty() {
+			return nil
+		}
+	}
+	res, err := netp.NewTCPUDP(t.ProtocolString() == netp.ProtocolStringTCP, int(t.DstPorts().Start()), int(t.DstPorts().End()),
+		int(dstPorts.Start()), int(dstPorts.End()))
+	if err != nil {
+		return nil
+	}
+	return res
+}
+
+// Description returns the reason for the responses allowed by the policy, for the explanations of response rules
+func (p ResponsePolicy) Description() string {
+	switch p {
+	case ResponseEphemeral:
+		return fmt.Sprintf("ephemeral ports %d-%d", minEphemeralPort, maxEphemeralPort)
+	case ResponseNone:
+		return "no responses"
+	}
+	return "exact inverse"
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ir
+
+import (
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ipset"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/utils"
+)
+
+type (
+	// TransitGatewayDetails describes the VPCs connected by a transit gateway
+	TransitGatewayDetails struct {
+		// the address prefixes each connected VPC advertises through the transit gateway, after applying the prefix filters
+		// of its connection
+		VPCPrefixes map[ID]*ipset.IPBlock
+	}
+
+	// PrefixFilter is a prefix filter of a transit gateway connection. It matches the address prefixes that are contained
+	// in Prefix, whose length is between Ge and Le; zero Ge and Le match only Prefix itself
+	PrefixFilter struct {
+		Action Action
+		Prefix *ipset.IPBlock
+		Ge     int64
+		Le     int64
+	}
+)
+
+// AdvertisedPrefixes returns the address prefixes that are advertised through a transit gateway connection. Each prefix
+// is permitted or denied by the first filter that matches it, or by defaultAction if no filter matches
+func AdvertisedPrefixes(addressPrefixes []*ipset.IPBlock, filters []*PrefixFilter, defaultAction Action) *ipset.IPBlock {
+	res := ipset.NewIPBlock()
+	for _, prefix := range addressPrefixes {
+		action := defaultAction
+		for _, filter := range filters {
+			if filter.matches(prefix) {
+				action = filter.Action
+				break
+			}
+		}
+		if action == Allow {
+			res = res.Union(prefix)
+		}
+	}
+	return res
+}
+
+// PrefixFilterAction translates the action of a prefix filter, or the default action of a transit gateway connection;
+// the default is permit
+func PrefixFilterAction(action string) Action {
+	if action == string(Deny) {
+		return Deny
+	}
+	return Allow
+}
+
+func (f *PrefixFilter) matches(prefix *ipset.IPBlock) bool {
+	if !prefix.IsSubset(f.Prefix) {
+		return false
+	}
+	length, err1 := prefix.PrefixLength()
+	filterLength, err2 := f.Prefix.PrefixLength()
+	if err1 != nil || err2 != nil {
+		return false
+	}
+	minLength, maxLength := filterLength, filterLength
+	if f.Ge != 0 {
+		minLength, maxLength = f.Ge, prefix.MaxPrefixLength()
+	}
+	if f.Le != 0 {
+		maxLength = f.Le
+	}
+	return minLength <= length && length <= maxLength
+}
+
+// TGWPrefixes returns the address prefixes of other VPCs that are advertised to the given VPC through the transit
+// gateways it is connected to
+func (c *ConfigDefs) TGWPrefixes(vpc ID) *ipset.IPBlock {
+	res := ipset.NewIPBlock()
+	for _, tgwName := range utils.SortedMapKeys(c.TransitGateways) {
+		tgw := c.TransitGateways[tgwName]
+		if _, ok := tgw.VPCPrefixes[vpc]; !ok {
+			continue
+		}
+		for otherVPC, prefixes := range tgw.VPCPrefixes {
+			if otherVPC != vpc {
+				res = res.Union(prefixes)
+			}
+		}
+	}
+	return res
+}
+
+// InternalAddrs returns the internal address space of a VPC, which the rules of connections with external resources must
+// not reach: the address prefixes of the VPC, the IPv6 CIDRs of its subnets (which are not allocated from address
+// prefixes) and the prefixes advertised to it through transit gateways, unless overridden
+func (c *ConfigDefs) InternalAddrs(vpc ID) *ipset.IPBlock {
+	if c.InternalAddrsOverride != nil {
+		return c.InternalAddrsOverride
+	}
+	res := c.TGWPrefixes(vpc)
+	if details, ok := c.VPCs[vpc]; ok {
+		res = res.Union(details.AddressPrefixes)
+	}
+	for subnetName, subnet := range c.Subnets {
+		if VpcFromScopedResource(subnetName) == vpc {
+			res = res.Union(subnet.CIDR.Intersect(ipset.GetIPv6CidrAll()))
+		}
+	}
+	return res
+}
+
+// Routable checks whether traffic between the src addresses in srcVPC and the dst addresses in dstVPC can be routed,
+// namely whether the two VPCs are the same VPC, or are connected by a transit gateway through which both src and dst
+// are advertised
+func (c *ConfigDefs) Routable(srcVPC ID, src *ipset.IPBlock, dstVPC ID, dst *ipset.IPBlock) bool {
+	if srcVPC == dstVPC {
+		return true
+	}
+	for _, tgw := range c.TransitGateways {
+		srcPrefixes, ok1 := tgw.VPCPrefixes[srcVPC]
+		dstPrefixes, ok2 := tgw.VPCPrefixes[dstVPC]
+		if ok1 && ok2 && src.IsSubset(srcPrefixes) && dst.IsSubset(dstPrefixes) {
+			return true
+		}
+	}
+	return false
+}
+
+// LocalAddrs returns the addresses of an endpoint of a connected resource. Endpoints of SG synthesis are named
+// instances, VPEs or load balancers, whose addresses are the addresses of their NIFs, reserved IPs or private IPs
+func (s *Definitions) LocalAddrs(endpoint *NamedAddrs) *ipset.IPBlock {
+	if endpoint.IPAddrs != nil {
+		return endpoint.IPAddrs
+	}
+	res := ipset.NewIPBlock()
+	if instance, ok := s.Instances[endpoint.Name]; ok {
+		for _, nif := range instance.Nifs {
+			res = res.Union(s.NIFs[nif].Address())
+		}
+	}
+	if vpe, ok := s.VPEs[endpoint.Name]; ok {
+		for _, reservedIP := range vpe.VPEReservedIPs {
+			res = res.Union(s.VPEReservedIPs[reservedIP].IP)
+		}
+	}
+	if lb, ok := s.LoadBalancers[endpoint.Name]; ok {
+		for _, privateIP := range lb.PrivateIPs {
+			res = res.Union(s.LoadBalancerIPs[privateIP].IP)
+		}
+	}
+	return res
+}
+
+// PublicEgress checks whether an endpoint (a subnet, instance, VPE or load balancer) may initiate connections to the
+// public internet, namely whether it has a NIF with a floating IP, or is in a subnet with a public gateway (subnets are
+// checked with any of their NIFs). VPEs have no path to the public internet, and load balancers only if they are public
+func (s *Definitions) PublicEgress(endpoint ID) bool {
+	if lb, ok := s.LoadBalancers[endpoint]; ok {
+		return lb.Public
+	}
+	if subnet, ok := s.Subnets[endpoint]; ok && subnet.PublicGateway != "" {
+		return true
+	}
+	for _, nif := range s.endpointNIFs(endpoint) {
+		if nif.FloatingIP != nil || s.Subnets[nif.Subnet].PublicGateway != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// PublicIngress checks whether an endpoint (a subnet, instance, VPE or load balancer) may accept connections from the
+// public internet, namely whether it has a NIF with a floating IP (subnets are checked with any of their NIFs), or is a
+// public load balancer
+func (s *Definitions) PublicIngress(endpoint ID) bool {
+	if lb, ok := s.LoadBalancers[endpoint]; ok {
+		return lb.Public
+	}
+	for _, nif := range s.endpointNIFs(endpoint) {
+		if nif.FloatingIP != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// endpointNIFs returns the NIFs of an instance, or the NIFs in a subnet
+func (s *Definitions) endpointNIFs(endpoint ID) []*NifDetails {
+	var res []*NifDetails
+	if instance, ok := s.Instances[endpoint]; ok {
+		for _, nif := range instance.Nifs {
+			res = append(res, s.NIFs[nif])
+		}
+		return res
+	}
+	if _, ok := s.Subnets[endpoint]; ok {
+		for _, nifName := range utils.SortedMapKeys(s.NIFs) {
+			if s.NIFs[nifName].Subnet == endpoint {
+				res = append(res, s.NIFs[nifName])
+			}
+		}
+	}
+	return res
+}
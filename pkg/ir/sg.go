@@ -11,8 +11,8 @@ import (
 	"slices"
 
 	"github.com/np-guard/models/pkg/netp"
-	"github.com/np-guard/models/pkg/netset"
 
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ipset"
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/utils"
 )
 
@@ -21,15 +21,18 @@ type (
 
 	RemoteType interface {
 		fmt.Stringer
-		// *netset.IPBlock | SGName
+		// *ipset.IPBlock | SGName
 	}
 
 	SGRule struct {
 		Direction   Direction
 		Remote      RemoteType
 		Protocol    netp.Protocol
-		Local       *netset.IPBlock
+		Local       *ipset.IPBlock
 		Explanation string
+		Origins     []*RuleOrigin // the spec connections a synthesized rule serves
+		ID          string        // the id of a rule read from a config object
+		Address     string        // the address of a rule read from a terraform state
 	}
 
 	SG struct {
@@ -37,6 +40,8 @@ type (
 		InboundRules  map[string][]*SGRule // the key is the locals value
 		OutboundRules map[string][]*SGRule // the key is the locals value
 		Targets       []ID
+		ID            string // the id of an SG read from a config object
+		Address       string // the address of an SG read from a terraform state
 	}
 
 	SGCollection struct {
@@ -52,9 +57,15 @@ func (s SGName) String() string {
 	return string(s)
 }
 
+// isRedundant returns true if one of the rules supersedes the rule, and adds the origins (and the address, if it has
+// none) of the rule to it
 func (r *SGRule) isRedundant(rules []*SGRule) bool {
 	for _, rule := range rules {
 		if rule.mustSupersede(r) {
+			rule.Origins = MergeOrigins(rule.Origins, r.Origins)
+			if rule.Address == "" {
+				rule.Address = r.Address
+			}
 			return true
 		}
 	}
@@ -62,14 +73,18 @@ func (r *SGRule) isRedundant(rules []*SGRule) bool {
 }
 
 func (r *SGRule) mustSupersede(other *SGRule) bool {
-	otherExplanation := other.Explanation
-	other.Explanation = r.Explanation
-	res := reflect.DeepEqual(r, other)
-	other.Explanation = otherExplanation
-	return res
+	return r.Equal(other)
+}
+
+// Equal returns true if the rules are the same, regardless of their explanations, origins, ids and addresses
+func (r *SGRule) Equal(other *SGRule) bool {
+	a, b := *r, *other
+	a.Explanation, a.Origins, a.ID, a.Address = "", nil, "", ""
+	b.Explanation, b.Origins, b.ID, b.Address = "", nil, "", ""
+	return reflect.DeepEqual(a, b)
 }
 
-func NewSGRule(direction Direction, remote RemoteType, p netp.Protocol, local *netset.IPBlock, e string) *SGRule {
+func NewSGRule(direction Direction, remote RemoteType, p netp.Protocol, local *ipset.IPBlock, e string) *SGRule {
 	return &SGRule{Direction: direction, Remote: remote, Protocol: p,
 		Local: local, Explanation: e}
 }
@@ -120,6 +135,38 @@ func (a *SG) AllRules() []*SGRule {
 	return res
 }
 
+// AttachedSGs returns the SGs of the given VPC that are attached to the given target (a NIF or a VPE, by unscoped name)
+func (c *SGCollection) AttachedSGs(vpc ID, target string) []*SG {
+	res := make([]*SG, 0)
+	for _, sgName := range utils.SortedMapKeys(c.SGs[vpc]) {
+		if sg := c.SGs[vpc][sgName]; slices.Contains(sg.Targets, target) {
+			res = append(res, sg)
+		}
+	}
+	return res
+}
+
+// RemoteIPs returns the IP addresses of an SG rule remote in the given VPC; an SG remote stands for the IPs of its targets.
+// a remote SG is looked up in the given VPC first (synthesized SGs may refer to SGs of other VPCs)
+func (c *SGCollection) RemoteIPs(remote RemoteType, vpc ID, targetIPs map[ID]map[string]*ipset.IPBlock) *ipset.IPBlock {
+	if ipb, ok := remote.(*ipset.IPBlock); ok {
+		return ipb
+	}
+	sgName := remote.(SGName)
+	for _, sgVPC := range slices.Concat([]ID{vpc}, utils.SortedMapKeys(c.SGs)) {
+		if sg, ok := c.SGs[sgVPC][sgName]; ok {
+			res := ipset.NewIPBlock()
+			for _, target := range sg.Targets {
+				if ips, ok := targetIPs[sgVPC][target]; ok {
+					res = res.Union(ips)
+				}
+			}
+			return res
+		}
+	}
+	return ipset.NewIPBlock()
+}
+
 func (c *SGCollection) VpcNames() []string {
 	return utils.SortedMapKeys(c.SGs)
 }
@@ -7,12 +7,15 @@ SPDX-License-Identifier: Apache-2.0
 package ir
 
 import (
+	"errors"
 	"fmt"
 	"slices"
 	"strings"
 
 	"github.com/np-guard/models/pkg/netp"
-	"github.com/np-guard/models/pkg/netset"
+
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ipset"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/utils"
 )
 
 type (
@@ -23,10 +26,16 @@ type (
 		// Required connections
 		Connections []*Connection
 
+		// Forbidden connections; these take precedence over required connections (and their responses)
+		Forbidden []*Connection
+
 		Defs *Definitions
 
 		// resources that does not appear in the Spec file
 		*BlockedResources
+
+		// Diagnostics raised while reading the spec
+		Diagnostics Diagnostics
 	}
 
 	Connection struct {
@@ -40,7 +49,7 @@ type (
 		TrackedProtocols []*TrackedProtocol
 
 		// Provenance information
-		Origin fmt.Stringer
+		Origin ConnectionOrigin
 	}
 
 	ConnectedResource struct {
@@ -59,13 +68,13 @@ type (
 	}
 
 	NamedAddrs struct {
-		IPAddrs *netset.IPBlock
+		IPAddrs *ipset.IPBlock
 		Name    string
 	}
 
 	TrackedProtocol struct {
 		netp.Protocol
-		Origin fmt.Stringer
+		Origin ProtocolOrigin
 	}
 
 	// ConfigDefs holds definitions that are part of the network architecture
@@ -81,6 +90,15 @@ type (
 		VPEReservedIPs map[ID]*VPEReservedIPsDetails
 
 		VPEs map[ID]*VPEDetails
+
+		LoadBalancerIPs map[ID]*LoadBalancerIPDetails
+
+		LoadBalancers map[ID]*LoadBalancerDetails
+
+		TransitGateways map[ID]*TransitGatewayDetails
+
+		// InternalAddrsOverride replaces the internal address space of all VPCs, if set
+		InternalAddrsOverride *ipset.IPBlock
 	}
 
 	// Definitions adds to ConfigDefs the spec-specific definitions
@@ -98,30 +116,38 @@ type (
 
 		VpeSegments map[ID]*SegmentDetails
 
+		LoadBalancerSegments map[ID]*SegmentDetails
+
 		// Externals are a way for users to name IP addresses or ranges external to the VPC.
 		Externals map[ID]*ExternalDetails
 	}
 
 	BlockedResources struct {
-		BlockedSubnets   map[ID]bool
-		BlockedInstances map[ID]bool
-		BlockedVPEs      map[ID]bool
+		BlockedSubnets       map[ID]bool
+		BlockedInstances     map[ID]bool
+		BlockedVPEs          map[ID]bool
+		BlockedLoadBalancers map[ID]bool
 	}
 
 	VPCDetails struct {
-		AddressPrefixes *netset.IPBlock
+		AddressPrefixes *ipset.IPBlock
 	}
 
 	// ConnectedResource is for caching lookup results
 	SubnetDetails struct {
-		CIDR              *netset.IPBlock
+		CIDR              *ipset.IPBlock
+		NetworkACL        ID // the name of the attached nACL, if known
+		PublicGateway     ID // the name of the attached public gateway, if any
 		ConnectedResource *ConnectedResource
 	}
 
 	NifDetails struct {
-		IP                *netset.IPBlock
+		IP                *ipset.IPBlock // the primary address of the NIF
+		SecondaryIPs      *ipset.IPBlock // the other addresses bound to the NIF, if any
+		FloatingIP        *ipset.IPBlock // the address of the floating IP bound to the NIF, if any
 		Instance          ID
 		Subnet            ID
+		Virtual           bool // the NIF is the virtual network interface of a network attachment, not a network interface
 		ConnectedResource *ConnectedResource
 	}
 
@@ -131,7 +157,7 @@ type (
 	}
 
 	VPEReservedIPsDetails struct {
-		IP      *netset.IPBlock
+		IP      *ipset.IPBlock
 		VPEName ID
 		Subnet  ID
 	}
@@ -141,18 +167,31 @@ type (
 		ConnectedResource *ConnectedResource
 	}
 
+	// LoadBalancerIPDetails describes a private IP of a load balancer, in one of its subnets
+	LoadBalancerIPDetails struct {
+		IP           *ipset.IPBlock
+		LoadBalancer ID
+		Subnet       ID
+	}
+
+	LoadBalancerDetails struct {
+		PrivateIPs        []ID
+		Public            bool // whether the load balancer is reachable from the public internet
+		ConnectedResource *ConnectedResource
+	}
+
 	SegmentDetails struct {
 		Elements          []ID
 		ConnectedResource *ConnectedResource
 	}
 
 	CidrSegmentDetails struct {
-		Cidrs             *netset.IPBlock
+		Cidrs             *ipset.IPBlock
 		ConnectedResource *ConnectedResource
 	}
 
 	ExternalDetails struct {
-		ExternalAddrs     *netset.IPBlock
+		ExternalAddrs     *ipset.IPBlock
 		ConnectedResource *ConnectedResource
 	}
 
@@ -162,14 +201,14 @@ type (
 
 	// generalizes subnet and external resource types
 	NWResource interface {
-		Address() *netset.IPBlock
+		Address() *ipset.IPBlock
 		getConnectedResource() *ConnectedResource
 		setConnectedResource(r *ConnectedResource)
 	}
 
 	// resources that are in a subnet. used for lookupContainerForACLSynth generic function
 	SubSubnetResource interface {
-		Address() *netset.IPBlock
+		Address() *ipset.IPBlock
 		SubnetName() ID
 	}
 
@@ -182,23 +221,25 @@ type (
 )
 
 const (
-	ResourceTypeExternal        ResourceType = "external"
-	ResourceTypeCidr            ResourceType = "cidr"
-	ResourceTypeSubnet          ResourceType = "subnet"
-	ResourceTypeNIF             ResourceType = "nif"
-	ResourceTypeVPE             ResourceType = "vpe"
-	ResourceTypeInstance        ResourceType = "instance"
-	ResourceTypeSubnetSegment   ResourceType = "subnetSegment"
-	ResourceTypeCidrSegment     ResourceType = "cidrSegment"
-	ResourceTypeNifSegment      ResourceType = "nifSegment"
-	ResourceTypeInstanceSegment ResourceType = "instanceSegment"
-	ResourceTypeVpeSegment      ResourceType = "vpeSegment"
+	ResourceTypeExternal            ResourceType = "external"
+	ResourceTypeCidr                ResourceType = "cidr"
+	ResourceTypeSubnet              ResourceType = "subnet"
+	ResourceTypeNIF                 ResourceType = "nif"
+	ResourceTypeVPE                 ResourceType = "vpe"
+	ResourceTypeInstance            ResourceType = "instance"
+	ResourceTypeLoadBalancer        ResourceType = "load_balancer"
+	ResourceTypeSubnetSegment       ResourceType = "subnetSegment"
+	ResourceTypeCidrSegment         ResourceType = "cidrSegment"
+	ResourceTypeNifSegment          ResourceType = "nifSegment"
+	ResourceTypeInstanceSegment     ResourceType = "instanceSegment"
+	ResourceTypeVpeSegment          ResourceType = "vpeSegment"
+	ResourceTypeLoadBalancerSegment ResourceType = "loadBalancerSegment"
 
 	resourceNotFound  = "%v %v not found"
 	containerNotFound = "container %v %v not found"
 )
 
-func (s *SubnetDetails) Address() *netset.IPBlock {
+func (s *SubnetDetails) Address() *ipset.IPBlock {
 	return s.CIDR
 }
 
@@ -210,15 +251,19 @@ func (s *SubnetDetails) setConnectedResource(r *ConnectedResource) {
 	s.ConnectedResource = r
 }
 
-func (n *NifDetails) Address() *netset.IPBlock {
-	return n.IP
+// Address returns all the addresses of the NIF: its primary address and its secondary addresses
+func (n *NifDetails) Address() *ipset.IPBlock {
+	if n.SecondaryIPs == nil {
+		return n.IP
+	}
+	return n.IP.Union(n.SecondaryIPs)
 }
 
 func (n *NifDetails) SubnetName() ID {
 	return n.Subnet
 }
 
-func (v *VPEReservedIPsDetails) Address() *netset.IPBlock {
+func (v *VPEReservedIPsDetails) Address() *ipset.IPBlock {
 	return v.IP
 }
 
@@ -226,7 +271,15 @@ func (v *VPEReservedIPsDetails) SubnetName() ID {
 	return v.Subnet
 }
 
-func (e *ExternalDetails) Address() *netset.IPBlock {
+func (l *LoadBalancerIPDetails) Address() *ipset.IPBlock {
+	return l.IP
+}
+
+func (l *LoadBalancerIPDetails) SubnetName() ID {
+	return l.Subnet
+}
+
+func (e *ExternalDetails) Address() *ipset.IPBlock {
 	return e.ExternalAddrs
 }
 
@@ -278,6 +331,26 @@ func (v *VPEDetails) setConnectedResource(r *ConnectedResource) {
 	v.ConnectedResource = r
 }
 
+func (l *LoadBalancerDetails) endpointNames() []ID {
+	return l.PrivateIPs
+}
+
+func (l *LoadBalancerDetails) endpointMap(s *Definitions) map[ID]SubSubnetResource {
+	res := make(map[ID]SubSubnetResource, len(l.PrivateIPs))
+	for _, ipName := range l.PrivateIPs {
+		res[ipName] = s.LoadBalancerIPs[ipName]
+	}
+	return res
+}
+
+func (l *LoadBalancerDetails) getConnectedResource() *ConnectedResource {
+	return l.ConnectedResource
+}
+
+func (l *LoadBalancerDetails) setConnectedResource(r *ConnectedResource) {
+	l.ConnectedResource = r
+}
+
 // lookupSingle is called only when the resource type is ResourceTypeSubnet or ResourceTypeExternal
 func lookupSingle[T NWResource](m map[ID]T, name string, t ResourceType) (*ConnectedResource, error) {
 	details, ok := m[name]
@@ -320,6 +393,52 @@ func (s *Definitions) lookupSegment(segment map[ID]*SegmentDetails, name string,
 	return res, nil
 }
 
+// SGTargetIPs returns the IP addresses of the resources SGs can be attached to, per VPC and per the name used in SG targets:
+// the unscoped name of a NIF, or the unscoped name of a VPE or a load balancer (standing for all its reserved/private IPs)
+func (c *ConfigDefs) SGTargetIPs() map[ID]map[string]*ipset.IPBlock {
+	res := map[ID]map[string]*ipset.IPBlock{}
+	add := func(scopedName ID, ip *ipset.IPBlock) {
+		vpc, target := VpcFromScopedResource(scopedName), UnscopedName(scopedName)
+		if res[vpc] == nil {
+			res[vpc] = map[string]*ipset.IPBlock{}
+		}
+		if res[vpc][target] == nil {
+			res[vpc][target] = ipset.NewIPBlock()
+		}
+		res[vpc][target] = res[vpc][target].Union(ip)
+	}
+	for _, nif := range utils.SortedMapKeys(c.NIFs) {
+		add(nif, c.NIFs[nif].Address())
+	}
+	for _, reservedIP := range utils.SortedMapKeys(c.VPEReservedIPs) {
+		add(c.VPEReservedIPs[reservedIP].VPEName, c.VPEReservedIPs[reservedIP].IP)
+	}
+	for _, privateIP := range utils.SortedMapKeys(c.LoadBalancerIPs) {
+		add(c.LoadBalancerIPs[privateIP].LoadBalancer, c.LoadBalancerIPs[privateIP].IP)
+	}
+	return res
+}
+
+// SubnetCIDR returns the addresses of a subnet, which has an IPv4 CIDR block, an IPv6 CIDR block or both; a missing
+// block is given as an empty string
+func SubnetCIDR(ipv4CIDRBlock, ipv6CIDRBlock string) (*ipset.IPBlock, error) {
+	res := ipset.NewIPBlock()
+	for _, block := range []string{ipv4CIDRBlock, ipv6CIDRBlock} {
+		if block == "" {
+			continue
+		}
+		cidr, err := ipset.IPBlockFromCidr(block)
+		if err != nil {
+			return nil, err
+		}
+		res = res.Union(cidr)
+	}
+	if res.IsEmpty() {
+		return nil, errors.New("missing CIDR block")
+	}
+	return res, nil
+}
+
 func ScopingComponents(s string) []string {
 	return strings.Split(s, "/")
 }
@@ -328,6 +447,11 @@ func VpcFromScopedResource(resource ID) ID {
 	return ScopingComponents(resource)[0]
 }
 
+func UnscopedName(name ID) string {
+	components := ScopingComponents(name)
+	return components[len(components)-1]
+}
+
 func ChangeScoping(s string) string {
 	return strings.ReplaceAll(s, "/", "--")
 }
@@ -7,10 +7,10 @@ package acloptimizer
 
 import (
 	"fmt"
-	"log"
 
 	"github.com/np-guard/models/pkg/ds"
 	"github.com/np-guard/models/pkg/netset"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ipset"
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/optimize"
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/utils"
@@ -23,8 +23,8 @@ type (
 		aclVPC        string
 	}
 
-	protocolTripleSet = ds.TripleSet[*netset.IPBlock, *netset.IPBlock, *netset.TransportSet]
-	srcDstProduct     = ds.Product[*netset.IPBlock, *netset.IPBlock]
+	protocolTripleSet = ds.TripleSet[*ipset.IPBlock, *ipset.IPBlock, *netset.TransportSet]
+	srcDstProduct     = ds.Product[*ipset.IPBlock, *ipset.IPBlock]
 
 	aclCubesPerProtocol struct {
 		tcpAllow protocolTripleSet
@@ -50,29 +50,32 @@ func NewACLOptimizer(collection ir.Collection, aclName string) optimize.Optimize
 	return &aclOptimizer{aclCollection: collection.(*ir.ACLCollection), aclName: components[1], aclVPC: components[0]}
 }
 
-func (a *aclOptimizer) Optimize() (ir.Collection, error) {
+// Optimize attempts to reduce the number of nACL rules; the rules of synthesized nACLs are first moved to their
+// inbound and outbound rules
+func (a *aclOptimizer) Optimize() (ir.Collection, ir.Diagnostics, error) {
+	a.aclCollection.SetDirectionalRules()
 	if a.aclName != "" {
 		for _, vpcName := range utils.SortedMapKeys(a.aclCollection.ACLs) {
 			if a.aclVPC != "" && a.aclVPC != vpcName {
 				continue
 			}
 			if _, ok := a.aclCollection.ACLs[vpcName][a.aclName]; ok {
-				a.optimizeACL(vpcName, a.aclName)
-				return a.aclCollection, nil
+				return a.aclCollection, ir.Diagnostics{a.optimizeACL(vpcName, a.aclName)}, nil
 			}
 		}
-		return nil, fmt.Errorf("could not find nACL %s", a.aclName)
+		return nil, nil, fmt.Errorf("could not find nACL %s", a.aclName)
 	}
 
+	var diagnostics ir.Diagnostics
 	for _, vpcName := range utils.SortedMapKeys(a.aclCollection.ACLs) {
 		for _, aclName := range utils.SortedMapKeys(a.aclCollection.ACLs[vpcName]) {
-			a.optimizeACL(vpcName, aclName)
+			diagnostics = append(diagnostics, a.optimizeACL(vpcName, aclName))
 		}
 	}
-	return a.aclCollection, nil
+	return a.aclCollection, diagnostics, nil
 }
 
-func (a *aclOptimizer) optimizeACL(vpcName, aclName string) {
+func (a *aclOptimizer) optimizeACL(vpcName, aclName string) *ir.Diagnostic {
 	acl := a.aclCollection.ACLs[vpcName][aclName]
 	reducedRules := 0
 
@@ -90,18 +93,32 @@ func (a *aclOptimizer) optimizeACL(vpcName, aclName string) {
 		acl.Outbound = newOutboundRules
 	}
 
-	// print a message to the log
-	if reducedRules == 0 {
-		log.Printf("no rules were reduced in acl %s\n", a.aclName)
-	} else {
-		log.Printf("the number of rules in acl %s was reduced by %d\n", a.aclName, reducedRules)
-	}
+	return optimize.ReducedRulesNotice("acl", aclName, reducedRules)
 }
 
 func (a *aclOptimizer) reduceACLRules(rules []*ir.ACLRule, direction ir.Direction) []*ir.ACLRule {
 	optimizedRules := aclCubesToRules(aclRulesToCubes(rules), direction)
 	if len(rules) > len(optimizedRules) {
+		explainACLRules(optimizedRules, rules)
 		return optimizedRules
 	}
 	return rules
 }
+
+// explainACLRules sets the explanation and the origins of each new rule to those of the original rules with the same
+// action it overlaps, so optimizing synthesized nACLs keeps track of the connections behind each rule
+func explainACLRules(optimized, original []*ir.ACLRule) {
+	for _, rule := range optimized {
+		explanations := []string{}
+		var origins []*ir.RuleOrigin
+		for _, o := range original {
+			if rule.Action == o.Action && rule.Source.Overlap(o.Source) && rule.Destination.Overlap(o.Destination) &&
+				optimize.TransportsOverlap(rule.Protocol, o.Protocol) {
+				explanations = append(explanations, o.Explanation)
+				origins = ir.MergeOrigins(origins, o.Origins)
+			}
+		}
+		rule.Explanation = optimize.JoinExplanations(explanations)
+		rule.Origins = origins
+	}
+}
@@ -0,0 +1,35 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package acloptimizer
+
+import (
+	"github.com/np-guard/models/pkg/netp"
+
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ipset"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/optimize"
+)
+
+// AllowedConnections returns the connections allowed by the given rules, following the nACL first-match semantics.
+// All rules are assumed to have the same direction.
+func AllowedConnections(rules []*ir.ACLRule) *ipset.EndpointsTrafficSet {
+	explicitRules := make([]*ir.ACLRule, len(rules))
+	for i, rule := range rules {
+		explicitRules[i] = rule
+		if icmp, ok := rule.Protocol.(netp.ICMP); ok {
+			explicitRules[i] = ir.NewACLRule(rule.Action, rule.Direction, rule.Source, rule.Destination,
+				optimize.ExplicitICMPCode(icmp), rule.Explanation)
+		}
+	}
+	cubes := aclRulesToCubes(explicitRules)
+	res := ipset.EmptyEndpointsTrafficSet()
+	for _, allowCubes := range []protocolTripleSet{cubes.tcpAllow, cubes.udpAllow, cubes.icmpAllow} {
+		for _, cube := range allowCubes.Partitions() {
+			res = res.Union(ipset.NewEndpointsTrafficSet(cube.S1, cube.S2, cube.S3))
+		}
+	}
+	return res
+}
@@ -11,21 +11,22 @@ import (
 	"github.com/np-guard/models/pkg/ds"
 	"github.com/np-guard/models/pkg/netp"
 	"github.com/np-guard/models/pkg/netset"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ipset"
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/optimize"
 )
 
 type (
-	dstProtocolProduct = ds.Product[*netset.IPBlock, *netset.TransportSet]
-	activeRule         = ds.Pair[*netset.IPBlock, dstProtocolProduct]
+	dstProtocolProduct = ds.Product[*ipset.IPBlock, *netset.TransportSet]
+	activeRule         = ds.Pair[*ipset.IPBlock, dstProtocolProduct]
 )
 
 func aclCubesToRules(cubes *aclCubesPerProtocol, direction ir.Direction) []*ir.ACLRule {
 	// we will calculate the optimized deny cubes in `reduceACLCubes` func
-	cubes.tcpDeny = ds.NewLeftTripleSet[*netset.IPBlock, *netset.IPBlock, *netset.TransportSet]()
-	cubes.udpDeny = ds.NewLeftTripleSet[*netset.IPBlock, *netset.IPBlock, *netset.TransportSet]()
-	cubes.icmpDeny = ds.NewLeftTripleSet[*netset.IPBlock, *netset.IPBlock, *netset.TransportSet]()
-	cubes.anyProtocolDeny = ds.NewProductLeft[*netset.IPBlock, *netset.IPBlock]()
+	cubes.tcpDeny = ds.NewLeftTripleSet[*ipset.IPBlock, *ipset.IPBlock, *netset.TransportSet]()
+	cubes.udpDeny = ds.NewLeftTripleSet[*ipset.IPBlock, *ipset.IPBlock, *netset.TransportSet]()
+	cubes.icmpDeny = ds.NewLeftTripleSet[*ipset.IPBlock, *ipset.IPBlock, *netset.TransportSet]()
+	cubes.anyProtocolDeny = ds.NewProductLeft[*ipset.IPBlock, *ipset.IPBlock]()
 
 	reduceACLCubes(cubes)
 
@@ -75,7 +76,7 @@ func cubesToRules(cubes protocolTripleSet, anyProtocolCubes srcDstProduct, direc
 	if len(partitions) == 0 {
 		return []*ir.ACLRule{}
 	}
-	anyProtocolSrcIPs := anyProtocolCubes.(*ds.ProductLeft[*netset.IPBlock, *netset.IPBlock]).Left(netset.NewIPBlock())
+	anyProtocolSrcIPs := anyProtocolCubes.(*ds.ProductLeft[*ipset.IPBlock, *ipset.IPBlock]).Left(ipset.NewIPBlock())
 
 	res := make([]*ir.ACLRule, 0)
 	activeRules := make([]activeRule, 0) // Left = first src's IP, Right = dst cidr & protocol details
@@ -89,10 +90,10 @@ func cubesToRules(cubes protocolTripleSet, anyProtocolCubes srcDstProduct, direc
 
 		// if there are active rules whose cubeDetails are not fully included in the current cube, they will be created
 		// also activeCubes will be calculated, which is the activeCubess that are still included in the active rules
-		activeCubes := ds.NewProductLeft[*netset.IPBlock, *netset.TransportSet]()
+		activeCubes := ds.NewProductLeft[*ipset.IPBlock, *netset.TransportSet]()
 		for j, rule := range slices.Backward(activeRules) {
 			if rule.Right.IsSubset(partitions[i].Right) {
-				activeCubes = activeCubes.Union(rule.Right).(*ds.ProductLeft[*netset.IPBlock, *netset.TransportSet])
+				activeCubes = activeCubes.Union(rule.Right).(*ds.ProductLeft[*ipset.IPBlock, *netset.TransportSet])
 			} else {
 				res = slices.Concat(res,
 					createNewRules(rule.Left, partitions[i-1].Left.LastIPAddressObject(), rule.Right.Partitions()[0], direction, action))
@@ -118,7 +119,7 @@ func cubesToRules(cubes protocolTripleSet, anyProtocolCubes srcDstProduct, direc
 	return slices.Concat(res, createActiveRules(activeRules, partitions[len(partitions)-1].Left.LastIPAddressObject(), direction, action))
 }
 
-func createActiveRules(activeRules []activeRule, srcLastIP *netset.IPBlock, direction ir.Direction, action ir.Action) []*ir.ACLRule {
+func createActiveRules(activeRules []activeRule, srcLastIP *ipset.IPBlock, direction ir.Direction, action ir.Action) []*ir.ACLRule {
 	res := make([]*ir.ACLRule, 0)
 	for _, rule := range activeRules {
 		res = slices.Concat(res, createNewRules(rule.Left, srcLastIP, rule.Right.Partitions()[0], direction, action))
@@ -126,9 +127,9 @@ func createActiveRules(activeRules []activeRule, srcLastIP *netset.IPBlock, dire
 	return res
 }
 
-func createNewRules(srcStartIP, srcEndIP *netset.IPBlock, cubeDetails ds.Pair[*netset.IPBlock, *netset.TransportSet],
+func createNewRules(srcStartIP, srcEndIP *ipset.IPBlock, cubeDetails ds.Pair[*ipset.IPBlock, *netset.TransportSet],
 	direction ir.Direction, action ir.Action) []*ir.ACLRule {
-	src, _ := netset.IPBlockFromIPRange(srcStartIP, srcEndIP)
+	src, _ := ipset.IPBlockFromIPRange(srcStartIP, srcEndIP)
 	srcCidrs := src.SplitToCidrs()
 
 	res := make([]*ir.ACLRule, len(srcCidrs))
@@ -186,11 +187,14 @@ func transportSetToProtocol(t *netset.TransportSet) netp.Protocol {
 	return res
 }
 
-// converts cubes from a slices of triples to a slice of `activeRule` type
-func convertCubesType(cubes []ds.Triple[*netset.IPBlock, *netset.IPBlock, *netset.TransportSet]) []activeRule {
-	res := make([]activeRule, len(cubes))
+// converts cubes from a slices of triples to a slice of `activeRule` type.
+// the src of each cube is split to IP ranges, since a rule can only continue over consecutive src IPs
+func convertCubesType(cubes []ds.Triple[*ipset.IPBlock, *ipset.IPBlock, *netset.TransportSet]) []activeRule {
+	res := make([]activeRule, 0, len(cubes))
 	for i := range cubes {
-		res[i] = activeRule{Left: cubes[i].S1, Right: ds.CartesianPairLeft(cubes[i].S2, cubes[i].S3)}
+		for _, srcRange := range cubes[i].S1.Split() {
+			res = append(res, activeRule{Left: srcRange, Right: ds.CartesianPairLeft(cubes[i].S2, cubes[i].S3)})
+		}
 	}
 	cmp := func(i, j activeRule) int { return i.Left.Compare(j.Left) }
 	slices.SortFunc(res, cmp)
@@ -198,10 +202,10 @@ func convertCubesType(cubes []ds.Triple[*netset.IPBlock, *netset.IPBlock, *netse
 }
 
 func convertAnyCubesToTripleSet(cubes srcDstProduct) protocolTripleSet {
-	res := ds.NewLeftTripleSet[*netset.IPBlock, *netset.IPBlock, *netset.TransportSet]()
+	res := ds.NewLeftTripleSet[*ipset.IPBlock, *ipset.IPBlock, *netset.TransportSet]()
 	for _, p := range cubes.Partitions() {
 		t := ds.CartesianLeftTriple(p.Left, p.Right, netset.AllTransports())
-		res = res.Union(t).(*ds.LeftTripleSet[*netset.IPBlock, *netset.IPBlock, *netset.TransportSet])
+		res = res.Union(t).(*ds.LeftTripleSet[*ipset.IPBlock, *ipset.IPBlock, *netset.TransportSet])
 	}
 
 	return res
@@ -8,6 +8,7 @@ package acloptimizer
 import (
 	"github.com/np-guard/models/pkg/ds"
 	"github.com/np-guard/models/pkg/netset"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ipset"
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/optimize"
 )
 
@@ -59,8 +60,8 @@ func divideCubes(protocolCubes protocolTripleSet) (allCombinations, anyCombinati
 	allUDPSet := netset.AllUDPTransport()
 	allICMPSet := netset.AllICMPTransport()
 
-	allCombinations = ds.NewProductLeft[*netset.IPBlock, *netset.IPBlock]()
-	anyCombination = ds.NewProductLeft[*netset.IPBlock, *netset.IPBlock]()
+	allCombinations = ds.NewProductLeft[*ipset.IPBlock, *ipset.IPBlock]()
+	anyCombination = ds.NewProductLeft[*ipset.IPBlock, *ipset.IPBlock]()
 
 	for _, p := range protocolCubes.Partitions() {
 		r := ds.CartesianPairLeft(p.S1, p.S2)
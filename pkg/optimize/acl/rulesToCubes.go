@@ -9,17 +9,18 @@ import (
 	"github.com/np-guard/models/pkg/ds"
 	"github.com/np-guard/models/pkg/netp"
 	"github.com/np-guard/models/pkg/netset"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ipset"
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
 )
 
 func aclRulesToCubes(rules []*ir.ACLRule) *aclCubesPerProtocol {
 	res := &aclCubesPerProtocol{
-		tcpAllow:  ds.NewLeftTripleSet[*netset.IPBlock, *netset.IPBlock, *netset.TransportSet](),
-		tcpDeny:   ds.NewLeftTripleSet[*netset.IPBlock, *netset.IPBlock, *netset.TransportSet](),
-		udpAllow:  ds.NewLeftTripleSet[*netset.IPBlock, *netset.IPBlock, *netset.TransportSet](),
-		udpDeny:   ds.NewLeftTripleSet[*netset.IPBlock, *netset.IPBlock, *netset.TransportSet](),
-		icmpAllow: ds.NewLeftTripleSet[*netset.IPBlock, *netset.IPBlock, *netset.TransportSet](),
-		icmpDeny:  ds.NewLeftTripleSet[*netset.IPBlock, *netset.IPBlock, *netset.TransportSet](),
+		tcpAllow:  ds.NewLeftTripleSet[*ipset.IPBlock, *ipset.IPBlock, *netset.TransportSet](),
+		tcpDeny:   ds.NewLeftTripleSet[*ipset.IPBlock, *ipset.IPBlock, *netset.TransportSet](),
+		udpAllow:  ds.NewLeftTripleSet[*ipset.IPBlock, *ipset.IPBlock, *netset.TransportSet](),
+		udpDeny:   ds.NewLeftTripleSet[*ipset.IPBlock, *ipset.IPBlock, *netset.TransportSet](),
+		icmpAllow: ds.NewLeftTripleSet[*ipset.IPBlock, *ipset.IPBlock, *netset.TransportSet](),
+		icmpDeny:  ds.NewLeftTripleSet[*ipset.IPBlock, *ipset.IPBlock, *netset.TransportSet](),
 	}
 
 	for _, rule := range rules {
@@ -6,23 +6,35 @@ SPDX-License-Identifier: Apache-2.0
 package optimize
 
 import (
+	"fmt"
 	"slices"
+	"strings"
 
 	"github.com/np-guard/models/pkg/ds"
 	"github.com/np-guard/models/pkg/netp"
 	"github.com/np-guard/models/pkg/netset"
 
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ipset"
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
 )
 
 type Optimizer interface {
 	// attempts to reduce number of SG/nACL rules
-	Optimize() (ir.Collection, error)
+	Optimize() (ir.Collection, ir.Diagnostics, error)
+}
+
+// ReducedRulesNotice reports the number of rules the optimization reduced in an SG or an nACL
+func ReducedRulesNotice(firewallType, name string, reducedRules int) *ir.Diagnostic {
+	message := fmt.Sprintf("the number of rules in %s %s was reduced by %d", firewallType, name, reducedRules)
+	if reducedRules == 0 {
+		message = fmt.Sprintf("no rules were reduced in %s %s", firewallType, name)
+	}
+	return ir.NewDiagnostic(ir.SeverityInfo, ir.CodeRulesReduced, message, name)
 }
 
 // each IPBlock is a single CIDR. The CIDRs are disjoint.
-func SortPartitionsByIPAddrs[T ds.Set[T]](p []ds.Pair[*netset.IPBlock, T]) []ds.Pair[*netset.IPBlock, T] {
-	cmp := func(i, j ds.Pair[*netset.IPBlock, T]) int {
+func SortPartitionsByIPAddrs[T ds.Set[T]](p []ds.Pair[*ipset.IPBlock, T]) []ds.Pair[*ipset.IPBlock, T] {
+	cmp := func(i, j ds.Pair[*ipset.IPBlock, T]) int {
 		return i.Left.Compare(j.Left)
 	}
 	slices.SortFunc(p, cmp)
@@ -62,14 +74,68 @@ func IsAllPorts(tcpudpPorts *netset.PortSet) bool {
 }
 
 // UncoveredHole returns true if the rules can not be continued between the two cubes
-// i.e there is a hole between two ipblocks that is not a subset of anyProtocol cubes
-func UncoveredHole(prevIPBlock, currIPBlock, anyProtocolCubes *netset.IPBlock) bool {
+// i.e there is a hole between two ipblocks that is not a subset of anyProtocol cubes, or the ipblocks are of different
+// IP versions (IPv4 cubes precede IPv6 cubes), since a rule is of a single IP version
+func UncoveredHole(prevIPBlock, currIPBlock, anyProtocolCubes *ipset.IPBlock) bool {
+	if prevIPBlock.IsIPv6() != currIPBlock.IsIPv6() {
+		return true
+	}
 	touching, _ := prevIPBlock.TouchingIPRanges(currIPBlock)
 	if touching {
 		return false
 	}
 	holeFirstIP, _ := prevIPBlock.NextIP()
 	holeEndIP, _ := currIPBlock.PreviousIP()
-	hole, _ := netset.IPBlockFromIPRange(holeFirstIP, holeEndIP)
+	hole, _ := ipset.IPBlockFromIPRange(holeFirstIP, holeEndIP)
 	return !hole.IsSubset(anyProtocolCubes)
 }
+
+// ProtocolToTransportSet converts a single SG/nACL rule protocol to a TransportSet
+func ProtocolToTransportSet(p netp.Protocol) *netset.TransportSet {
+	switch tp := p.(type) {
+	case netp.TCPUDP:
+		srcPorts := tp.SrcPorts()
+		dstPorts := tp.DstPorts()
+		return netset.NewTCPorUDPTransport(tp.ProtocolString(), srcPorts.Start(), srcPorts.End(), dstPorts.Start(), dstPorts.End())
+	case netp.ICMP:
+		return netset.NewICMPTransportFromICMPSet(netset.ICMPSetFromICMP(ExplicitICMPCode(tp)))
+	}
+	return netset.AllTransports()
+}
+
+// SGRuleString returns the rule as it is reported by query and explain
+func SGRuleString(rule *ir.SGRule) string {
+	return fmt.Sprintf("allow remote: %s, local: %s, conns: %s", rule.Remote, rule.Local, ProtocolToTransportSet(rule.Protocol))
+}
+
+// ACLRuleString returns the rule as it is reported by query and explain
+func ACLRuleString(rule *ir.ACLRule) string {
+	return fmt.Sprintf("%s src: %s, dst: %s, conns: %s", rule.Action, rule.Source, rule.Destination,
+		ProtocolToTransportSet(rule.Protocol))
+}
+
+// ExplicitICMPCode sets the code of ICMP types that have a single valid code, since netp.NewICMP omits it
+func ExplicitICMPCode(icmp netp.ICMP) netp.ICMP {
+	tc := icmp.TypeCode
+	if tc == nil || tc.Code != nil || netp.ValidateICMP(tc) != nil || !netp.HasSingleCode(tc.Type) {
+		return icmp
+	}
+	code := netp.MinICMPCode
+	return netp.ICMP{TypeCode: &netp.ICMPTypeCode{Type: tc.Type, Code: &code}}
+}
+
+// JoinExplanations returns the distinct non-empty explanations, in order, as a single explanation
+func JoinExplanations(explanations []string) string {
+	res := []string{}
+	for _, e := range explanations {
+		if e != "" && !slices.Contains(res, e) {
+			res = append(res, e)
+		}
+	}
+	return strings.Join(res, " | ")
+}
+
+// TransportsOverlap returns true if the two SG/nACL rule protocols share a connection
+func TransportsOverlap(p1, p2 netp.Protocol) bool {
+	return !ProtocolToTransportSet(p1).Intersect(ProtocolToTransportSet(p2)).IsEmpty()
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sgoptimizer
+
+import (
+	"github.com/np-guard/models/pkg/ds"
+	"github.com/np-guard/models/pkg/netp"
+	"github.com/np-guard/models/pkg/netset"
+
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ipset"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
+)
+
+type remoteProtocolProduct = ds.Product[*ipset.IPBlock, *netset.TransportSet]
+
+// AllowedConnections returns the connections allowed by the given rules, which are assumed to share the same direction and local:
+// the connections to IP remotes (remote IPs X protocols) and the protocols allowed to each SG remote
+func AllowedConnections(rules []*ir.SGRule) (ipConns remoteProtocolProduct, sgConns map[ir.SGName]*netset.TransportSet) {
+	ruleGroups := divideSGRules(rules)
+
+	ipCubes := rulesToIPCubes(ruleGroups.ipRemoteRules)
+	ipConns = ds.CartesianPairLeft(ipCubes.anyProtocol, netset.AllTransports())
+	for _, cube := range ipCubes.tcp {
+		ipConns = ipConns.Union(ds.CartesianPairLeft(cube.Left, portSetToTransportSet(netp.ProtocolStringTCP, cube.Right)))
+	}
+	for _, cube := range ipCubes.udp {
+		ipConns = ipConns.Union(ds.CartesianPairLeft(cube.Left, portSetToTransportSet(netp.ProtocolStringUDP, cube.Right)))
+	}
+	for _, cube := range ipCubes.icmp {
+		ipConns = ipConns.Union(ds.CartesianPairLeft(cube.Left, netset.NewICMPTransportFromICMPSet(cube.Right)))
+	}
+
+	sgCubes := rulesToSGCubes(ruleGroups.sgRemoteRules)
+	sgConns = make(map[ir.SGName]*netset.TransportSet)
+	addSGConns := func(remote ir.SGName, t *netset.TransportSet) {
+		if sgConns[remote] == nil {
+			sgConns[remote] = netset.NoTransports()
+		}
+		sgConns[remote] = sgConns[remote].Union(t)
+	}
+	for remote, ports := range sgCubes.tcp {
+		addSGConns(remote, portSetToTransportSet(netp.ProtocolStringTCP, ports))
+	}
+	for remote, ports := range sgCubes.udp {
+		addSGConns(remote, portSetToTransportSet(netp.ProtocolStringUDP, ports))
+	}
+	for remote, icmpSet := range sgCubes.icmp {
+		addSGConns(remote, netset.NewICMPTransportFromICMPSet(icmpSet))
+	}
+	for _, remote := range sgCubes.anyProtocol {
+		addSGConns(remote, netset.AllTransports())
+	}
+	return ipConns, sgConns
+}
+
+// portSetToTransportSet returns a TransportSet of the given protocol, with all source ports and the given destination ports
+func portSetToTransportSet(protocol netp.ProtocolString, dstPorts *netset.PortSet) *netset.TransportSet {
+	res := netset.NoTransports()
+	for _, ports := range dstPorts.Intervals() {
+		res = res.Union(netset.NewTCPorUDPTransport(protocol, netp.MinPort, netp.MaxPort, ports.Start(), ports.End()))
+	}
+	return res
+}
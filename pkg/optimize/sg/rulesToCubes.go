@@ -13,6 +13,7 @@ import (
 	"github.com/np-guard/models/pkg/netp"
 	"github.com/np-guard/models/pkg/netset"
 
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ipset"
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/optimize"
 )
@@ -76,38 +77,38 @@ func rulesToIPCubes(rules *sgRulesPerProtocol) *ipCubesPerProtocol {
 }
 
 // any protocol rules to cubes
-func anyProtocolRulesToIPCubes(rules []*ir.SGRule) *netset.IPBlock {
-	res := netset.NewIPBlock()
+func anyProtocolRulesToIPCubes(rules []*ir.SGRule) *ipset.IPBlock {
+	res := ipset.NewIPBlock()
 	for i := range rules {
-		res = res.Union(rules[i].Remote.(*netset.IPBlock))
+		res = res.Union(rules[i].Remote.(*ipset.IPBlock))
 	}
 	return res
 }
 
 // tcp/udp rules (separately) to cubes (IPBlock X portset)
-func tcpudpRulesToIPCubes(rules []*ir.SGRule, anyProtocolCubes *netset.IPBlock) []ds.Pair[*netset.IPBlock, *netset.PortSet] {
-	cubes := ds.NewProductLeft[*netset.IPBlock, *netset.PortSet]()
+func tcpudpRulesToIPCubes(rules []*ir.SGRule, anyProtocolCubes *ipset.IPBlock) []ds.Pair[*ipset.IPBlock, *netset.PortSet] {
+	cubes := ds.NewProductLeft[*ipset.IPBlock, *netset.PortSet]()
 	for _, rule := range rules {
-		ipb := rule.Remote.(*netset.IPBlock) // already checked
-		p := rule.Protocol.(netp.TCPUDP)     // already checked
+		ipb := rule.Remote.(*ipset.IPBlock) // already checked
+		p := rule.Protocol.(netp.TCPUDP)    // already checked
 		r := ds.CartesianPairLeft(ipb, p.DstPorts().ToSet())
-		cubes = cubes.Union(r).(*ds.ProductLeft[*netset.IPBlock, *netset.PortSet])
+		cubes = cubes.Union(r).(*ds.ProductLeft[*ipset.IPBlock, *netset.PortSet])
 	}
 	anyProtocolPair := ds.CartesianPairLeft(anyProtocolCubes, netset.AllPorts())
-	cubes = cubes.Subtract(anyProtocolPair).(*ds.ProductLeft[*netset.IPBlock, *netset.PortSet]) // subtract any protocol cubes
+	cubes = cubes.Subtract(anyProtocolPair).(*ds.ProductLeft[*ipset.IPBlock, *netset.PortSet]) // subtract any protocol cubes
 	return optimize.SortPartitionsByIPAddrs(cubes.Partitions())
 }
 
 // icmp rules to cubes (IPBlock X icmp set).
-func icmpRulesToIPCubes(rules []*ir.SGRule, anyProtocolCubes *netset.IPBlock) []ds.Pair[*netset.IPBlock, *netset.ICMPSet] {
-	cubes := ds.NewProductLeft[*netset.IPBlock, *netset.ICMPSet]()
+func icmpRulesToIPCubes(rules []*ir.SGRule, anyProtocolCubes *ipset.IPBlock) []ds.Pair[*ipset.IPBlock, *netset.ICMPSet] {
+	cubes := ds.NewProductLeft[*ipset.IPBlock, *netset.ICMPSet]()
 	for _, rule := range rules {
-		ipb := rule.Remote.(*netset.IPBlock) // already checked
-		p := rule.Protocol.(netp.ICMP)       // already checked
+		ipb := rule.Remote.(*ipset.IPBlock) // already checked
+		p := rule.Protocol.(netp.ICMP)      // already checked
 		r := ds.CartesianPairLeft(ipb, netset.ICMPSetFromICMP(p))
-		cubes = cubes.Union(r).(*ds.ProductLeft[*netset.IPBlock, *netset.ICMPSet])
+		cubes = cubes.Union(r).(*ds.ProductLeft[*ipset.IPBlock, *netset.ICMPSet])
 	}
 	anyProtocolPair := ds.CartesianPairLeft(anyProtocolCubes, netset.AllICMPSet())
-	cubes = cubes.Subtract(anyProtocolPair).(*ds.ProductLeft[*netset.IPBlock, *netset.ICMPSet]) // subtract any protocol cubes
+	cubes = cubes.Subtract(anyProtocolPair).(*ds.ProductLeft[*ipset.IPBlock, *netset.ICMPSet]) // subtract any protocol cubes
 	return optimize.SortPartitionsByIPAddrs(cubes.Partitions())
 }
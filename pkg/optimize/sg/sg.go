@@ -7,13 +7,13 @@ package sgoptimizer
 
 import (
 	"fmt"
-	"log"
 	"slices"
 
 	"github.com/np-guard/models/pkg/ds"
 	"github.com/np-guard/models/pkg/netp"
 	"github.com/np-guard/models/pkg/netset"
 
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ipset"
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/optimize"
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/utils"
@@ -48,10 +48,10 @@ type (
 
 	// ipblocks refers to remote IPs
 	ipCubesPerProtocol struct {
-		tcp         []ds.Pair[*netset.IPBlock, *netset.PortSet]
-		udp         []ds.Pair[*netset.IPBlock, *netset.PortSet]
-		icmp        []ds.Pair[*netset.IPBlock, *netset.ICMPSet]
-		anyProtocol *netset.IPBlock
+		tcp         []ds.Pair[*ipset.IPBlock, *netset.PortSet]
+		udp         []ds.Pair[*ipset.IPBlock, *netset.PortSet]
+		icmp        []ds.Pair[*ipset.IPBlock, *netset.ICMPSet]
+		anyProtocol *ipset.IPBlock
 	}
 )
 
@@ -66,37 +66,37 @@ func NewSGOptimizer(collection ir.Collection, sgName string) optimize.Optimizer
 // Optimize attempts to reduce the number of SG rules
 // if -n was supplied, it will attempt to reduce the number of rules only in the requested SG
 // otherwise, it will attempt to reduce the number of rules in all SGs
-func (s *sgOptimizer) Optimize() (ir.Collection, error) {
+func (s *sgOptimizer) Optimize() (ir.Collection, ir.Diagnostics, error) {
 	if s.sgName != "" {
 		for _, vpcName := range utils.SortedMapKeys(s.sgCollection.SGs) {
 			if s.sgVPC != "" && s.sgVPC != vpcName {
 				continue
 			}
 			if _, ok := s.sgCollection.SGs[vpcName][s.sgName]; ok {
-				s.optimizeSG(s.sgCollection.SGs[vpcName][s.sgName])
-				return s.sgCollection, nil
+				return s.sgCollection, ir.Diagnostics{s.optimizeSG(s.sgCollection.SGs[vpcName][s.sgName])}, nil
 			}
 		}
-		return nil, fmt.Errorf("could not find %s sg", s.sgName)
+		return nil, nil, fmt.Errorf("could not find %s sg", s.sgName)
 	}
 
+	var diagnostics ir.Diagnostics
 	for _, vpcName := range utils.SortedMapKeys(s.sgCollection.SGs) {
 		for _, sgName := range utils.SortedMapKeys(s.sgCollection.SGs[vpcName]) {
-			s.optimizeSG(s.sgCollection.SGs[vpcName][sgName])
+			diagnostics = append(diagnostics, s.optimizeSG(s.sgCollection.SGs[vpcName][sgName]))
 		}
 	}
-	return s.sgCollection, nil
+	return s.sgCollection, diagnostics, nil
 }
 
 // optimizeSG attempts to reduce the number of SG rules
 // the algorithm attempts to reduce both inbound and outbound rules separately
-// A message is printed to the log at the end of the algorithm
-func (s *sgOptimizer) optimizeSG(sg *ir.SG) {
+// A notice with the number of reduced rules is returned at the end of the algorithm
+func (s *sgOptimizer) optimizeSG(sg *ir.SG) *ir.Diagnostic {
 	reducedRules := 0
 
 	// reduce inbound rules first
 	for l, rules := range sg.InboundRules {
-		local, _ := netset.IPBlockFromCidr(l)
+		local, _ := ipset.IPBlockFromCidrOrAddress(l)
 		newInboundRules := s.reduceSGRules(rules, ir.Inbound, local)
 		if len(rules) > len(newInboundRules) {
 			reducedRules += len(rules) - len(newInboundRules)
@@ -106,7 +106,7 @@ func (s *sgOptimizer) optimizeSG(sg *ir.SG) {
 
 	// reduce outbound rules second
 	for l, rules := range sg.OutboundRules {
-		local, _ := netset.IPBlockFromCidr(l)
+		local, _ := ipset.IPBlockFromCidrOrAddress(l)
 		newOutboundRules := s.reduceSGRules(rules, ir.Outbound, local)
 		if len(rules) > len(newOutboundRules) {
 			reducedRules += len(rules) - len(newOutboundRules)
@@ -114,16 +114,11 @@ func (s *sgOptimizer) optimizeSG(sg *ir.SG) {
 		}
 	}
 
-	// print a message to the log
-	if reducedRules == 0 {
-		log.Printf("no rules were reduced in sg %s\n", string(sg.SGName))
-	} else {
-		log.Printf("the number of rules in sg %s was reduced by %d\n", string(sg.SGName), reducedRules)
-	}
+	return optimize.ReducedRulesNotice("sg", string(sg.SGName), reducedRules)
 }
 
 // reduceSGRules attempts to reduce the number of rules with different remote types separately
-func (s *sgOptimizer) reduceSGRules(rules []*ir.SGRule, direction ir.Direction, l *netset.IPBlock) []*ir.SGRule {
+func (s *sgOptimizer) reduceSGRules(rules []*ir.SGRule, direction ir.Direction, l *ipset.IPBlock) []*ir.SGRule {
 	// separate all rules to groups of protocol X remote ([tcp, udp, icmp, protocolAll] X [ip, sg])
 	ruleGroups := divideSGRules(rules)
 
@@ -141,10 +136,41 @@ func (s *sgOptimizer) reduceSGRules(rules []*ir.SGRule, direction ir.Direction,
 		optimizedRulesToIPAddrs = originalRulesToIPAddrs
 	}
 
-	return slices.Concat(optimizedRulesToSG, optimizedRulesToIPAddrs)
+	res := slices.Concat(optimizedRulesToSG, optimizedRulesToIPAddrs)
+	explainSGRules(res, rules)
+	return res
+}
+
+// explainSGRules sets the explanation and the origins of each new rule to those of the original rules it overlaps,
+// so optimizing synthesized SGs keeps track of the connections behind each rule
+func explainSGRules(optimized, original []*ir.SGRule) {
+	for _, rule := range optimized {
+		if rule.Explanation != "" { // an original rule
+			continue
+		}
+		explanations := []string{}
+		var origins []*ir.RuleOrigin
+		for _, o := range original {
+			if remotesOverlap(rule.Remote, o.Remote) && optimize.TransportsOverlap(rule.Protocol, o.Protocol) {
+				explanations = append(explanations, o.Explanation)
+				origins = ir.MergeOrigins(origins, o.Origins)
+			}
+		}
+		rule.Explanation = optimize.JoinExplanations(explanations)
+		rule.Origins = origins
+	}
+}
+
+func remotesOverlap(r1, r2 ir.RemoteType) bool {
+	ipb1, ok1 := r1.(*ipset.IPBlock)
+	ipb2, ok2 := r2.(*ipset.IPBlock)
+	if ok1 && ok2 {
+		return ipb1.Overlap(ipb2)
+	}
+	return !ok1 && !ok2 && r1.(ir.SGName) == r2.(ir.SGName)
 }
 
-func reduceRulesSGRemote(cubes *sgCubesPerProtocol, direction ir.Direction, l *netset.IPBlock) []*ir.SGRule {
+func reduceRulesSGRemote(cubes *sgCubesPerProtocol, direction ir.Direction, l *ipset.IPBlock) []*ir.SGRule {
 	reduceCubesWithSGRemote(cubes)
 
 	// cubes to SG rules
@@ -157,7 +183,7 @@ func reduceRulesSGRemote(cubes *sgCubesPerProtocol, direction ir.Direction, l *n
 	return slices.Concat(tcpRules, udpRules, icmpRules, anyProtocolRules)
 }
 
-func reduceRulesIPRemote(cubes *ipCubesPerProtocol, direction ir.Direction, l *netset.IPBlock) []*ir.SGRule {
+func reduceRulesIPRemote(cubes *ipCubesPerProtocol, direction ir.Direction, l *ipset.IPBlock) []*ir.SGRule {
 	reduceIPCubes(cubes)
 
 	tcpRules := tcpudpIPCubesToMinRules(cubes.tcp, cubes.anyProtocol, direction, true, l)
@@ -209,7 +235,7 @@ func divideSGRules(rules []*ir.SGRule) *sgRuleGroups {
 }
 
 func isRemoteIPBlock(rule *ir.SGRule) bool {
-	_, ok := rule.Remote.(*netset.IPBlock)
+	_, ok := rule.Remote.(*ipset.IPBlock)
 	return ok
 }
 
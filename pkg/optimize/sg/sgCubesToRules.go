@@ -9,12 +9,13 @@ import (
 	"github.com/np-guard/models/pkg/netp"
 	"github.com/np-guard/models/pkg/netset"
 
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ipset"
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/optimize"
 )
 
 // cubes (SGName X portSet) to SG rules
-func tcpudpSGCubesToRules(cubes map[ir.SGName]*netset.PortSet, direction ir.Direction, isTCP bool, l *netset.IPBlock) []*ir.SGRule {
+func tcpudpSGCubesToRules(cubes map[ir.SGName]*netset.PortSet, direction ir.Direction, isTCP bool, l *ipset.IPBlock) []*ir.SGRule {
 	result := make([]*ir.SGRule, 0)
 	for sgName, portSet := range cubes {
 		for _, dstPorts := range portSet.Intervals() {
@@ -26,7 +27,7 @@ func tcpudpSGCubesToRules(cubes map[ir.SGName]*netset.PortSet, direction ir.Dire
 }
 
 // cubes (SGName X icmpset) to SG rules
-func icmpSGCubesToRules(cubes map[ir.SGName]*netset.ICMPSet, direction ir.Direction, l *netset.IPBlock) []*ir.SGRule {
+func icmpSGCubesToRules(cubes map[ir.SGName]*netset.ICMPSet, direction ir.Direction, l *ipset.IPBlock) []*ir.SGRule {
 	result := make([]*ir.SGRule, 0)
 	for sgName, icmpSet := range cubes {
 		for _, icmp := range optimize.IcmpsetPartitions(icmpSet) {
@@ -37,7 +38,7 @@ func icmpSGCubesToRules(cubes map[ir.SGName]*netset.ICMPSet, direction ir.Direct
 }
 
 // slice of remote SGs to SG rules
-func anyProtocolCubesToRules(remoteSG []ir.SGName, direction ir.Direction, l *netset.IPBlock) []*ir.SGRule {
+func anyProtocolCubesToRules(remoteSG []ir.SGName, direction ir.Direction, l *ipset.IPBlock) []*ir.SGRule {
 	result := make([]*ir.SGRule, len(remoteSG))
 	for i, sgName := range remoteSG {
 		result[i] = ir.NewSGRule(direction, sgName, netp.AnyProtocol{}, l, "")
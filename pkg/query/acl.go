@@ -0,0 +1,88 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package query
+
+import (
+	"github.com/np-guard/models/pkg/netset"
+
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ipset"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/optimize"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/utils"
+)
+
+type ACLQuerier struct {
+	defs       *ir.ConfigDefs
+	collection *ir.ACLCollection
+}
+
+// NewACLQuerier creates and returns a new ACLQuerier instance
+func NewACLQuerier(defs *ir.ConfigDefs, collection ir.Collection) Querier {
+	return &ACLQuerier{defs: defs, collection: collection.(*ir.ACLCollection)}
+}
+
+// Query checks the flow against the outbound rules of the nACL of the source subnet and the inbound rules of the nACL
+// of the destination subnet, in first-match order. Traffic within a subnet is not filtered by its nACL.
+// Responses are not checked.
+func (a *ACLQuerier) Query(f *Flow) *Result {
+	res := &Result{Flow: f}
+	srcSubnet, dstSubnet := a.subnetOf(f.Src), a.subnetOf(f.Dst)
+	if srcSubnet == dstSubnet {
+		return res
+	}
+	if srcSubnet != "" {
+		res.Checks = append(res.Checks, a.check(srcSubnet, f, ir.Outbound))
+	}
+	if dstSubnet != "" {
+		res.Checks = append(res.Checks, a.check(dstSubnet, f, ir.Inbound))
+	}
+	return res
+}
+
+func (a *ACLQuerier) check(subnet ir.ID, f *Flow, direction ir.Direction) *Check {
+	res := &Check{Resource: subnet, Direction: direction, Allowed: netset.NoTransports()}
+	acl := a.collection.AttachedACL(subnet, a.defs.Subnets[subnet].NetworkACL)
+	if acl == nil {
+		return res
+	}
+	firewall := ir.VpcFromScopedResource(subnet) + "/" + acl.Name
+	res.Firewalls = []string{firewall}
+
+	remaining := f.Transport
+	index := 0
+	for _, rule := range acl.Rules() {
+		if rule.Direction != direction {
+			continue
+		}
+		index++
+		if !f.Src.IsSubset(rule.Source) || !f.Dst.IsSubset(rule.Destination) {
+			continue
+		}
+		decided := remaining.Intersect(optimize.ProtocolToTransportSet(rule.Protocol))
+		if decided.IsEmpty() {
+			continue
+		}
+		res.Matches = append(res.Matches, &Match{Firewall: firewall, Index: index, Action: rule.Action,
+			Rule: optimize.ACLRuleString(rule), Explanation: rule.Explanation, Conns: decided})
+		if rule.Action == ir.Allow {
+			res.Allowed = res.Allowed.Union(decided)
+		}
+		if remaining = remaining.Subtract(decided); remaining.IsEmpty() {
+			break
+		}
+	}
+	return res
+}
+
+// subnetOf returns the subnet that contains the given IP address, or an empty string if there is no such subnet
+func (a *ACLQuerier) subnetOf(ip *ipset.IPBlock) ir.ID {
+	for _, subnet := range utils.SortedMapKeys(a.defs.Subnets) {
+		if ip.IsSubset(a.defs.Subnets[subnet].CIDR) {
+			return subnet
+		}
+	}
+	return ""
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package query reports whether existing Network ACLs and Security Groups allow a given flow, and which rules decide it.
+package query
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/np-guard/models/pkg/netset"
+
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ipset"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
+)
+
+type (
+	Querier interface {
+		Query(f *Flow) *Result
+	}
+
+	// Flow is a set of packets from a single IP address to a single IP address (e.g., TCP to port 443 from any source port)
+	Flow struct {
+		Src       *ipset.IPBlock
+		Dst       *ipset.IPBlock
+		Transport *netset.TransportSet
+	}
+
+	// Result holds the decision of the firewalls at each resource that filters the flow
+	Result struct {
+		Flow   *Flow
+		Checks []*Check
+	}
+
+	// Check is the decision of the firewalls of a single resource (subnet, NIF or VPE reserved IP) in a single direction
+	Check struct {
+		Resource  ir.ID
+		Direction ir.Direction
+		Firewalls []string
+
+		// the part of the flow allowed by the firewalls
+		Allowed *netset.TransportSet
+
+		// the rules that decide the flow: the first matching rules of an nACL, or all matching rules of the SGs
+		Matches []*Match
+	}
+
+	Match struct {
+		Firewall string
+
+		// the index of the rule among the rules of the firewall in the same direction, starting from 1
+		Index int
+
+		Action      ir.Action
+		Rule        string
+		Explanation string
+
+		// the part of the flow decided by the rule
+		Conns *netset.TransportSet
+	}
+)
+
+const noFirewalls = "none"
+
+// NewFlow creates and returns a new Flow; src and dst must be single IP addresses
+func NewFlow(src, dst *ipset.IPBlock, transport *netset.TransportSet) (*Flow, error) {
+	if !src.IsSingleIPAddress() || !dst.IsSingleIPAddress() {
+		return nil, fmt.Errorf("source and destination must be single IP addresses, got %v and %v", src, dst)
+	}
+	return &Flow{Src: src, Dst: dst, Transport: transport}, nil
+}
+
+func (f *Flow) String() string {
+	return fmt.Sprintf("src: %s, dst: %s, conns: %s", f.Src, f.Dst, f.Transport)
+}
+
+// Allowed returns the part of the flow allowed by all the checks
+func (r *Result) Allowed() *netset.TransportSet {
+	res := r.Flow.Transport
+	for _, check := range r.Checks {
+		res = res.Intersect(check.Allowed)
+	}
+	return res
+}
+
+func (r *Result) Summary() string {
+	allowed := r.Allowed()
+	switch {
+	case allowed.IsEmpty():
+		return fmt.Sprintf("%s is blocked", r.Flow)
+	case allowed.Equal(r.Flow.Transport):
+		return fmt.Sprintf("%s is allowed", r.Flow)
+	default:
+		return fmt.Sprintf("%s is partially allowed: %s", r.Flow, allowed)
+	}
+}
+
+func (r *Result) String() string {
+	var sb strings.Builder
+	if len(r.Checks) == 0 {
+		sb.WriteString("the flow is not filtered by any firewall\n")
+	}
+	for _, check := range r.Checks {
+		firewalls := noFirewalls
+		if len(check.Firewalls) > 0 {
+			firewalls = strings.Join(check.Firewalls, ", ")
+		}
+		fmt.Fprintf(&sb, "%s %s [%s]: ", check.Resource, check.Direction, firewalls)
+		if check.Allowed.IsEmpty() {
+			sb.WriteString("blocked\n")
+		} else {
+			fmt.Fprintf(&sb, "allowed %s\n", check.Allowed)
+		}
+		if len(check.Matches) == 0 {
+			sb.WriteString("\tno matching rule\n")
+		}
+		for _, m := range check.Matches {
+			fmt.Fprintf(&sb, "\t%s rule #%d: %s\n", m.Firewall, m.Index, m.Rule)
+			fmt.Fprintf(&sb, "\t\tdecides: %s\n", m.Conns)
+			if m.Explanation != "" {
+				fmt.Fprintf(&sb, "\t\texplanation: %s\n", m.Explanation)
+			}
+		}
+	}
+	return sb.String()
+}
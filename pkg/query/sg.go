@@ -0,0 +1,83 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package query
+
+import (
+	"github.com/np-guard/models/pkg/netset"
+
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ipset"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/optimize"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/utils"
+)
+
+type SGQuerier struct {
+	defs       *ir.ConfigDefs
+	collection *ir.SGCollection
+
+	// IP addresses of the NIFs/reserved IPs, per VPC and per the name used in SG targets
+	targetIPs map[ir.ID]map[string]*ipset.IPBlock
+}
+
+// NewSGQuerier creates and returns a new SGQuerier instance
+func NewSGQuerier(defs *ir.ConfigDefs, collection ir.Collection) Querier {
+	return &SGQuerier{defs: defs, collection: collection.(*ir.SGCollection)}
+}
+
+// Query checks the flow against the outbound rules of the SGs attached to the source NIF/VPE and the inbound rules of
+// the SGs attached to the destination NIF/VPE. A flow is allowed by the SGs of a target if any of their rules allows it.
+func (s *SGQuerier) Query(f *Flow) *Result {
+	s.targetIPs = s.defs.SGTargetIPs()
+	res := &Result{Flow: f}
+	for _, vpc := range utils.SortedMapKeys(s.targetIPs) {
+		for _, target := range utils.SortedMapKeys(s.targetIPs[vpc]) {
+			if f.Src.IsSubset(s.targetIPs[vpc][target]) {
+				res.Checks = append(res.Checks, s.check(vpc, target, f, ir.Outbound))
+			}
+		}
+	}
+	for _, vpc := range utils.SortedMapKeys(s.targetIPs) {
+		for _, target := range utils.SortedMapKeys(s.targetIPs[vpc]) {
+			if f.Dst.IsSubset(s.targetIPs[vpc][target]) {
+				res.Checks = append(res.Checks, s.check(vpc, target, f, ir.Inbound))
+			}
+		}
+	}
+	return res
+}
+
+func (s *SGQuerier) check(vpc ir.ID, target string, f *Flow, direction ir.Direction) *Check {
+	res := &Check{Resource: vpc + "/" + target, Direction: direction, Allowed: netset.NoTransports()}
+	local, remote := f.Src, f.Dst
+	if direction == ir.Inbound {
+		local, remote = f.Dst, f.Src
+	}
+	for _, sg := range s.collection.AttachedSGs(vpc, target) {
+		firewall := vpc + "/" + string(sg.SGName)
+		res.Firewalls = append(res.Firewalls, firewall)
+		rules := sg.InboundRules
+		if direction == ir.Outbound {
+			rules = sg.OutboundRules
+		}
+		index := 0
+		for _, localKey := range utils.SortedMapKeys(rules) {
+			for _, rule := range rules[localKey] {
+				index++
+				if !local.IsSubset(rule.Local) || !remote.IsSubset(s.collection.RemoteIPs(rule.Remote, vpc, s.targetIPs)) {
+					continue
+				}
+				conns := f.Transport.Intersect(optimize.ProtocolToTransportSet(rule.Protocol))
+				if conns.IsEmpty() {
+					continue
+				}
+				res.Matches = append(res.Matches, &Match{Firewall: firewall, Index: index, Action: ir.Allow,
+					Rule: optimize.SGRuleString(rule), Explanation: rule.Explanation, Conns: conns})
+				res.Allowed = res.Allowed.Union(conns)
+			}
+		}
+	}
+	return res
+}
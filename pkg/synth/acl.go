@@ -7,50 +7,105 @@ SPDX-License-Identifier: Apache-2.0
 package synth
 
 import (
-	"github.com/np-guard/models/pkg/netset"
+	"slices"
 
+	"github.com/np-guard/models/pkg/netp"
+
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ipset"
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
+	acloptimizer "github.com/np-guard/vpc-network-config-synthesis/pkg/optimize/acl"
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/utils"
 )
 
-type ACLSynthesizer struct {
-	spec      *ir.Spec
-	singleACL bool
-	result    *ir.ACLCollection
-}
+type (
+	ACLSynthesizer struct {
+		spec      *ir.Spec
+		singleACL bool
+		optimize  bool
+		coarse    bool
+		responses *ir.ResponsePolicies
+		quotas    *Quotas
+		result    *ir.ACLCollection
+	}
+
+	// ACLStrategy sets how precisely the synthesized nACLs filter the traffic
+	ACLStrategy string
+)
+
+const (
+	// FineACLs allow exactly the protocols of the required connections
+	FineACLs ACLStrategy = "fine"
+
+	// CoarseACLs allow any protocol between the subnets of the required connections, with their rules merged, leaving
+	// the protocol and port restrictions to the SGs
+	CoarseACLs ACLStrategy = "coarse"
+)
 
 const WarningUnspecifiedACL = "The following subnets do not have required connections; the generated ACL will block all traffic: "
 
+var ACLStrategies = []string{string(FineACLs), string(CoarseACLs)}
+
 // NewACLSynthesizer creates and returns a new ACLSynthesizer instance
-func NewACLSynthesizer(s *ir.Spec, single bool) Synthesizer {
-	return &ACLSynthesizer{spec: s, singleACL: single, result: ir.NewACLCollection()}
+func NewACLSynthesizer(s *ir.Spec, options *Options) Synthesizer {
+	return &ACLSynthesizer{spec: s, singleACL: options.SingleACL, optimize: options.Optimize, coarse: options.ACLStrategy == CoarseACLs,
+		responses: options.Responses, quotas: options.Quotas, result: ir.NewACLCollection()}
 }
 
-func (a *ACLSynthesizer) Synth() (collection ir.Collection, warning string) {
-	return a.makeACL()
+// Synth returns an error listing the nACLs that have more rules than the quota allows.
+// Coarse nACLs are always optimized, so that the rules of each subnet are merged.
+func (a *ACLSynthesizer) Synth() (collection ir.Collection, diagnostics ir.Diagnostics, err error) {
+	collection, diagnostics = a.makeACL()
+	if a.optimize || a.coarse {
+		var optimizerDiagnostics ir.Diagnostics
+		if collection, optimizerDiagnostics, err = acloptimizer.NewACLOptimizer(collection, "").Optimize(); err != nil {
+			return nil, diagnostics, err
+		}
+		diagnostics = append(diagnostics, optimizerDiagnostics...)
+	}
+	if err = checkACLQuotas(a.result, a.quotas); err != nil {
+		return nil, diagnostics, err
+	}
+	return collection, diagnostics, nil
 }
 
 // makeACL translates Spec to a collection of nACLs
 // 1. generate nACL rules for relevant subnets for each connection
-// 2. generate nACL rules for blocked subnets (subnets that do not appear in Spec)
-func (a *ACLSynthesizer) makeACL() (collection *ir.ACLCollection, warning string) {
+// 2. generate nACL deny rules for relevant subnets for each forbidden connection
+// 3. generate nACL rules for blocked subnets (subnets that do not appear in Spec)
+// 4. set the internal address space of the nACLs, which is denied before the rules of external connections
+func (a *ACLSynthesizer) makeACL() (collection *ir.ACLCollection, warnings ir.Diagnostics) {
 	for _, conn := range a.spec.Connections {
 		a.generateACLRulesFromConnection(conn, conn.Src, conn.Dst, a.allowConnectionSrc)
 		a.generateACLRulesFromConnection(conn, conn.Dst, conn.Src, a.allowConnectionDst)
 	}
-	warning = a.generateACLRulesForBlockedSubnets()
-	return a.result, warning
+	for _, conn := range a.spec.Forbidden {
+		a.generateACLRulesFromConnection(conn, conn.Src, conn.Dst, a.denyConnectionSrc)
+		a.generateACLRulesFromConnection(conn, conn.Dst, conn.Src, a.denyConnectionDst)
+	}
+	warnings = slices.Concat(a.spec.Diagnostics,
+		joinWarnings(unroutableConnectionsWarning(a.spec), noPublicPathWarning(a.spec), a.generateACLRulesForBlockedSubnets()))
+	a.setInternalAddrs()
+	return a.result, warnings
 }
 
+// generateACLRulesFromConnection generates the rules of a connection between the subnets of this resource and the cidrs of
+// the other resource, separately for each IP version, since an nACL rule is of a single IP version
 func (a *ACLSynthesizer) generateACLRulesFromConnection(conn *ir.Connection, thisResource, otherResource *ir.ConnectedResource,
-	allowConnection func(*ir.Connection, *ir.TrackedProtocol, *ir.NamedAddrs, *netset.IPBlock)) {
+	allowConnection func(*ir.Connection, *ir.TrackedProtocol, *ir.NamedAddrs, *ipset.IPBlock)) {
 	for _, thisSubnet := range thisResource.CidrsWhenLocal {
 		for _, otherCidr := range otherResource.CidrsWhenRemote {
 			if thisSubnet.IPAddrs.Equal(otherCidr.IPAddrs) {
 				continue
 			}
-			for _, trackedProtocol := range conn.TrackedProtocols {
-				allowConnection(conn, trackedProtocol, thisSubnet, otherCidr.IPAddrs)
+			for _, thisCidr := range thisSubnet.IPAddrs.SplitByIPVersion() {
+				otherCidrOfVersion := otherCidr.IPAddrs.Intersect(ipset.CidrAllOf(thisCidr))
+				if otherCidrOfVersion.IsEmpty() {
+					continue
+				}
+				thisSubnetOfVersion := &ir.NamedAddrs{Name: thisSubnet.Name, IPAddrs: thisCidr}
+				for _, trackedProtocol := range conn.TrackedProtocols {
+					allowConnection(conn, trackedProtocol, thisSubnetOfVersion, otherCidrOfVersion)
+				}
 			}
 		}
 	}
@@ -58,38 +113,76 @@ func (a *ACLSynthesizer) generateACLRulesFromConnection(conn *ir.Connection, thi
 
 // if the src in internal, rule(s) will be created to allow traffic.
 // if the protocol allows response, more rules will be created.
-func (a *ACLSynthesizer) allowConnectionSrc(conn *ir.Connection, p *ir.TrackedProtocol, srcSubnet *ir.NamedAddrs, dstCidr *netset.IPBlock) {
+func (a *ACLSynthesizer) allowConnectionSrc(conn *ir.Connection, p *ir.TrackedProtocol, srcSubnet *ir.NamedAddrs, dstCidr *ipset.IPBlock) {
 	internalSrc, _, internal := internalConnection(conn)
 
 	if !internalSrc {
 		return
 	}
 	reason := explanation{internal: internal, connectionOrigin: conn.Origin, protocolOrigin: p.Origin}
-	request := &ir.Packet{Src: srcSubnet.IPAddrs, Dst: dstCidr, Protocol: p.Protocol, Explanation: reason.String()}
+	request := &ir.Packet{Src: srcSubnet.IPAddrs, Dst: dstCidr, Protocol: a.allowedProtocol(p), Explanation: reason.String(),
+		Origins: reason.origins()}
 	a.addRuleToACL(ir.AllowSend(request), srcSubnet.Name, internal)
-	if inverseProtocol := p.Protocol.InverseDirection(); inverseProtocol != nil {
-		response := &ir.Packet{Src: dstCidr, Dst: srcSubnet.IPAddrs, Protocol: inverseProtocol, Explanation: reason.response().String()}
+	if inverseProtocol := a.responses.Response(request.Protocol); inverseProtocol != nil {
+		responseReason := reason.response(a.responses.Policy(request.Protocol))
+		response := &ir.Packet{Src: dstCidr, Dst: srcSubnet.IPAddrs, Protocol: inverseProtocol, Explanation: responseReason.String(),
+			Origins: responseReason.origins()}
 		a.addRuleToACL(ir.AllowReceive(response), srcSubnet.Name, internal)
 	}
 }
 
 // if the dst in internal, rule(s) will be created to allow traffic.
 // if the protocol allows response, more rules will be created.
-func (a *ACLSynthesizer) allowConnectionDst(conn *ir.Connection, p *ir.TrackedProtocol, dstSubnet *ir.NamedAddrs, srcCidr *netset.IPBlock) {
+func (a *ACLSynthesizer) allowConnectionDst(conn *ir.Connection, p *ir.TrackedProtocol, dstSubnet *ir.NamedAddrs, srcCidr *ipset.IPBlock) {
 	_, internalDst, internal := internalConnection(conn)
 
 	if !internalDst {
 		return
 	}
 	reason := explanation{internal: internal, connectionOrigin: conn.Origin, protocolOrigin: p.Origin}
-	request := &ir.Packet{Src: srcCidr, Dst: dstSubnet.IPAddrs, Protocol: p.Protocol, Explanation: reason.String()}
+	request := &ir.Packet{Src: srcCidr, Dst: dstSubnet.IPAddrs, Protocol: a.allowedProtocol(p), Explanation: reason.String(),
+		Origins: reason.origins()}
 	a.addRuleToACL(ir.AllowReceive(request), dstSubnet.Name, internal)
-	if inverseProtocol := p.Protocol.InverseDirection(); inverseProtocol != nil {
-		response := &ir.Packet{Src: dstSubnet.IPAddrs, Dst: srcCidr, Protocol: inverseProtocol, Explanation: reason.response().String()}
+	if inverseProtocol := a.responses.Response(request.Protocol); inverseProtocol != nil {
+		responseReason := reason.response(a.responses.Policy(request.Protocol))
+		response := &ir.Packet{Src: dstSubnet.IPAddrs, Dst: srcCidr, Protocol: inverseProtocol, Explanation: responseReason.String(),
+			Origins: responseReason.origins()}
 		a.addRuleToACL(ir.AllowSend(response), dstSubnet.Name, internal)
 	}
 }
 
+// allowedProtocol returns the protocol of the rules that allow a connection. Coarse nACLs allow any protocol, so that
+// the rules of all the connections between the same subnets are merged.
+func (a *ACLSynthesizer) allowedProtocol(p *ir.TrackedProtocol) netp.Protocol {
+	if a.coarse {
+		return netp.AnyProtocol{}
+	}
+	return p.Protocol
+}
+
+// if the src in internal, a deny rule will be created, preceding all allow rules.
+// responses are not denied, since they may be responses to required connections in the other direction.
+func (a *ACLSynthesizer) denyConnectionSrc(conn *ir.Connection, p *ir.TrackedProtocol, srcSubnet *ir.NamedAddrs, dstCidr *ipset.IPBlock) {
+	if internalSrc, _, _ := internalConnection(conn); !internalSrc {
+		return
+	}
+	reason := explanation{forbidden: true, connectionOrigin: conn.Origin, protocolOrigin: p.Origin}
+	request := &ir.Packet{Src: srcSubnet.IPAddrs, Dst: dstCidr, Protocol: p.Protocol, Explanation: reason.String(),
+		Origins: reason.origins()}
+	a.result.LookupOrCreate(srcSubnet.Name, a.singleACL).AppendForbidden(ir.DenySend(request))
+}
+
+// if the dst in internal, a deny rule will be created, preceding all allow rules.
+func (a *ACLSynthesizer) denyConnectionDst(conn *ir.Connection, p *ir.TrackedProtocol, dstSubnet *ir.NamedAddrs, srcCidr *ipset.IPBlock) {
+	if _, internalDst, _ := internalConnection(conn); !internalDst {
+		return
+	}
+	reason := explanation{forbidden: true, connectionOrigin: conn.Origin, protocolOrigin: p.Origin}
+	request := &ir.Packet{Src: srcCidr, Dst: dstSubnet.IPAddrs, Protocol: p.Protocol, Explanation: reason.String(),
+		Origins: reason.origins()}
+	a.result.LookupOrCreate(dstSubnet.Name, a.singleACL).AppendForbidden(ir.DenyReceive(request))
+}
+
 func (a *ACLSynthesizer) addRuleToACL(rule *ir.ACLRule, subnetName ir.ID, internal bool) {
 	acl := a.result.LookupOrCreate(subnetName, a.singleACL)
 	if internal {
@@ -99,14 +192,25 @@ func (a *ACLSynthesizer) addRuleToACL(rule *ir.ACLRule, subnetName ir.ID, intern
 	}
 }
 
+// setInternalAddrs sets the internal address space of each nACL to that of its VPC
+func (a *ACLSynthesizer) setInternalAddrs() {
+	for _, vpc := range a.result.VpcNames() {
+		internalAddrs := a.spec.Defs.InternalAddrs(vpc)
+		for _, acl := range a.result.ACLs[vpc] {
+			acl.InternalAddrs = internalAddrs
+		}
+	}
+}
+
 // generate nACL rules for blocked subnets (subnets that do not appear in Spec)
-func (a *ACLSynthesizer) generateACLRulesForBlockedSubnets() string {
+func (a *ACLSynthesizer) generateACLRulesForBlockedSubnets() *ir.Diagnostic {
 	blockedSubnets := utils.TrueKeyValues(a.spec.BlockedSubnets)
 	for _, subnet := range blockedSubnets {
 		acl := a.result.LookupOrCreate(subnet, a.singleACL)
-		cidr := a.spec.Defs.Subnets[subnet].Address()
-		acl.AppendInternal(ir.DenyAllReceive(subnet, cidr))
-		acl.AppendInternal(ir.DenyAllSend(subnet, cidr))
+		for _, cidr := range a.spec.Defs.Subnets[subnet].Address().SplitByIPVersion() {
+			acl.AppendInternal(ir.DenyAllReceive(subnet, cidr))
+			acl.AppendInternal(ir.DenyAllSend(subnet, cidr))
+		}
 	}
-	return setUnspecifiedWarning(WarningUnspecifiedACL, blockedSubnets)
+	return setUnspecifiedWarning(ir.CodeUnspecifiedACL, WarningUnspecifiedACL, blockedSubnets)
 }
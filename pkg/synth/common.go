@@ -7,37 +7,64 @@ package synth
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
 )
 
+const WarningUnroutableConnections = "The following required connections are between VPCs that are not connected by a transit gateway " +
+	"advertising both of their endpoints, so their traffic cannot be routed: "
+
+const WarningNoPublicPath = "The following required connections with the public internet can never work, since their endpoints " +
+	"have no public gateway or floating IP: "
+
 type (
 	Synthesizer interface {
-		Synth() (ir.Collection, string)
+		Synth() (ir.Collection, ir.Diagnostics, error)
+	}
+
+	// Options configure the synthesis
+	Options struct {
+		SingleACL   bool        // generate a single nACL per VPC (nACL synthesis only)
+		ACLStrategy ACLStrategy // how precise the nACL rules are (nACL synthesis only; default: FineACLs)
+		Optimize    bool        // optimize the synthesized rules, before checking the quotas
+		Quotas      *Quotas
+		Responses   *ir.ResponsePolicies // the responses that nACLs allow (nACL synthesis only; default: exact TCP responses)
 	}
 
 	explanation struct {
 		isResponse       bool
+		responsePolicy   ir.ResponsePolicy
 		internal         bool
-		connectionOrigin fmt.Stringer
-		protocolOrigin   fmt.Stringer
+		forbidden        bool
+		connectionOrigin ir.ConnectionOrigin
+		protocolOrigin   ir.ProtocolOrigin
 	}
 )
 
-func (e explanation) response() explanation {
+func (e explanation) response(policy ir.ResponsePolicy) explanation {
 	e.isResponse = true
+	e.responsePolicy = policy
 	return e
 }
 
+// origins returns the spec connection and protocol that the explained rule serves
+func (e explanation) origins() []*ir.RuleOrigin {
+	return []*ir.RuleOrigin{ir.NewRuleOrigin(e.connectionOrigin, e.protocolOrigin, e.isResponse)}
+}
+
 func (e explanation) String() string {
 	locality := "External"
-	if e.internal {
+	switch {
+	case e.forbidden:
+		locality = "Forbidden"
+	case e.internal:
 		locality = "Internal"
 	}
 	result := fmt.Sprintf("%v; %v", e.connectionOrigin, e.protocolOrigin)
 	if e.isResponse {
-		result = fmt.Sprintf("response to %v", result)
+		result = fmt.Sprintf("response (%v) to %v", e.responsePolicy.Description(), result)
 	}
 	result = fmt.Sprintf("%v. %v", locality, result)
 	return result
@@ -50,10 +77,86 @@ func internalConnection(conn *ir.Connection) (internalSrc, internalDst, internal
 	return
 }
 
-func setUnspecifiedWarning(warningPrefix string, blockedResources []ir.ID) string {
-	warning := ""
-	if len(blockedResources) > 0 {
-		warning = warningPrefix + strings.Join(blockedResources, ", ")
+// unroutableConnectionsWarning lists the required connections between endpoints in different VPCs that are not connected
+// by a transit gateway through which both endpoints are advertised
+func unroutableConnectionsWarning(spec *ir.Spec) *ir.Diagnostic {
+	var unroutable []string
+	for _, conn := range spec.Connections {
+		if _, _, internal := internalConnection(conn); internal && !routable(spec.Defs, conn) {
+			unroutable = append(unroutable, conn.Origin.String())
+		}
+	}
+	return originsWarning(ir.CodeUnroutableConnections, WarningUnroutableConnections, unroutable)
+}
+
+func routable(defs *ir.Definitions, conn *ir.Connection) bool {
+	for _, src := range conn.Src.CidrsWhenLocal {
+		for _, dst := range conn.Dst.CidrsWhenLocal {
+			srcVPC, dstVPC := ir.VpcFromScopedResource(src.Name), ir.VpcFromScopedResource(dst.Name)
+			if defs.VPCs[srcVPC] == nil || defs.VPCs[dstVPC] == nil {
+				continue
+			}
+			if !defs.Routable(srcVPC, defs.LocalAddrs(src), dstVPC, defs.LocalAddrs(dst)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// noPublicPathWarning lists the required connections between the public internet and endpoints that cannot reach it,
+// or cannot be reached from it: initiating connections requires a public gateway or a floating IP, while accepting
+// connections requires a floating IP. Connections with private external addresses (e.g., on-premises networks) are not checked
+func noPublicPathWarning(spec *ir.Spec) *ir.Diagnostic {
+	var noPath []string
+	for _, conn := range spec.Connections {
+		internalSrc, internalDst, _ := internalConnection(conn)
+		switch {
+		case internalSrc && !internalDst && isPublic(conn.Dst) && !hasPublicPath(conn.Src, spec.Defs.PublicEgress):
+			noPath = append(noPath, conn.Origin.String())
+		case !internalSrc && internalDst && isPublic(conn.Src) && !hasPublicPath(conn.Dst, spec.Defs.PublicIngress):
+			noPath = append(noPath, conn.Origin.String())
+		}
+	}
+	return originsWarning(ir.CodeNoPublicPath, WarningNoPublicPath, noPath)
+}
+
+func isPublic(external *ir.ConnectedResource) bool {
+	for _, remote := range external.CidrsWhenRemote {
+		if !remote.IPAddrs.IsSubset(ir.PrivateAddresses()) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasPublicPath(resource *ir.ConnectedResource, path func(ir.ID) bool) bool {
+	for _, endpoint := range resource.CidrsWhenLocal {
+		if !path(endpoint.Name) {
+			return false
+		}
+	}
+	return true
+}
+
+// joinWarnings returns the non-nil warnings
+func joinWarnings(warnings ...*ir.Diagnostic) ir.Diagnostics {
+	return slices.DeleteFunc(warnings, func(w *ir.Diagnostic) bool { return w == nil })
+}
+
+// originsWarning returns a warning about the connections of the given spec origins, or nil if there are none
+func originsWarning(code, warningPrefix string, origins []string) *ir.Diagnostic {
+	if len(origins) == 0 {
+		return nil
+	}
+	res := ir.NewDiagnostic(ir.SeverityWarning, code, warningPrefix+strings.Join(origins, ", "))
+	res.Origins = origins
+	return res
+}
+
+func setUnspecifiedWarning(code, warningPrefix string, blockedResources []ir.ID) *ir.Diagnostic {
+	if len(blockedResources) == 0 {
+		return nil
 	}
-	return warning
+	return ir.NewDiagnostic(ir.SeverityWarning, code, warningPrefix+strings.Join(blockedResources, ", "), blockedResources...)
 }
@@ -0,0 +1,72 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package synth
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/verify"
+)
+
+// LayeredSynthesizer generates both nACLs and SGs from the same spec, so that traffic is filtered by two layers
+type LayeredSynthesizer struct {
+	sgSpec  *ir.Spec
+	aclSpec *ir.Spec
+	options *Options
+}
+
+// NewLayeredSynthesizer creates and returns a new LayeredSynthesizer instance. The spec is given twice, since its
+// resources are resolved differently for SG synthesis and for nACL synthesis
+func NewLayeredSynthesizer(sgSpec, aclSpec *ir.Spec, options *Options) Synthesizer {
+	return &LayeredSynthesizer{sgSpec: sgSpec, aclSpec: aclSpec, options: options}
+}
+
+// Synth generates the nACLs and the SGs, and checks that each layer allows all the required connections; with coarse
+// nACLs, it also checks that the SGs alone allow no other connections, since the nACLs do not enforce the spec.
+// It returns an error if the check fails, with a diagnostic for each resource that fails it.
+func (l *LayeredSynthesizer) Synth() (ir.Collection, ir.Diagnostics, error) {
+	acls, aclDiagnostics, err := NewACLSynthesizer(l.aclSpec, l.options).Synth()
+	if err != nil {
+		return nil, aclDiagnostics, err
+	}
+	sgs, sgDiagnostics, err := NewSGSynthesizer(l.sgSpec, l.options).Synth()
+	diagnostics := mergeDiagnostics(aclDiagnostics, sgDiagnostics)
+	if err != nil {
+		return nil, diagnostics, err
+	}
+	if inconsistencies, err := l.check(acls, sgs); err != nil {
+		return nil, append(diagnostics, inconsistencies...), err
+	}
+	return &ir.LayeredCollection{ACLs: acls.(*ir.ACLCollection), SGs: sgs.(*ir.SGCollection)}, diagnostics, nil
+}
+
+func (l *LayeredSynthesizer) check(acls, sgs ir.Collection) (ir.Diagnostics, error) {
+	aclReport := verify.NewACLVerifierWithResponses(l.aclSpec, acls, l.options.Responses).Verify()
+	sgReport := verify.NewSGVerifier(l.sgSpec, sgs).Verify()
+	if blocked := aclReport.BlockedCount() + sgReport.BlockedCount(); blocked > 0 {
+		return slices.Concat(aclReport.Diagnostics(), sgReport.Diagnostics()).Filter(ir.SeverityError),
+			fmt.Errorf("the generated nACLs and SGs are inconsistent: %d required connections are blocked by one of the layers", blocked)
+	}
+	if l.options.ACLStrategy == CoarseACLs && !sgReport.Exact() {
+		return sgReport.Diagnostics(), fmt.Errorf("the generated SGs do not enforce the spec, as required with coarse nACLs: "+
+			"%d resources allow connections that are not required", sgReport.ExtraCount())
+	}
+	return nil, nil
+}
+
+// mergeDiagnostics drops the diagnostics of the second list that are already reported by the first, such as the
+// warnings on connections that cannot be routed, which are raised by both syntheses
+func mergeDiagnostics(first, second ir.Diagnostics) ir.Diagnostics {
+	res := slices.Clone(first)
+	for _, d := range second {
+		if !slices.ContainsFunc(first, func(f *ir.Diagnostic) bool { return f.Code == d.Code && f.Message == d.Message }) {
+			res = append(res, d)
+		}
+	}
+	return res
+}
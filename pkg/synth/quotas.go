@@ -0,0 +1,150 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package synth
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/utils"
+)
+
+type (
+	// Quotas are the limits IBM Cloud VPC imposes on nACLs and SGs; resources that exceed them fail on apply
+	Quotas struct {
+		ACLRules     int // rules (inbound and outbound) per nACL
+		SGRules      int // rules (inbound and outbound) per SG
+		SGsPerTarget int // SGs attached to a single NIF or VPE
+	}
+
+	// QuotaError lists the resources that exceed the quotas
+	QuotaError struct {
+		Overflows []*Overflow
+	}
+
+	// Overflow describes a single resource that exceeds a quota
+	Overflow struct {
+		Resource string
+		Count    int
+		Limit    int
+		Unit     string
+
+		// the connections that caused the overflow: the explanations of the rules beyond the limit
+		Connections []string
+	}
+)
+
+const (
+	defaultACLRules     = 200
+	defaultSGRules      = 250
+	defaultSGsPerTarget = 5
+
+	sgPartSeparator = "-part"
+)
+
+// DefaultQuotas returns the default IBM Cloud VPC quotas
+func DefaultQuotas() *Quotas {
+	return &Quotas{ACLRules: defaultACLRules, SGRules: defaultSGRules, SGsPerTarget: defaultSGsPerTarget}
+}
+
+func (q *Quotas) Validate() error {
+	if q.ACLRules <= 0 || q.SGRules <= 0 || q.SGsPerTarget <= 0 {
+		return fmt.Errorf("quotas must be positive, got %d nACL rules, %d SG rules and %d SGs per target",
+			q.ACLRules, q.SGRules, q.SGsPerTarget)
+	}
+	return nil
+}
+
+func (e *QuotaError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d resources exceed the quotas:", len(e.Overflows))
+	for _, o := range e.Overflows {
+		fmt.Fprintf(&sb, "\n%s has %d %s, the limit is %d; connections beyond the limit:", o.Resource, o.Count, o.Unit, o.Limit)
+		for _, conn := range o.Connections {
+			fmt.Fprintf(&sb, "\n\t%s", conn)
+		}
+	}
+	return sb.String()
+}
+
+// checkACLQuotas returns a QuotaError if an nACL has more rules than allowed; nACLs cannot be split, since a subnet
+// is attached to a single nACL
+func checkACLQuotas(collection *ir.ACLCollection, quotas *Quotas) error {
+	res := &QuotaError{}
+	for _, vpc := range utils.SortedMapKeys(collection.ACLs) {
+		for _, aclName := range utils.SortedMapKeys(collection.ACLs[vpc]) {
+			rules := collection.ACLs[vpc][aclName].Rules()
+			if len(rules) <= quotas.ACLRules {
+				continue
+			}
+			explanations := make([]string, len(rules)-quotas.ACLRules)
+			for i, rule := range rules[quotas.ACLRules:] {
+				explanations[i] = rule.Explanation
+			}
+			res.Overflows = append(res.Overflows, newOverflow(aclName, len(rules), quotas.ACLRules, "nACL rules", explanations))
+		}
+	}
+	if len(res.Overflows) > 0 {
+		return res
+	}
+	return nil
+}
+
+// splitSGs splits each SG with more rules than allowed into several SGs with the same targets. The first part keeps the
+// name of the SG, so remote SG references still stand for the same targets. Returns a QuotaError if a target would
+// have more SGs attached than allowed.
+func splitSGs(collection *ir.SGCollection, quotas *Quotas) error {
+	res := &QuotaError{}
+	for _, vpc := range utils.SortedMapKeys(collection.SGs) {
+		for _, sgName := range utils.SortedMapKeys(collection.SGs[vpc]) {
+			sg := collection.SGs[vpc][sgName]
+			rules := sg.AllRules()
+			if len(rules) <= quotas.SGRules {
+				continue
+			}
+			parts := (len(rules) + quotas.SGRules - 1) / quotas.SGRules
+			if parts > quotas.SGsPerTarget {
+				explanations := make([]string, len(rules)-quotas.SGRules*quotas.SGsPerTarget)
+				for i, rule := range rules[quotas.SGRules*quotas.SGsPerTarget:] {
+					explanations[i] = rule.Explanation
+				}
+				res.Overflows = append(res.Overflows, newOverflow(string(sgName), parts, quotas.SGsPerTarget,
+					fmt.Sprintf("SGs of up to %d rules", quotas.SGRules), explanations))
+				continue
+			}
+			for i := range parts {
+				part := ir.NewSG(sgName)
+				if i > 0 {
+					part = collection.LookupOrCreate(ir.SGName(fmt.Sprintf("%s%s%d", sgName, sgPartSeparator, i+1)))
+				}
+				part.Targets = sg.Targets
+				for _, rule := range rules[i*quotas.SGRules : min((i+1)*quotas.SGRules, len(rules))] {
+					part.Add(rule)
+				}
+				if i == 0 {
+					collection.SGs[vpc][sgName] = part
+				}
+			}
+		}
+	}
+	if len(res.Overflows) > 0 {
+		return res
+	}
+	return nil
+}
+
+// newOverflow lists each connection that caused the overflow once, in the order of the rules
+func newOverflow(resource string, count, limit int, unit string, explanations []string) *Overflow {
+	connections := []string{}
+	for _, e := range explanations {
+		if !slices.Contains(connections, e) {
+			connections = append(connections, e)
+		}
+	}
+	return &Overflow{Resource: resource, Count: count, Limit: limit, Unit: unit, Connections: connections}
+}
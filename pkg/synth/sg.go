@@ -9,38 +9,55 @@ import (
 	"slices"
 
 	"github.com/np-guard/models/pkg/netp"
-	"github.com/np-guard/models/pkg/netset"
 
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ipset"
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
+	sgoptimizer "github.com/np-guard/vpc-network-config-synthesis/pkg/optimize/sg"
 	"github.com/np-guard/vpc-network-config-synthesis/pkg/utils"
 )
 
 type SGSynthesizer struct {
-	spec   *ir.Spec
-	result *ir.SGCollection
+	spec     *ir.Spec
+	optimize bool
+	quotas   *Quotas
+	result   *ir.SGCollection
 }
 
 const WarningUnspecifiedSG = "The following endpoints do not have required connections; the generated SGs will block all traffic: "
 
 // NewSGSynthesizer creates and returns a new SGSynthesizer instance
-func NewSGSynthesizer(s *ir.Spec, _ bool) Synthesizer {
-	return &SGSynthesizer{spec: s, result: ir.NewSGCollection()}
+func NewSGSynthesizer(s *ir.Spec, options *Options) Synthesizer {
+	return &SGSynthesizer{spec: s, optimize: options.Optimize, quotas: options.Quotas, result: ir.NewSGCollection()}
 }
 
-func (s *SGSynthesizer) Synth() (collection ir.Collection, warning string) {
-	return s.makeSG()
+// Synth splits SGs that have more rules than the quota allows, and returns an error listing the SGs
+// that cannot be split without exceeding the number of SGs per target
+func (s *SGSynthesizer) Synth() (collection ir.Collection, diagnostics ir.Diagnostics, err error) {
+	collection, diagnostics = s.makeSG()
+	if s.optimize {
+		var optimizerDiagnostics ir.Diagnostics
+		if collection, optimizerDiagnostics, err = sgoptimizer.NewSGOptimizer(collection, "").Optimize(); err != nil {
+			return nil, diagnostics, err
+		}
+		diagnostics = append(diagnostics, optimizerDiagnostics...)
+	}
+	if err = splitSGs(s.result, s.quotas); err != nil {
+		return nil, diagnostics, err
+	}
+	return collection, diagnostics, nil
 }
 
 // this method translates spec to a collection of Security Groups
 // 1. generate SGs for relevant endpoints for each connection
 // 2. generate SGs for blocked endpoints (endpoints that do not appear in Spec)
-func (s *SGSynthesizer) makeSG() (collection *ir.SGCollection, warning string) {
+func (s *SGSynthesizer) makeSG() (collection *ir.SGCollection, warnings ir.Diagnostics) {
 	for _, conn := range s.spec.Connections {
 		s.generateSGRulesFromConnection(conn, ir.Outbound)
 		s.generateSGRulesFromConnection(conn, ir.Inbound)
 	}
-	warning = s.generateSGsForBlockedResources()
-	return s.result, warning
+	warnings = slices.Concat(s.spec.Diagnostics,
+		joinWarnings(unroutableConnectionsWarning(s.spec), noPublicPathWarning(s.spec), s.generateSGsForBlockedResources()))
+	return s.result, warnings
 }
 
 func (s *SGSynthesizer) generateSGRulesFromConnection(conn *ir.Connection, direction ir.Direction) {
@@ -49,7 +66,7 @@ func (s *SGSynthesizer) generateSGRulesFromConnection(conn *ir.Connection, direc
 	for _, localEndpoint := range localResource.CidrsWhenLocal {
 		for _, remoteCidr := range remoteResource.CidrsWhenRemote {
 			for _, trackedProtocol := range conn.TrackedProtocols {
-				ruleExplanation := explanation{internal: internalConn, connectionOrigin: conn.Origin, protocolOrigin: trackedProtocol.Origin}.String()
+				ruleExplanation := explanation{internal: internalConn, connectionOrigin: conn.Origin, protocolOrigin: trackedProtocol.Origin}
 				s.allowConnectionEndpoint(localEndpoint, remoteCidr, remoteResource.ResourceType, trackedProtocol.Protocol, direction,
 					internalEndpoint, ruleExplanation)
 			}
@@ -59,21 +76,42 @@ func (s *SGSynthesizer) generateSGRulesFromConnection(conn *ir.Connection, direc
 
 // if the endpoint in internal, a rule will be created to allow traffic.
 func (s *SGSynthesizer) allowConnectionEndpoint(localEndpoint, remoteEndpoint *ir.NamedAddrs, remoteType ir.ResourceType,
-	p netp.Protocol, direction ir.Direction, internalEndpoint bool, ruleExplanation string) {
+	p netp.Protocol, direction ir.Direction, internalEndpoint bool, ruleExplanation explanation) {
 	if !internalEndpoint {
 		return
 	}
 	localSGName := ir.SGName(localEndpoint.Name)
 	localSG := s.result.LookupOrCreate(localSGName)
 	localSG.Targets = []ir.ID{ir.ID(localSGName)}
-	localSG.Add(ir.NewSGRule(direction, sgRemote(remoteEndpoint, remoteType), p, netset.GetCidrAll(), ruleExplanation))
+	for _, all := range s.ipVersions(localEndpoint, remoteEndpoint, remoteType) {
+		local := all
+		if localEndpoint.IPAddrs != nil { // a NIF of an instance with several NIFs
+			local = localEndpoint.IPAddrs.Intersect(all)
+		}
+		for _, localCidr := range local.SplitToCidrs() {
+			rule := ir.NewSGRule(direction, sgRemote(remoteEndpoint, remoteType, all), p, localCidr, ruleExplanation.String())
+			rule.Origins = ruleExplanation.origins()
+			localSG.Add(rule)
+		}
+	}
+}
+
+// ipVersions returns the address space of each IP version of both the local and the remote endpoints (0.0.0.0/0 and/or
+// ::/0), since an SG rule is of a single IP version
+func (s *SGSynthesizer) ipVersions(localEndpoint, remoteEndpoint *ir.NamedAddrs, remoteType ir.ResourceType) []*ipset.IPBlock {
+	remoteAddrs := remoteEndpoint.IPAddrs
+	if isSGRemote(remoteType) {
+		remoteAddrs = s.spec.Defs.LocalAddrs(remoteEndpoint)
+	}
+	return ipset.CidrAllOf(s.spec.Defs.LocalAddrs(localEndpoint)).Intersect(ipset.CidrAllOf(remoteAddrs)).SplitByIPVersion()
 }
 
-func sgRemote(resource *ir.NamedAddrs, t ir.ResourceType) ir.RemoteType {
+// sgRemote returns the remote of an SG rule of the IP version whose address space is all
+func sgRemote(resource *ir.NamedAddrs, t ir.ResourceType, all *ipset.IPBlock) ir.RemoteType {
 	if isSGRemote(t) {
 		return ir.SGName(resource.Name)
 	}
-	return resource.IPAddrs
+	return resource.IPAddrs.Intersect(all)
 }
 
 func connSettings(conn *ir.Connection, direction ir.Direction) (local, remote *ir.ConnectedResource, internalEndpoint, internalConn bool) {
@@ -90,15 +128,16 @@ func connSettings(conn *ir.Connection, direction ir.Direction) (local, remote *i
 }
 
 func isSGRemote(t ir.ResourceType) bool {
-	return t == ir.ResourceTypeInstance || t == ir.ResourceTypeNIF || t == ir.ResourceTypeVPE
+	return t == ir.ResourceTypeInstance || t == ir.ResourceTypeNIF || t == ir.ResourceTypeVPE || t == ir.ResourceTypeLoadBalancer
 }
 
 // generate SGs for blocked endpoints (endpoints that do not appear in Spec)
-func (s *SGSynthesizer) generateSGsForBlockedResources() string {
-	blockedResources := slices.Concat(utils.TrueKeyValues(s.spec.BlockedInstances), utils.TrueKeyValues(s.spec.BlockedVPEs))
+func (s *SGSynthesizer) generateSGsForBlockedResources() *ir.Diagnostic {
+	blockedResources := slices.Concat(utils.TrueKeyValues(s.spec.BlockedInstances), utils.TrueKeyValues(s.spec.BlockedVPEs),
+		utils.TrueKeyValues(s.spec.BlockedLoadBalancers))
 	for _, resource := range blockedResources {
 		sg := s.result.LookupOrCreate(ir.SGName(resource)) // an empty SG allows no connections
 		sg.Targets = []ir.ID{resource}
 	}
-	return setUnspecifiedWarning(WarningUnspecifiedSG, blockedResources)
+	return setUnspecifiedWarning(ir.CodeUnspecifiedSG, WarningUnspecifiedSG, blockedResources)
 }
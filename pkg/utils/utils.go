@@ -33,8 +33,8 @@ func SortedAllInnerMapsKeys[T, K cmp.Ordered, V any](m map[K]map[T]V) []T {
 }
 
 // GetProperty returns pointer p if it is valid, else it returns the provided default value
-// used to get min/max port or icmp type
-func GetProperty(p *int64, defaultP int64) int64 {
+// used to get min/max port, icmp type or resource ids
+func GetProperty[T any](p *T, defaultP T) T {
 	if p == nil {
 		return defaultP
 	}
@@ -0,0 +1,168 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verify
+
+import (
+	"github.com/np-guard/models/pkg/netp"
+	"github.com/np-guard/models/pkg/netset"
+
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ipset"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/optimize"
+	acloptimizer "github.com/np-guard/vpc-network-config-synthesis/pkg/optimize/acl"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/utils"
+)
+
+type ACLVerifier struct {
+	spec       *ir.Spec
+	collection *ir.ACLCollection
+	responses  *ir.ResponsePolicies
+
+	// required connections per subnet
+	required map[ir.ID][]*requirement
+
+	// forbidden connections per subnet and direction, which synthesized nACLs deny before any required connection
+	forbidden map[ir.ID]map[ir.Direction]*ipset.EndpointsTrafficSet
+}
+
+// NewACLVerifier creates and returns a new ACLVerifier instance, requiring the responses of the default policies
+func NewACLVerifier(s *ir.Spec, collection ir.Collection) Verifier {
+	return NewACLVerifierWithResponses(s, collection, nil)
+}
+
+// NewACLVerifierWithResponses creates and returns a new ACLVerifier instance, requiring the responses that nACLs
+// synthesized with the given response policies allow
+func NewACLVerifierWithResponses(s *ir.Spec, collection ir.Collection, responses *ir.ResponsePolicies) Verifier {
+	return &ACLVerifier{spec: s, collection: collection.(*ir.ACLCollection), responses: responses, required: map[ir.ID][]*requirement{},
+		forbidden: map[ir.ID]map[ir.Direction]*ipset.EndpointsTrafficSet{}}
+}
+
+// Verify checks, for each subnet, the nACL attached to it against the required connections.
+// As in nACL synthesis, a required connection of a resource inside a subnet is required for the whole subnet,
+// and the response of a required connection must be allowed by the nACLs as well.
+// Connections that are forbidden by the spec are not required.
+func (a *ACLVerifier) Verify() *Report {
+	for _, conn := range a.spec.Forbidden {
+		a.forbiddenFromConnection(conn, conn.Src, conn.Dst, ir.Outbound)
+		a.forbiddenFromConnection(conn, conn.Dst, conn.Src, ir.Inbound)
+	}
+	for _, conn := range a.spec.Connections {
+		a.requiredFromConnection(conn, conn.Src, conn.Dst, ir.Outbound)
+		a.requiredFromConnection(conn, conn.Dst, conn.Src, ir.Inbound)
+	}
+
+	report := &Report{}
+	for _, subnet := range utils.SortedMapKeys(a.spec.Defs.Subnets) {
+		subnetCidr := a.spec.Defs.Subnets[subnet].CIDR
+		firewalls := []string{}
+		allowed := map[ir.Direction]*ipset.EndpointsTrafficSet{
+			ir.Inbound:  ipset.EmptyEndpointsTrafficSet(),
+			ir.Outbound: ipset.EmptyEndpointsTrafficSet(),
+		}
+		if acl := a.collection.AttachedACL(subnet, a.spec.Defs.Subnets[subnet].NetworkACL); acl != nil {
+			firewalls = append(firewalls, scopedFirewallName(ir.VpcFromScopedResource(subnet), acl.Name))
+			allowed[ir.Inbound] = subnetConns(acloptimizer.AllowedConnections(acl.DirectionRules(ir.Inbound)), subnetCidr, ir.Inbound)
+			allowed[ir.Outbound] = subnetConns(acloptimizer.AllowedConnections(acl.DirectionRules(ir.Outbound)), subnetCidr, ir.Outbound)
+		}
+		report.Results = append(report.Results, check(subnet, firewalls, a.required[subnet], allowed))
+	}
+	return report
+}
+
+// requiredFromConnection mirrors the rules nACL synthesis would generate for the local side of the connection
+func (a *ACLVerifier) requiredFromConnection(conn *ir.Connection, localResource, remoteResource *ir.ConnectedResource,
+	direction ir.Direction) {
+	if localResource.ResourceType == ir.ResourceTypeExternal {
+		return
+	}
+	internal := remoteResource.ResourceType != ir.ResourceTypeExternal
+	for _, localSubnet := range localResource.CidrsWhenLocal {
+		for _, remoteCidr := range remoteResource.CidrsWhenRemote {
+			if localSubnet.IPAddrs.Equal(remoteCidr.IPAddrs) {
+				continue
+			}
+			for _, trackedProtocol := range conn.TrackedProtocols {
+				reason := explanation{connectionOrigin: conn.Origin, protocolOrigin: trackedProtocol.Origin}
+				a.addRequirement(localSubnet, remoteCidr.IPAddrs, direction, trackedProtocol.Protocol, reason, internal)
+				if inverseProtocol := a.responses.Response(trackedProtocol.Protocol); inverseProtocol != nil {
+					a.addRequirement(localSubnet, remoteCidr.IPAddrs, oppositeDirection(direction), inverseProtocol, reason.response(), internal)
+				}
+			}
+		}
+	}
+}
+
+// forbiddenFromConnection mirrors the deny rules nACL synthesis would generate for the local side of the connection
+func (a *ACLVerifier) forbiddenFromConnection(conn *ir.Connection, localResource, remoteResource *ir.ConnectedResource,
+	direction ir.Direction) {
+	if localResource.ResourceType == ir.ResourceTypeExternal {
+		return
+	}
+	for _, localSubnet := range localResource.CidrsWhenLocal {
+		if _, ok := a.forbidden[localSubnet.Name]; !ok {
+			a.forbidden[localSubnet.Name] = map[ir.Direction]*ipset.EndpointsTrafficSet{
+				ir.Inbound:  ipset.EmptyEndpointsTrafficSet(),
+				ir.Outbound: ipset.EmptyEndpointsTrafficSet(),
+			}
+		}
+		for _, remoteCidr := range remoteResource.CidrsWhenRemote {
+			for _, trackedProtocol := range conn.TrackedProtocols {
+				src, dst := localSubnet.IPAddrs, remoteCidr.IPAddrs
+				if direction == ir.Inbound {
+					src, dst = remoteCidr.IPAddrs, localSubnet.IPAddrs
+				}
+				forbidden := ipset.NewEndpointsTrafficSet(src, dst, optimize.ProtocolToTransportSet(trackedProtocol.Protocol))
+				a.forbidden[localSubnet.Name][direction] = a.forbidden[localSubnet.Name][direction].Union(forbidden)
+			}
+		}
+	}
+}
+
+// a required connection with an external resource does not include communication with the internal address space of the
+// VPC, which synthesized nACLs deny
+func (a *ACLVerifier) addRequirement(localSubnet *ir.NamedAddrs, remote *ipset.IPBlock, direction ir.Direction,
+	p netp.Protocol, reason explanation, internal bool) {
+	src, dst := localSubnet.IPAddrs, remote
+	if direction == ir.Inbound {
+		src, dst = remote, localSubnet.IPAddrs
+	}
+	r := newRequirement(direction, src, dst, p, reason)
+	r.conns = subnetConns(r.conns, a.spec.Defs.Subnets[localSubnet.Name].CIDR, direction)
+	if !internal {
+		internalAddrs := a.spec.Defs.InternalAddrs(ir.VpcFromScopedResource(localSubnet.Name))
+		all := ipset.CidrAllOf(internalAddrs)
+		denied := ipset.NewEndpointsTrafficSet(all, internalAddrs, netset.AllTransports())
+		if direction == ir.Inbound {
+			denied = ipset.NewEndpointsTrafficSet(internalAddrs, all, netset.AllTransports())
+		}
+		r.conns = r.conns.Subtract(denied)
+	}
+	if forbidden, ok := a.forbidden[localSubnet.Name]; ok {
+		r.conns = r.conns.Subtract(forbidden[direction])
+	}
+	if !r.conns.IsEmpty() {
+		a.required[localSubnet.Name] = append(a.required[localSubnet.Name], r)
+	}
+}
+
+// subnetConns returns the connections that are filtered by the nACL of the subnet in the given direction.
+// traffic within the subnet is never filtered by its nACL.
+func subnetConns(conns *ipset.EndpointsTrafficSet, subnetCidr *ipset.IPBlock, direction ir.Direction) *ipset.EndpointsTrafficSet {
+	all := ipset.CidrAllOf(subnetCidr)
+	relevant := ipset.NewEndpointsTrafficSet(subnetCidr, all, netset.AllTransports())
+	if direction == ir.Inbound {
+		relevant = ipset.NewEndpointsTrafficSet(all, subnetCidr, netset.AllTransports())
+	}
+	internal := ipset.NewEndpointsTrafficSet(subnetCidr, subnetCidr, netset.AllTransports())
+	return conns.Intersect(relevant).Subtract(internal)
+}
+
+func oppositeDirection(direction ir.Direction) ir.Direction {
+	if direction == ir.Inbound {
+		return ir.Outbound
+	}
+	return ir.Inbound
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package verify checks existing Network ACLs and Security Groups against the connectivity described in a global specification.
+package verify
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/np-guard/models/pkg/netp"
+
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ipset"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/optimize"
+)
+
+type (
+	Verifier interface {
+		Verify() *Report
+	}
+
+	// Report holds the verification result of each checked resource (subnets for nACLs, endpoints for SGs)
+	Report struct {
+		Results []*Result
+	}
+
+	Result struct {
+		Resource  ir.ID
+		Firewalls []string
+
+		// required connections that are not allowed by the firewalls
+		Blocked []*BlockedConnection
+
+		// connections allowed by the firewalls that are not required by the spec
+		Extra map[ir.Direction]*ipset.EndpointsTrafficSet
+	}
+
+	BlockedConnection struct {
+		Direction   ir.Direction
+		Missing     *ipset.EndpointsTrafficSet
+		Explanation string
+	}
+
+	requirement struct {
+		direction   ir.Direction
+		conns       *ipset.EndpointsTrafficSet
+		explanation string
+	}
+
+	explanation struct {
+		isResponse       bool
+		connectionOrigin fmt.Stringer
+		protocolOrigin   fmt.Stringer
+	}
+)
+
+const noFirewalls = "none"
+
+var directions = []ir.Direction{ir.Inbound, ir.Outbound}
+
+func (e explanation) response() explanation {
+	e.isResponse = true
+	return e
+}
+
+func (e explanation) String() string {
+	result := fmt.Sprintf("%v; %v", e.connectionOrigin, e.protocolOrigin)
+	if e.isResponse {
+		result = fmt.Sprintf("response to %v", result)
+	}
+	return result
+}
+
+func newRequirement(direction ir.Direction, src, dst *ipset.IPBlock, p netp.Protocol, e explanation) *requirement {
+	return &requirement{direction: direction,
+		conns:       ipset.NewEndpointsTrafficSet(src, dst, optimize.ProtocolToTransportSet(p)),
+		explanation: e.String(),
+	}
+}
+
+// check compares the connections required for a resource with the connections its firewalls allow
+func check(resource ir.ID, firewalls []string, required []*requirement, allowed map[ir.Direction]*ipset.EndpointsTrafficSet) *Result {
+	res := &Result{Resource: resource, Firewalls: firewalls, Extra: map[ir.Direction]*ipset.EndpointsTrafficSet{}}
+	requiredConns := map[ir.Direction]*ipset.EndpointsTrafficSet{}
+	for _, d := range directions {
+		requiredConns[d] = ipset.EmptyEndpointsTrafficSet()
+	}
+	for _, r := range required {
+		requiredConns[r.direction] = requiredConns[r.direction].Union(r.conns)
+		if missing := r.conns.Subtract(allowed[r.direction]); !missing.IsEmpty() {
+			res.Blocked = append(res.Blocked, &BlockedConnection{Direction: r.direction, Missing: missing, Explanation: r.explanation})
+		}
+	}
+	for _, d := range directions {
+		res.Extra[d] = allowed[d].Subtract(requiredConns[d])
+	}
+	return res
+}
+
+// scopedFirewallName returns the name of an SG or an nACL scoped by its VPC; the names of synthesized SGs and nACLs are
+// already scoped
+func scopedFirewallName(vpc ir.ID, name string) string {
+	if strings.HasPrefix(name, vpc+"/") {
+		return name
+	}
+	return vpc + "/" + name
+}
+
+func (r *Result) hasExtra() bool {
+	for _, d := range directions {
+		if !r.Extra[d].IsEmpty() {
+			return true
+		}
+	}
+	return false
+}
+
+// BlockedCount returns the number of required connections that are (at least partially) blocked
+func (r *Report) BlockedCount() int {
+	res := 0
+	for _, result := range r.Results {
+		res += len(result.Blocked)
+	}
+	return res
+}
+
+// ExtraCount returns the number of resources whose firewalls allow connections that are not required
+func (r *Report) ExtraCount() int {
+	res := 0
+	for _, result := range r.Results {
+		if result.hasExtra() {
+			res++
+		}
+	}
+	return res
+}
+
+// Exact returns true if the firewalls allow all the required connections, and no other connections
+func (r *Report) Exact() bool {
+	return r.BlockedCount() == 0 && r.ExtraCount() == 0
+}
+
+// Diagnostics returns an error for each resource whose firewalls block required connections, with the spec elements
+// that require them, and a warning for each resource whose firewalls allow connections that are not required
+func (r *Report) Diagnostics() ir.Diagnostics {
+	var res ir.Diagnostics
+	for _, result := range r.Results {
+		resources := append([]ir.ID{result.Resource}, result.Firewalls...)
+		if len(result.Blocked) > 0 {
+			blocked := ir.NewDiagnostic(ir.SeverityError, ir.CodeBlockedConnections,
+				fmt.Sprintf("%s: %d required connections are blocked", result.Resource, len(result.Blocked)), resources...)
+			for _, b := range result.Blocked {
+				blocked.Origins = append(blocked.Origins, b.Explanation)
+			}
+			res = append(res, blocked)
+		}
+		if result.hasExtra() {
+			res = append(res, ir.NewDiagnostic(ir.SeverityWarning, ir.CodeExtraConnections,
+				fmt.Sprintf("%s: connections that are not required are allowed", result.Resource), resources...))
+		}
+	}
+	return res
+}
+
+func (r *Report) Summary() string {
+	return fmt.Sprintf("%d required connections are blocked; %d resources allow connections that are not required",
+		r.BlockedCount(), r.ExtraCount())
+}
+
+func (r *Report) String() string {
+	var sb strings.Builder
+	for _, result := range r.Results {
+		if len(result.Blocked) == 0 && !result.hasExtra() {
+			continue
+		}
+		firewalls := noFirewalls
+		if len(result.Firewalls) > 0 {
+			firewalls = strings.Join(result.Firewalls, ", ")
+		}
+		fmt.Fprintf(&sb, "%s [%s]:\n", result.Resource, firewalls)
+		for _, blocked := range result.Blocked {
+			fmt.Fprintf(&sb, "\tblocked %s connections, required by %s:\n", blocked.Direction, blocked.Explanation)
+			sb.WriteString(blocked.Missing.Lines("\t\t"))
+		}
+		for _, d := range directions {
+			if !result.Extra[d].IsEmpty() {
+				fmt.Fprintf(&sb, "\textra %s connections:\n", d)
+				sb.WriteString(result.Extra[d].Lines("\t\t"))
+			}
+		}
+	}
+	if sb.Len() == 0 {
+		return "All required connections are allowed, and no other connections are allowed\n"
+	}
+	return sb.String()
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verify
+
+import (
+	"slices"
+
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ipset"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/optimize"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/utils"
+)
+
+type (
+	SGVerifier struct {
+		spec       *ir.Spec
+		collection *ir.SGCollection
+
+		// required connections per endpoint (instance, VPE or load balancer)
+		required map[ir.ID][]*requirement
+
+		// IP addresses of the NIFs/reserved IPs, per VPC and per the name used in SG targets
+		targetIPs map[ir.ID]map[string]*ipset.IPBlock
+	}
+
+	// a NIF of an instance, a reserved IP of a VPE or a private IP of a load balancer
+	member struct {
+		vpc        ir.ID
+		targetName string
+		endpoint   ir.ID // the target of synthesized SGs
+		ip         *ipset.IPBlock
+	}
+)
+
+// NewSGVerifier creates and returns a new SGVerifier instance
+func NewSGVerifier(s *ir.Spec, collection ir.Collection) Verifier {
+	return &SGVerifier{spec: s, collection: collection.(*ir.SGCollection), required: map[ir.ID][]*requirement{}}
+}
+
+// Verify checks, for each instance, VPE and load balancer, the SGs attached to it against the required connections.
+// SGs are stateful, therefore responses are not checked.
+func (s *SGVerifier) Verify() *Report {
+	endpoints := slices.Concat(utils.SortedMapKeys(s.spec.Defs.Instances), utils.SortedMapKeys(s.spec.Defs.VPEs),
+		utils.SortedMapKeys(s.spec.Defs.LoadBalancers))
+	s.targetIPs = s.spec.Defs.SGTargetIPs()
+	for _, endpoint := range endpoints { // synthesized SGs are attached to the scoped names of the endpoints
+		vpc := ir.VpcFromScopedResource(endpoint)
+		if s.targetIPs[vpc] == nil {
+			s.targetIPs[vpc] = map[string]*ipset.IPBlock{}
+		}
+		s.targetIPs[vpc][endpoint] = s.endpointIPs(endpoint)
+	}
+	for _, conn := range s.spec.Connections {
+		s.requiredFromConnection(conn, conn.Src, conn.Dst, ir.Outbound)
+		s.requiredFromConnection(conn, conn.Dst, conn.Src, ir.Inbound)
+	}
+
+	report := &Report{}
+	for _, endpoint := range endpoints {
+		firewalls := []string{}
+		allowed := map[ir.Direction]*ipset.EndpointsTrafficSet{
+			ir.Inbound:  ipset.EmptyEndpointsTrafficSet(),
+			ir.Outbound: ipset.EmptyEndpointsTrafficSet(),
+		}
+		for _, m := range s.members(endpoint) {
+			attached := slices.Concat(s.collection.AttachedSGs(m.vpc, m.targetName), s.collection.AttachedSGs(m.vpc, m.endpoint))
+			for _, sg := range attached {
+				firewalls = append(firewalls, scopedFirewallName(m.vpc, string(sg.SGName)))
+				for _, d := range directions {
+					allowed[d] = allowed[d].Union(s.allowedConnections(sg, m, d))
+				}
+			}
+		}
+		firewalls = slices.Compact(slices.Sorted(slices.Values(firewalls)))
+		report.Results = append(report.Results, check(endpoint, firewalls, s.required[endpoint], allowed))
+	}
+	return report
+}
+
+// requiredFromConnection mirrors the rules SG synthesis would generate for the local side of the connection
+func (s *SGVerifier) requiredFromConnection(conn *ir.Connection, localResource, remoteResource *ir.ConnectedResource,
+	direction ir.Direction) {
+	if localResource.ResourceType == ir.ResourceTypeExternal {
+		return
+	}
+	for _, localEndpoint := range localResource.CidrsWhenLocal {
+		localIPs := s.endpointIPs(localEndpoint.Name)
+		if localEndpoint.IPAddrs != nil { // a NIF of an instance with several NIFs
+			localIPs = localEndpoint.IPAddrs
+		}
+		for _, remoteCidr := range remoteResource.CidrsWhenRemote {
+			remoteIPs := remoteCidr.IPAddrs
+			if isNamedEndpoint(remoteResource.ResourceType) {
+				remoteIPs = s.endpointIPs(remoteCidr.Name)
+			}
+			src, dst := localIPs, remoteIPs
+			if direction == ir.Inbound {
+				src, dst = remoteIPs, localIPs
+			}
+			for _, trackedProtocol := range conn.TrackedProtocols {
+				reason := explanation{connectionOrigin: conn.Origin, protocolOrigin: trackedProtocol.Origin}
+				s.required[localEndpoint.Name] = append(s.required[localEndpoint.Name],
+					newRequirement(direction, src, dst, trackedProtocol.Protocol, reason))
+			}
+		}
+	}
+}
+
+// allowedConnections returns the connections that the given SG allows for the given NIF/reserved IP
+func (s *SGVerifier) allowedConnections(sg *ir.SG, m *member, direction ir.Direction) *ipset.EndpointsTrafficSet {
+	rules := sg.InboundRules
+	if direction == ir.Outbound {
+		rules = sg.OutboundRules
+	}
+	res := ipset.EmptyEndpointsTrafficSet()
+	for _, local := range utils.SortedMapKeys(rules) {
+		for _, rule := range rules[local] {
+			// a rule applies to the addresses of the member in its local field, e.g. to a secondary IP of a NIF only
+			localIPs := m.ip.Intersect(rule.Local)
+			if localIPs.IsEmpty() {
+				continue
+			}
+			remote := s.collection.RemoteIPs(rule.Remote, m.vpc, s.targetIPs)
+			src, dst := localIPs, remote
+			if direction == ir.Inbound {
+				src, dst = remote, localIPs
+			}
+			res = res.Union(ipset.NewEndpointsTrafficSet(src, dst, optimize.ProtocolToTransportSet(rule.Protocol)))
+		}
+	}
+	return res
+}
+
+// members returns the NIFs of an instance, the reserved IPs of a VPE or the private IPs of a load balancer
+func (s *SGVerifier) members(endpoint ir.ID) []*member {
+	vpc := ir.VpcFromScopedResource(endpoint)
+	res := make([]*member, 0)
+	if instance, ok := s.spec.Defs.Instances[endpoint]; ok {
+		for _, nif := range instance.Nifs {
+			res = append(res, &member{vpc: vpc, targetName: ir.UnscopedName(nif), endpoint: endpoint, ip: s.spec.Defs.NIFs[nif].Address()})
+		}
+	}
+	if vpe, ok := s.spec.Defs.VPEs[endpoint]; ok {
+		for _, reservedIP := range vpe.VPEReservedIPs {
+			res = append(res, &member{vpc: vpc, targetName: ir.UnscopedName(endpoint), endpoint: endpoint,
+				ip: s.spec.Defs.VPEReservedIPs[reservedIP].IP})
+		}
+	}
+	if lb, ok := s.spec.Defs.LoadBalancers[endpoint]; ok {
+		for _, privateIP := range lb.PrivateIPs {
+			res = append(res, &member{vpc: vpc, targetName: ir.UnscopedName(endpoint), endpoint: endpoint,
+				ip: s.spec.Defs.LoadBalancerIPs[privateIP].IP})
+		}
+	}
+	return res
+}
+
+func (s *SGVerifier) endpointIPs(endpoint ir.ID) *ipset.IPBlock {
+	res := ipset.NewIPBlock()
+	for _, m := range s.members(endpoint) {
+		res = res.Union(m.ip)
+	}
+	return res
+}
+
+func isNamedEndpoint(t ir.ResourceType) bool {
+	return t == ir.ResourceTypeInstance || t == ir.ResourceTypeNIF || t == ir.ResourceTypeVPE || t == ir.ResourceTypeLoadBalancer
+}
@@ -0,0 +1,275 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package vpcgen is the Go API of vpcgen, for tools that embed the synthesis, optimization and verification of SGs and
+// nACLs. Inputs are passed as bytes and results are returned rather than printed; the functions keep no global state,
+// so they may be called concurrently.
+package vpcgen
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/io"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/io/confio"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/io/jsonio"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/io/tfio"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/io/tfstateio"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ipset"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/optimize"
+	aclOptimizer "github.com/np-guard/vpc-network-config-synthesis/pkg/optimize/acl"
+	sgOptimizer "github.com/np-guard/vpc-network-config-synthesis/pkg/optimize/sg"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/synth"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/utils"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/verify"
+)
+
+// Output formats accepted by Write
+const (
+	TFOutputFormat   = "tf"
+	CSVOutputFormat  = "csv"
+	MDOutputFormat   = "md"
+	JSONOutputFormat = "json"
+)
+
+type (
+	// Options configure the API calls; the zero value gives the defaults of the vpcgen CLI
+	Options struct {
+		SpecFormat    string               // one of jsonio.SpecFormats (default: JSON)
+		SingleACL     bool                 // generate a single nACL per VPC (nACL and layered synthesis only)
+		ACLStrategy   synth.ACLStrategy    // how precise the nACL rules are (default: synth.FineACLs)
+		Responses     *ir.ResponsePolicies // the responses that nACLs allow (default: exact TCP responses, no UDP responses)
+		Optimize      bool                 // optimize the synthesized rules
+		Quotas        *synth.Quotas        // if nil, synth.DefaultQuotas() are used
+		InternalCidrs *ipset.IPBlock       // if set, overrides the internal address space of the config
+		FirewallName  string               // the only SG/nACL to optimize (default: all of them)
+	}
+
+	// Result is a generated collection, with the VPCs it covers and the diagnostics raised while generating it
+	Result struct {
+		Collection  ir.Collection
+		VPCs        []string
+		Diagnostics ir.Diagnostics
+	}
+)
+
+// ReadDefs reads the definitions of either a config object or the output of `terraform show -json`
+func ReadDefs(config []byte) (*ir.ConfigDefs, error) {
+	if tfstateio.IsStateBytes(config) {
+		return tfstateio.ReadDefsFromBytes(config)
+	}
+	return confio.ReadDefsFromBytes(config)
+}
+
+// ReadCollection reads the SGs (if isSG) or the nACLs of either a config object or the output of `terraform show -json`
+func ReadCollection(config []byte, isSG bool) (ir.Collection, ir.Diagnostics, error) {
+	if tfstateio.IsStateBytes(config) {
+		if isSG {
+			return tfstateio.ReadSGsFromBytes(config)
+		}
+		return tfstateio.ReadACLsFromBytes(config)
+	}
+	if isSG {
+		return confio.ReadSGsFromBytes(config)
+	}
+	return confio.ReadACLsFromBytes(config)
+}
+
+// ReadSpec reads a connectivity spec, resolving its resources against the given config
+func ReadSpec(config, spec []byte, opts *Options, isSG bool) (*ir.Spec, error) {
+	opts = withDefaults(opts)
+	return readSpec(config, spec, opts, jsonio.NewReaderWithFormat(opts.SpecFormat), isSG)
+}
+
+func readSpec(config, spec []byte, opts *Options, reader *jsonio.Reader, isSG bool) (*ir.Spec, error) {
+	defs, err := ReadDefs(config)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse config: %w", err)
+	}
+	defs.InternalAddrsOverride = opts.InternalCidrs
+	res, err := reader.ReadSpecFromBytes(spec, defs, isSG)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse connectivity spec: %w", err)
+	}
+	return res, nil
+}
+
+// SynthSG generates SGs that only allow the connectivity of the spec
+func SynthSG(config, spec []byte, opts *Options) (*Result, error) {
+	return synthesis(config, spec, opts, synth.NewSGSynthesizer, true)
+}
+
+// SynthACL generates nACLs that only allow the connectivity of the spec
+func SynthACL(config, spec []byte, opts *Options) (*Result, error) {
+	return synthesis(config, spec, opts, synth.NewACLSynthesizer, false)
+}
+
+// SynthAll generates both nACLs and SGs that only allow the connectivity of the spec, and returns an error if either
+// layer blocks a required connection. The collection is an *ir.LayeredCollection.
+func SynthAll(config, spec []byte, opts *Options) (*Result, error) {
+	opts = withDefaults(opts)
+	if err := opts.Quotas.Validate(); err != nil {
+		return nil, err
+	}
+	// the forbidden connections are denied by the nACLs, so the SGs are synthesized without them
+	reader := jsonio.NewReaderWithFormat(opts.SpecFormat)
+	sgSpec, err := readSpec(config, spec, opts, reader.SkippingForbiddenForSGs(), true)
+	if err != nil {
+		return nil, err
+	}
+	aclSpec, err := readSpec(config, spec, opts, reader, false)
+	if err != nil {
+		return nil, err
+	}
+	return runSynthesizer(synth.NewLayeredSynthesizer(sgSpec, aclSpec, synthOptions(opts)), sgSpec)
+}
+
+// OptimizeSG optimizes the SGs of the config
+func OptimizeSG(config []byte, opts *Options) (*Result, error) {
+	return optimization(config, opts, sgOptimizer.NewSGOptimizer, true)
+}
+
+// OptimizeACL optimizes the nACLs of the config
+func OptimizeACL(config []byte, opts *Options) (*Result, error) {
+	return optimization(config, opts, aclOptimizer.NewACLOptimizer, false)
+}
+
+// VerifySG checks the SGs of the config against the spec; the diagnostics are those of reading the spec and the SGs
+func VerifySG(config, spec []byte, opts *Options) (*verify.Report, ir.Diagnostics, error) {
+	return verification(config, spec, opts, verify.NewSGVerifier, true)
+}
+
+// VerifyACL checks the nACLs of the config against the spec; the diagnostics are those of reading the spec and the nACLs
+func VerifyACL(config, spec []byte, opts *Options) (*verify.Report, ir.Diagnostics, error) {
+	return verification(config, spec, opts, func(s *ir.Spec, collection ir.Collection) verify.Verifier {
+		return verify.NewACLVerifierWithResponses(s, collection, withDefaults(opts).Responses)
+	}, false)
+}
+
+// Write returns a collection in the given output format. If vpc is not empty, only its resources are written.
+// The json format updates the given config object, and is not supported for a terraform state.
+func Write(collection ir.Collection, format, vpc string, config []byte, isSynth bool) ([]byte, error) {
+	var data bytes.Buffer
+	writer, err := pickWriter(format, bufio.NewWriter(&data), config)
+	if err != nil {
+		return nil, err
+	}
+	if err := collection.Write(writer, vpc, isSynth); err != nil {
+		return nil, err
+	}
+	return data.Bytes(), nil
+}
+
+// WriteTFWithAttachments returns a collection in tf format, with the resources that attach the nACLs to their subnets
+// and the SGs to their targets, which are looked up in the given config. If vpc is not empty, only its resources are written.
+func WriteTFWithAttachments(collection ir.Collection, vpc string, config []byte, isSynth bool) ([]byte, error) {
+	defs, err := ReadDefs(config)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse config: %w", err)
+	}
+	var data bytes.Buffer
+	if err := collection.Write(tfio.NewWriterWithAttachments(&data, defs), vpc, isSynth); err != nil {
+		return nil, err
+	}
+	return data.Bytes(), nil
+}
+
+// WriteTFInPlace returns an optimized collection in tf format, with the import and moved blocks that update the SGs or
+// the nACLs of the given config in place, rather than replacing them. If vpc is not empty, only its resources are written.
+func WriteTFInPlace(collection ir.Collection, vpc string, config []byte) ([]byte, error) {
+	_, isSG := collection.(*ir.SGCollection)
+	original, _, err := ReadCollection(config, isSG) // read again, since the optimizers change the collection they optimize
+	if err != nil {
+		return nil, fmt.Errorf("could not parse config: %w", err)
+	}
+	var data bytes.Buffer
+	if err := collection.Write(tfio.NewWriterWithImports(&data, nil, original), vpc, false); err != nil {
+		return nil, err
+	}
+	return data.Bytes(), nil
+}
+
+func synthesis(config, spec []byte, opts *Options, newSynthesizer func(*ir.Spec, *synth.Options) synth.Synthesizer,
+	isSG bool) (*Result, error) {
+	opts = withDefaults(opts)
+	if err := opts.Quotas.Validate(); err != nil {
+		return nil, err
+	}
+	s, err := ReadSpec(config, spec, opts, isSG)
+	if err != nil {
+		return nil, err
+	}
+	return runSynthesizer(newSynthesizer(s, synthOptions(opts)), s)
+}
+
+func runSynthesizer(synthesizer synth.Synthesizer, s *ir.Spec) (*Result, error) {
+	collection, diagnostics, err := synthesizer.Synth()
+	if err != nil {
+		return &Result{Diagnostics: diagnostics}, err
+	}
+	return &Result{Collection: collection, VPCs: utils.MapKeys(s.Defs.ConfigDefs.VPCs), Diagnostics: diagnostics}, nil
+}
+
+func synthOptions(opts *Options) *synth.Options {
+	return &synth.Options{SingleACL: opts.SingleACL, ACLStrategy: opts.ACLStrategy, Optimize: opts.Optimize, Quotas: opts.Quotas,
+		Responses: opts.Responses}
+}
+
+func optimization(config []byte, opts *Options, newOptimizer func(ir.Collection, string) optimize.Optimizer,
+	isSG bool) (*Result, error) {
+	opts = withDefaults(opts)
+	collection, readDiagnostics, err := ReadCollection(config, isSG)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse config: %w", err)
+	}
+	optimized, diagnostics, err := newOptimizer(collection, opts.FirewallName).Optimize()
+	if err != nil {
+		return &Result{Diagnostics: readDiagnostics}, err
+	}
+	return &Result{Collection: optimized, VPCs: collection.VpcNames(), Diagnostics: append(readDiagnostics, diagnostics...)}, nil
+}
+
+func verification(config, spec []byte, opts *Options, newVerifier func(*ir.Spec, ir.Collection) verify.Verifier,
+	isSG bool) (*verify.Report, ir.Diagnostics, error) {
+	s, err := ReadSpec(config, spec, opts, isSG)
+	if err != nil {
+		return nil, nil, err
+	}
+	collection, diagnostics, err := ReadCollection(config, isSG)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not parse config: %w", err)
+	}
+	return newVerifier(s, collection).Verify(), append(s.Diagnostics, diagnostics...), nil
+}
+
+func pickWriter(format string, w *bufio.Writer, config []byte) (ir.Writer, error) {
+	switch format {
+	case TFOutputFormat:
+		return tfio.NewWriter(w), nil
+	case CSVOutputFormat:
+		return io.NewCSVWriter(w), nil
+	case MDOutputFormat:
+		return io.NewMDWriter(w), nil
+	case JSONOutputFormat:
+		if tfstateio.IsStateBytes(config) {
+			return nil, fmt.Errorf("the %s output format requires a config object, not a terraform state", JSONOutputFormat)
+		}
+		return confio.NewWriterFromBytes(w, config)
+	}
+	return nil, fmt.Errorf("bad output format: %q", format)
+}
+
+func withDefaults(opts *Options) *Options {
+	res := Options{}
+	if opts != nil {
+		res = *opts
+	}
+	if res.Quotas == nil {
+		res.Quotas = synth.DefaultQuotas()
+	}
+	return &res
+}
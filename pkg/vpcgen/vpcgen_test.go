@@ -0,0 +1,246 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vpcgen
+
+import (
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/np-guard/models/pkg/netp"
+
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/ir"
+	"github.com/np-guard/vpc-network-config-synthesis/pkg/synth"
+)
+
+const (
+	dataFolder     = "../../test/data/"
+	expectedFolder = "../../test/expected/"
+	concurrency    = 8
+)
+
+type apiTest struct {
+	config   string
+	spec     string
+	expected string
+	synth    func(config, spec []byte, opts *Options) (*Result, error)
+}
+
+var apiTests = []apiTest{
+	{
+		config:   "acl_testing5/config_object.json",
+		spec:     "acl_testing5/conn_spec.json",
+		expected: "acl_testing5_tf/nacl_expected.tf",
+		synth:    SynthACL,
+	},
+	{
+		config:   "tg_multiple/config_object.json",
+		spec:     "sg_protocols/conn_spec.json",
+		expected: "sg_protocols_tf/sg_expected.tf",
+		synth:    SynthSG,
+	},
+}
+
+// TestConcurrentSynthesis runs several syntheses at once, and checks each of them produces the output of the CLI
+func TestConcurrentSynthesis(t *testing.T) {
+	var wg sync.WaitGroup
+	errs := make(chan string, concurrency*len(apiTests))
+	for i := 0; i < concurrency; i++ {
+		for _, tt := range apiTests {
+			config, spec, expected := readFile(t, dataFolder+tt.config), readFile(t, dataFolder+tt.spec), readFile(t, expectedFolder+tt.expected)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if msg := runSynthesis(&tt, config, spec, expected); msg != "" {
+					errs <- msg
+				}
+			}()
+		}
+	}
+	wg.Wait()
+	close(errs)
+	for msg := range errs {
+		t.Error(msg)
+	}
+}
+
+func TestSynthDiagnostics(t *testing.T) {
+	tt := apiTests[1]
+	result, err := tt.synth(readFile(t, dataFolder+tt.config), readFile(t, dataFolder+tt.spec), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Diagnostics) != 1 || result.Diagnostics[0].Code != ir.CodeUnspecifiedSG ||
+		!strings.HasPrefix(result.Diagnostics[0].Message, synth.WarningUnspecifiedSG) || len(result.Diagnostics[0].Resources) == 0 {
+		t.Fatalf("unexpected diagnostics: %v", result.Diagnostics)
+	}
+}
+
+// TestSpecDiagnostics checks that the warnings of reading the spec are returned with those of the synthesis
+func TestSpecDiagnostics(t *testing.T) {
+	spec := []byte(`{"required-connections": [{"src": {"name": "proxy", "type": "instance"}, "dst": {"name": "fe", "type": "instance"},
+		"allowed-protocols": [{"protocol": "ANY"}, {"protocol": "TCP"}]}]}`)
+	result, err := SynthSG(readFile(t, dataFolder+"sg_testing3/config_object.json"), spec, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range result.Diagnostics {
+		if d.Code == ir.CodeRedundantProtocols {
+			if d.Severity != ir.SeverityWarning || len(d.Origins) != 1 || d.Origins[0] != "required-connections[0].allowed-protocols" {
+				t.Fatalf("unexpected diagnostic: %+v", d)
+			}
+			return
+		}
+	}
+	t.Fatalf("missing %s diagnostic: %v", ir.CodeRedundantProtocols, result.Diagnostics)
+}
+
+func TestSynthAll(t *testing.T) {
+	config, spec := readFile(t, dataFolder+"sg_testing3/config_object.json"), readFile(t, dataFolder+"sg_testing3/conn_spec.json")
+	result, err := SynthAll(config, spec, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := Write(result.Collection, TFOutputFormat, "", config, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != string(readFile(t, expectedFolder+"all_sg_testing3_tf/expected.tf")) {
+		t.Fatal("the output is different than expected")
+	}
+}
+
+// TestSynthAllForbidden checks that the forbidden connections of the spec are denied by the nACLs of layered synthesis
+func TestSynthAllForbidden(t *testing.T) {
+	config, spec := readFile(t, dataFolder+"acl_testing5/config_object.json"), readFile(t, dataFolder+"acl_forbidden/conn_spec.json")
+	if _, err := SynthSG(config, spec, nil); err == nil {
+		t.Fatal("SG synthesis of a spec with forbidden connections should fail")
+	}
+	result, err := SynthAll(config, spec, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	acls := result.Collection.(*ir.LayeredCollection).ACLs
+	forbidden := 0
+	for _, vpc := range acls.VpcNames() {
+		for _, acl := range acls.ACLs[vpc] {
+			forbidden += len(acl.Forbidden)
+		}
+	}
+	if forbidden == 0 {
+		t.Fatal("the nACLs do not deny the forbidden connections")
+	}
+}
+
+func TestWriteTFWithAttachments(t *testing.T) {
+	config, spec := readFile(t, dataFolder+"sg_testing3/config_object.json"), readFile(t, dataFolder+"sg_testing3/conn_spec.json")
+	result, err := SynthAll(config, spec, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := WriteTFWithAttachments(result.Collection, "", config, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != string(readFile(t, expectedFolder+"all_sg_testing3_attach_tf/expected.tf")) {
+		t.Fatal("the output is different than expected")
+	}
+}
+
+// TestWriteTFInPlace checks that the rules an optimization keeps are imported by their ids in the config object
+func TestWriteTFInPlace(t *testing.T) {
+	config := readFile(t, dataFolder+"optimize_sg_protocols_to_all/config_object.json")
+	result, err := OptimizeSG(config, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := WriteTFInPlace(result.Collection, "", config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != string(readFile(t, expectedFolder+"optimize_sg_protocols_to_all_in_place_tf/sg_expected.tf")) {
+		t.Fatal("the output is different than expected")
+	}
+}
+
+// TestCoarseACLs checks that coarse nACLs allow any protocol, leaving the protocols of the spec to the SGs
+func TestCoarseACLs(t *testing.T) {
+	config, spec := readFile(t, dataFolder+"tg_multiple/config_object.json"), readFile(t, dataFolder+"sg_protocols/conn_spec.json")
+	result, err := SynthAll(config, spec, &Options{ACLStrategy: synth.CoarseACLs})
+	if err != nil {
+		t.Fatal(err)
+	}
+	acls := result.Collection.(*ir.LayeredCollection).ACLs
+	for _, vpc := range acls.VpcNames() {
+		for _, acl := range acls.ACLs[vpc] {
+			for _, rule := range acl.Rules() {
+				if _, ok := rule.Protocol.(netp.AnyProtocol); rule.Action == ir.Allow && !ok {
+					t.Errorf("%s: rule does not allow any protocol: %v", acl.Name, rule)
+				}
+			}
+		}
+	}
+}
+
+// TestResponsePolicies checks that nACLs synthesized with response policies verify against the same policies only
+func TestResponsePolicies(t *testing.T) {
+	config, spec := readFile(t, dataFolder+"tg_multiple/config_object.json"), readFile(t, dataFolder+"acl_protocols/conn_spec.json")
+	opts := &Options{Responses: &ir.ResponsePolicies{TCP: ir.ResponseEphemeral, UDP: ir.ResponseExact}}
+	result, err := SynthACL(config, spec, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	synthesized, err := Write(result.Collection, JSONOutputFormat, "", config, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, _, err := VerifyACL(synthesized, spec, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Exact() {
+		t.Errorf("the nACLs do not verify against their response policies:\n%s", report)
+	}
+	report, _, err = VerifyACL(synthesized, spec, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Exact() {
+		t.Error("the nACLs allow UDP responses, which the default response policies do not require")
+	}
+}
+
+func TestBadSpec(t *testing.T) {
+	tt := apiTests[0]
+	_, err := tt.synth(readFile(t, dataFolder+tt.config), []byte("{"), nil)
+	if err == nil || !strings.HasPrefix(err.Error(), "could not parse connectivity spec") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func runSynthesis(tt *apiTest, config, spec, expected []byte) string {
+	result, err := tt.synth(config, spec, nil)
+	if err != nil {
+		return tt.expected + ": " + err.Error()
+	}
+	data, err := Write(result.Collection, TFOutputFormat, "", config, true)
+	if err != nil {
+		return tt.expected + ": " + err.Error()
+	}
+	if string(data) != string(expected) {
+		return tt.expected + ": the output is different than expected"
+	}
+	return ""
+}
+
+func readFile(t *testing.T, filename string) []byte {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("error reading file %s: %v", filename, err)
+	}
+	return data
+}
@@ -31,6 +31,34 @@ func errorTestsList() []testCase {
 			},
 		},
 
+		// --in-place with a format other than tf
+		{
+			testName:    "in place md fmt",
+			expectedErr: "--in-place flag requires setting the output format to tf",
+			args: &command{
+				cmd:        optimize,
+				subcmd:     sg,
+				config:     cliConfig,
+				format:     "md",
+				outputFile: outputPath,
+				inPlace:    true,
+			},
+		},
+		// --attach with a format other than tf
+		{
+			testName:    "attach csv fmt",
+			expectedErr: "--attach flag requires setting the output format to tf",
+			args: &command{
+				cmd:        synthesis,
+				subcmd:     sg,
+				config:     cliConfig,
+				spec:       cliSpec,
+				format:     "csv",
+				outputFile: outputPath,
+				attach:     true,
+			},
+		},
+
 		// json fmt with -d
 		{
 			testName:    "json separate",
@@ -83,6 +111,104 @@ func errorTestsList() []testCase {
 			},
 		},
 
+		// unsupported spec format
+		{
+			testName:    "bad spec format",
+			expectedErr: "bad spec format \"xml\"; must be one of [json, yaml]",
+			args: &command{
+				cmd:        synthesis,
+				subcmd:     acl,
+				config:     cliConfig,
+				spec:       cliSpec,
+				specFormat: "xml",
+				outputFile: outputPath,
+			},
+		},
+
+		// unsupported diagnostics format
+		{
+			testName:    "bad diagnostics format",
+			expectedErr: "bad diagnostics format \"xml\"; must be one of [text, json]",
+			args: &command{
+				cmd:               synthesis,
+				subcmd:            acl,
+				config:            cliConfig,
+				spec:              cliSpec,
+				diagnosticsFormat: "xml",
+				outputFile:        outputPath,
+			},
+		},
+
+		// json diagnostics, which are printed to stdout, with the generated resources printed to stdout as well
+		{
+			testName:    "json diagnostics without output file",
+			expectedErr: "--diagnostics-format json requires -o or -d, since the diagnostics are printed to the standard output",
+			args: &command{
+				cmd:               synthesis,
+				subcmd:            sg,
+				config:            cliConfig,
+				spec:              cliSpec,
+				diagnosticsFormat: "json",
+			},
+		},
+
+		// unsupported nACL strategy
+		{
+			testName:    "bad acl strategy",
+			expectedErr: "bad nACL strategy \"tight\"; must be one of [fine, coarse]",
+			args: &command{
+				cmd:         synthesis,
+				subcmd:      acl,
+				config:      cliConfig,
+				spec:        cliSpec,
+				aclStrategy: "tight",
+				outputFile:  outputPath,
+			},
+		},
+
+		// unsupported response policy; TCP responses cannot be disabled
+		{
+			testName:    "bad tcp response policy",
+			expectedErr: "bad TCP response policy \"none\"; must be one of [exact, ephemeral]",
+			args: &command{
+				cmd:          synthesis,
+				subcmd:       acl,
+				config:       cliConfig,
+				spec:         cliSpec,
+				tcpResponses: "none",
+				outputFile:   outputPath,
+			},
+		},
+
+		// bad internal address space override
+		{
+			testName:    "bad internal cidrs",
+			expectedErr: "bad --internal-cidrs: invalid CIDR address: 10.0.0.0/33",
+			args: &command{
+				cmd:           synthesis,
+				subcmd:        acl,
+				config:        cliConfig,
+				spec:          cliSpec,
+				internalCidrs: "10.0.0.0/33",
+				outputFile:    outputPath,
+			},
+		},
+
+		// query with a port of an icmp flow
+		{
+			testName:    "query icmp port",
+			expectedErr: "--src-port and --dst-port require a tcp or udp flow",
+			args: &command{
+				cmd:      query,
+				subcmd:   acl,
+				config:   cliConfig,
+				src:      "10.240.1.5",
+				dst:      "10.240.2.9",
+				protocol: "icmp",
+				dstPort:  80,
+			},
+		},
+
 		/*  ############################  */
 		/*	####### INPUT ERRORS #######  */
 		/*  ############################  */
@@ -102,8 +228,9 @@ func errorTestsList() []testCase {
 
 		// bad protocol
 		{
-			testName:    "bad protocol",
-			expectedErr: "could not parse connectivity file data_for_testing_errors/bad_protocol/conn_spec.json: invalid protocol type \"ALOHA\"",
+			testName: "bad protocol",
+			expectedErr: "could not parse connectivity file data_for_testing_errors/bad_protocol/conn_spec.json: " +
+				"required-connections[0].allowed-protocols[0] (line 13, column 17): invalid protocol type \"ALOHA\"",
 			args: &command{
 				cmd:        synthesis,
 				subcmd:     acl,
@@ -126,6 +253,76 @@ func errorTestsList() []testCase {
 			},
 		},
 
+		// forbidden connections with SG synthesis
+		{
+			testName:    "forbidden connections sg",
+			expectedErr: "forbidden connections are not supported for SGs, since SGs cannot deny traffic",
+			args: &command{
+				cmd:        synthesis,
+				subcmd:     sg,
+				config:     "%s/forbidden_sg/config_object.json",
+				spec:       "%s/forbidden_sg/conn_spec.json",
+				outputFile: outputPath,
+			},
+		},
+
+		// terraform plan with values that are unknown until apply
+		{
+			testName:    "tfplan unknown values",
+			expectedErr: "subnet subnet1: missing reference; unknown values of a plan are not supported",
+			args: &command{
+				cmd:        synthesis,
+				subcmd:     acl,
+				config:     "%s/tfplan_unknown/tfplan.json",
+				spec:       "%s/tfplan_unknown/conn_spec.json",
+				outputFile: outputPath,
+			},
+		},
+
+		// YAML spec with an unknown resource in a connection
+		{
+			testName:    "yaml unknown resource",
+			expectedErr: "required-connections[1].dst (line 6, column 5): unknown resource name subnet12 (resource type: \"subnet\")",
+			args: &command{
+				cmd:        synthesis,
+				subcmd:     acl,
+				config:     "%s/yaml_unknown_resource/config_object.json",
+				spec:       "%s/yaml_unknown_resource/conn_spec.yaml",
+				outputFile: outputPath,
+			},
+		},
+
+		// YAML spec with a segment that has no type
+		{
+			testName:    "yaml bad segment",
+			expectedErr: "segments.elevens (line 5, column 3): field type in Segment: required",
+			args: &command{
+				cmd:        synthesis,
+				subcmd:     acl,
+				config:     "%s/yaml_bad_segment/config_object.json",
+				spec:       "%s/yaml_bad_segment/conn_spec.yaml",
+				outputFile: outputPath,
+			},
+		},
+
+		// nACL rules quota
+		{
+			testName: "acl rules quota",
+			expectedErr: "2 resources exceed the quotas:\n" +
+				"test-vpc1/subnet10 has 3 nACL rules, the limit is 2; connections beyond the limit:\n" +
+				"\tInternal. required-connections[0]: (subnet test-vpc1/subnet10)->(subnet test-vpc1/subnet11); allowed-protocols[1]\n" +
+				"test-vpc1/subnet11 has 3 nACL rules, the limit is 2; connections beyond the limit:\n" +
+				"\tInternal. required-connections[0]: (subnet test-vpc1/subnet10)->(subnet test-vpc1/subnet11); allowed-protocols[1]",
+			args: &command{
+				cmd:         synthesis,
+				subcmd:      acl,
+				config:      "%s/acl_quota/config_object.json",
+				spec:        "%s/acl_quota/conn_spec.json",
+				outputFile:  outputPath,
+				maxACLRules: 2,
+			},
+		},
+
 		// unknown resource in spec
 		{
 			testName:    "unknown resource",
@@ -139,12 +336,27 @@ func errorTestsList() []testCase {
 			},
 		},
 
+		// all unknown resources in spec are reported
+		{
+			testName: "multiple spec errors",
+			expectedErr: "required-connections[0].dst (line 8, column 13): unknown resource name subnet35 (resource type: \"subnet\")\n" +
+				"required-connections[1].src (line 14, column 13): unknown resource name subnet36 (resource type: \"subnet\")",
+			args: &command{
+				cmd:        synthesis,
+				subcmd:     acl,
+				config:     "%s/multiple_spec_errors/config_object.json",
+				spec:       "%s/multiple_spec_errors/conn_spec.json",
+				outputFile: outputPath,
+			},
+		},
+
 		// impossible resource type
 		{
 			testName: "impossible resource type",
 			expectedErr: "could not parse connectivity file data_for_testing_errors/impossible_resource_type/conn_spec.json: " +
-				"invalid value (expected one of []interface {}{\"external\", \"segment\", \"subnet\"," +
-				" \"instance\", \"nif\", \"cidr\", \"vpe\"}): \"policydb-endpoint-gateway\"",
+				"required-connections[0].dst (line 8, column 13): invalid value " +
+				"(expected one of []interface {}{\"external\", \"segment\", \"subnet\", \"instance\", \"nif\", \"cidr\", \"vpe\", " +
+				"\"load_balancer\"}): \"policydb-endpoint-gateway\"",
 			args: &command{
 				cmd:        synthesis,
 				subcmd:     acl,
@@ -0,0 +1,132 @@
+# Attached subnets: test-vpc1/subnet1
+resource "ibm_is_network_acl" "test-vpc1--subnet1" {
+  name           = "test-vpc1--subnet1"
+  resource_group = local.acl_synth_resource_group_id
+  vpc            = local.acl_synth_test-vpc1_id
+  # Deny other internal communication; internal address space item 0
+  rules {
+    name        = "rule0"
+    action      = "deny"
+    direction   = "outbound"
+    source      = "0.0.0.0/0"
+    destination = "10.0.0.0/8"
+  }
+  # Deny other internal communication; internal address space item 0
+  rules {
+    name        = "rule1"
+    action      = "deny"
+    direction   = "inbound"
+    source      = "10.0.0.0/8"
+    destination = "0.0.0.0/0"
+  }
+  # Deny other internal communication; internal address space item 1
+  rules {
+    name        = "rule2"
+    action      = "deny"
+    direction   = "outbound"
+    source      = "0.0.0.0/0"
+    destination = "172.16.0.0/12"
+  }
+  # Deny other internal communication; internal address space item 1
+  rules {
+    name        = "rule3"
+    action      = "deny"
+    direction   = "inbound"
+    source      = "172.16.0.0/12"
+    destination = "0.0.0.0/0"
+  }
+  # External. required-connections[0]: (external dns)->(subnet test-vpc1/subnet1); allowed-protocols[0]
+  rules {
+    name        = "rule4"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "8.8.8.8"
+    destination = "10.240.10.0/24"
+  }
+  # External. response (exact inverse) to required-connections[0]: (external dns)->(subnet test-vpc1/subnet1); allowed-protocols[0]
+  rules {
+    name        = "rule5"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "10.240.10.0/24"
+    destination = "8.8.8.8"
+  }
+}
+
+# Attached subnets: test-vpc1/subnet2
+resource "ibm_is_network_acl" "test-vpc1--subnet2" {
+  name           = "test-vpc1--subnet2"
+  resource_group = local.acl_synth_resource_group_id
+  vpc            = local.acl_synth_test-vpc1_id
+  # Deny other internal communication; internal address space item 0
+  rules {
+    name        = "rule0"
+    action      = "deny"
+    direction   = "outbound"
+    source      = "0.0.0.0/0"
+    destination = "10.0.0.0/8"
+  }
+  # Deny other internal communication; internal address space item 0
+  rules {
+    name        = "rule1"
+    action      = "deny"
+    direction   = "inbound"
+    source      = "10.0.0.0/8"
+    destination = "0.0.0.0/0"
+  }
+  # Deny other internal communication; internal address space item 1
+  rules {
+    name        = "rule2"
+    action      = "deny"
+    direction   = "outbound"
+    source      = "0.0.0.0/0"
+    destination = "172.16.0.0/12"
+  }
+  # Deny other internal communication; internal address space item 1
+  rules {
+    name        = "rule3"
+    action      = "deny"
+    direction   = "inbound"
+    source      = "172.16.0.0/12"
+    destination = "0.0.0.0/0"
+  }
+  # External. required-connections[1]: (subnet test-vpc1/subnet2)->(external public internet); allowed-protocols[0]
+  rules {
+    name        = "rule4"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "10.240.20.0/24"
+    destination = "0.0.0.0/0"
+  }
+  # External. response (exact inverse) to required-connections[1]: (subnet test-vpc1/subnet2)->(external public internet); allowed-protocols[0]
+  rules {
+    name        = "rule5"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "0.0.0.0/0"
+    destination = "10.240.20.0/24"
+  }
+}
+
+# Attached subnets: test-vpc1/subnet3
+resource "ibm_is_network_acl" "test-vpc1--subnet3" {
+  name           = "test-vpc1--subnet3"
+  resource_group = local.acl_synth_resource_group_id
+  vpc            = local.acl_synth_test-vpc1_id
+  # Deny all communication; subnet test-vpc1/subnet3[10.240.30.0/24] does not have required connections
+  rules {
+    name        = "rule0"
+    action      = "deny"
+    direction   = "inbound"
+    source      = "0.0.0.0/0"
+    destination = "10.240.30.0/24"
+  }
+  # Deny all communication; subnet test-vpc1/subnet3[10.240.30.0/24] does not have required connections
+  rules {
+    name        = "rule1"
+    action      = "deny"
+    direction   = "outbound"
+    source      = "10.240.30.0/24"
+    destination = "0.0.0.0/0"
+  }
+}
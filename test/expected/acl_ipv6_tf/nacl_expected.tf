@@ -0,0 +1,236 @@
+# Attached subnets: test-vpc1/subnet1
+resource "ibm_is_network_acl" "test-vpc1--subnet1" {
+  name           = "test-vpc1--subnet1"
+  resource_group = local.acl_synth_resource_group_id
+  vpc            = local.acl_synth_test-vpc1_id
+  # Internal. required-connections[2]: (subnet test-vpc1/subnet1)->(subnet test-vpc1/subnet2); allowed-protocols[0]
+  rules {
+    name        = "rule0"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "10.240.10.0/24"
+    destination = "10.240.20.0/24"
+    tcp {
+      port_min = 443
+      port_max = 443
+    }
+  }
+  # Internal. response (exact inverse) to required-connections[2]: (subnet test-vpc1/subnet1)->(subnet test-vpc1/subnet2); allowed-protocols[0]
+  rules {
+    name        = "rule1"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "10.240.20.0/24"
+    destination = "10.240.10.0/24"
+    tcp {
+      source_port_min = 443
+      source_port_max = 443
+    }
+  }
+  # Internal. required-connections[2]: (subnet test-vpc1/subnet1)->(subnet test-vpc1/subnet2); allowed-protocols[0]
+  rules {
+    name        = "rule2"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "2001:db8:0:10::/64"
+    destination = "2001:db8:0:20::/64"
+    tcp {
+      port_min = 443
+      port_max = 443
+    }
+  }
+  # Internal. response (exact inverse) to required-connections[2]: (subnet test-vpc1/subnet1)->(subnet test-vpc1/subnet2); allowed-protocols[0]
+  rules {
+    name        = "rule3"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "2001:db8:0:20::/64"
+    destination = "2001:db8:0:10::/64"
+    tcp {
+      source_port_min = 443
+      source_port_max = 443
+    }
+  }
+  # Deny other internal communication; internal address space item 0
+  rules {
+    name        = "rule4"
+    action      = "deny"
+    direction   = "outbound"
+    source      = "0.0.0.0/0"
+    destination = "10.240.0.0/17"
+  }
+  # Deny other internal communication; internal address space item 0
+  rules {
+    name        = "rule5"
+    action      = "deny"
+    direction   = "inbound"
+    source      = "10.240.0.0/17"
+    destination = "0.0.0.0/0"
+  }
+  # Deny other internal communication; internal address space item 1
+  rules {
+    name        = "rule6"
+    action      = "deny"
+    direction   = "outbound"
+    source      = "0.0.0.0/0"
+    destination = "10.240.128.0/18"
+  }
+  # Deny other internal communication; internal address space item 1
+  rules {
+    name        = "rule7"
+    action      = "deny"
+    direction   = "inbound"
+    source      = "10.240.128.0/18"
+    destination = "0.0.0.0/0"
+  }
+  # Deny other internal communication; internal address space item 2
+  rules {
+    name        = "rule8"
+    action      = "deny"
+    direction   = "outbound"
+    source      = "::/0"
+    destination = "2001:db8:0:10::/64"
+  }
+  # Deny other internal communication; internal address space item 2
+  rules {
+    name        = "rule9"
+    action      = "deny"
+    direction   = "inbound"
+    source      = "2001:db8:0:10::/64"
+    destination = "::/0"
+  }
+  # Deny other internal communication; internal address space item 3
+  rules {
+    name        = "rule10"
+    action      = "deny"
+    direction   = "outbound"
+    source      = "::/0"
+    destination = "2001:db8:0:20::/64"
+  }
+  # Deny other internal communication; internal address space item 3
+  rules {
+    name        = "rule11"
+    action      = "deny"
+    direction   = "inbound"
+    source      = "2001:db8:0:20::/64"
+    destination = "::/0"
+  }
+  # External. required-connections[0]: (subnet test-vpc1/subnet1)->(external ipv6 dns); allowed-protocols[0]
+  rules {
+    name        = "rule12"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "2001:db8:0:10::/64"
+    destination = "2001:4860:4860::8888"
+    udp {
+      port_min = 53
+      port_max = 53
+    }
+  }
+  # External. required-connections[1]: (subnet test-vpc1/subnet1)->(external ipv4 dns); allowed-protocols[0]
+  rules {
+    name        = "rule13"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "10.240.10.0/24"
+    destination = "8.8.8.8"
+    udp {
+      port_min = 53
+      port_max = 53
+    }
+  }
+}
+
+# Attached subnets: test-vpc1/subnet2
+resource "ibm_is_network_acl" "test-vpc1--subnet2" {
+  name           = "test-vpc1--subnet2"
+  resource_group = local.acl_synth_resource_group_id
+  vpc            = local.acl_synth_test-vpc1_id
+  # Internal. required-connections[2]: (subnet test-vpc1/subnet1)->(subnet test-vpc1/subnet2); allowed-protocols[0]
+  rules {
+    name        = "rule0"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "10.240.10.0/24"
+    destination = "10.240.20.0/24"
+    tcp {
+      port_min = 443
+      port_max = 443
+    }
+  }
+  # Internal. response (exact inverse) to required-connections[2]: (subnet test-vpc1/subnet1)->(subnet test-vpc1/subnet2); allowed-protocols[0]
+  rules {
+    name        = "rule1"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "10.240.20.0/24"
+    destination = "10.240.10.0/24"
+    tcp {
+      source_port_min = 443
+      source_port_max = 443
+    }
+  }
+  # Internal. required-connections[2]: (subnet test-vpc1/subnet1)->(subnet test-vpc1/subnet2); allowed-protocols[0]
+  rules {
+    name        = "rule2"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "2001:db8:0:10::/64"
+    destination = "2001:db8:0:20::/64"
+    tcp {
+      port_min = 443
+      port_max = 443
+    }
+  }
+  # Internal. response (exact inverse) to required-connections[2]: (subnet test-vpc1/subnet1)->(subnet test-vpc1/subnet2); allowed-protocols[0]
+  rules {
+    name        = "rule3"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "2001:db8:0:20::/64"
+    destination = "2001:db8:0:10::/64"
+    tcp {
+      source_port_min = 443
+      source_port_max = 443
+    }
+  }
+  # Internal. required-connections[3]: (subnet test-vpc1/subnet2)->(subnet test-vpc1/subnet3); allowed-protocols[0]
+  rules {
+    name        = "rule4"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "10.240.20.0/24"
+    destination = "10.240.30.0/24"
+  }
+  # Internal. response (exact inverse) to required-connections[3]: (subnet test-vpc1/subnet2)->(subnet test-vpc1/subnet3); allowed-protocols[0]
+  rules {
+    name        = "rule5"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "10.240.30.0/24"
+    destination = "10.240.20.0/24"
+  }
+}
+
+# Attached subnets: test-vpc1/subnet3
+resource "ibm_is_network_acl" "test-vpc1--subnet3" {
+  name           = "test-vpc1--subnet3"
+  resource_group = local.acl_synth_resource_group_id
+  vpc            = local.acl_synth_test-vpc1_id
+  # Internal. required-connections[3]: (subnet test-vpc1/subnet2)->(subnet test-vpc1/subnet3); allowed-protocols[0]
+  rules {
+    name        = "rule0"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "10.240.20.0/24"
+    destination = "10.240.30.0/24"
+  }
+  # Internal. response (exact inverse) to required-connections[3]: (subnet test-vpc1/subnet2)->(subnet test-vpc1/subnet3); allowed-protocols[0]
+  rules {
+    name        = "rule1"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "10.240.30.0/24"
+    destination = "10.240.20.0/24"
+  }
+}
@@ -0,0 +1,212 @@
+# Attached subnets: test-vpc/sub1
+resource "ibm_is_network_acl" "test-vpc--sub1" {
+  name           = "test-vpc--sub1"
+  resource_group = local.acl_synth_resource_group_id
+  vpc            = local.acl_synth_test-vpc_id
+  # Internal. required-connections[1]: (instance test-vpc/proxy)->(load_balancer test-vpc/fe-lb); allowed-protocols[0]
+  rules {
+    name        = "rule0"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "10.240.0.0/24"
+    destination = "10.240.64.0/24"
+    tcp {
+      port_min = 443
+      port_max = 443
+    }
+  }
+  # Internal. response (exact inverse) to required-connections[1]: (instance test-vpc/proxy)->(load_balancer test-vpc/fe-lb); allowed-protocols[0]
+  rules {
+    name        = "rule1"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "10.240.64.0/24"
+    destination = "10.240.0.0/24"
+    tcp {
+      source_port_min = 443
+      source_port_max = 443
+    }
+  }
+  # Internal. required-connections[2]: (segment frontend-lbs)->(instance test-vpc/fe); allowed-protocols[0]
+  rules {
+    name        = "rule2"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "10.240.0.0/24"
+    destination = "10.240.128.0/24"
+    tcp {
+      port_min = 9000
+      port_max = 9000
+    }
+  }
+  # Internal. response (exact inverse) to required-connections[2]: (segment frontend-lbs)->(instance test-vpc/fe); allowed-protocols[0]
+  rules {
+    name        = "rule3"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "10.240.128.0/24"
+    destination = "10.240.0.0/24"
+    tcp {
+      source_port_min = 9000
+      source_port_max = 9000
+    }
+  }
+  # Deny other internal communication; internal address space item 0
+  rules {
+    name        = "rule4"
+    action      = "deny"
+    direction   = "outbound"
+    source      = "0.0.0.0/0"
+    destination = "10.240.0.0/17"
+  }
+  # Deny other internal communication; internal address space item 0
+  rules {
+    name        = "rule5"
+    action      = "deny"
+    direction   = "inbound"
+    source      = "10.240.0.0/17"
+    destination = "0.0.0.0/0"
+  }
+  # Deny other internal communication; internal address space item 1
+  rules {
+    name        = "rule6"
+    action      = "deny"
+    direction   = "outbound"
+    source      = "0.0.0.0/0"
+    destination = "10.240.128.0/18"
+  }
+  # Deny other internal communication; internal address space item 1
+  rules {
+    name        = "rule7"
+    action      = "deny"
+    direction   = "inbound"
+    source      = "10.240.128.0/18"
+    destination = "0.0.0.0/0"
+  }
+  # External. required-connections[0]: (external public internet)->(instance test-vpc/proxy); allowed-protocols[0]
+  rules {
+    name        = "rule8"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "0.0.0.0/0"
+    destination = "10.240.0.0/24"
+  }
+  # External. response (exact inverse) to required-connections[0]: (external public internet)->(instance test-vpc/proxy); allowed-protocols[0]
+  rules {
+    name        = "rule9"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "10.240.0.0/24"
+    destination = "0.0.0.0/0"
+  }
+}
+
+# Attached subnets: test-vpc/sub2
+resource "ibm_is_network_acl" "test-vpc--sub2" {
+  name           = "test-vpc--sub2"
+  resource_group = local.acl_synth_resource_group_id
+  vpc            = local.acl_synth_test-vpc_id
+  # Internal. required-connections[2]: (segment frontend-lbs)->(instance test-vpc/fe); allowed-protocols[0]
+  rules {
+    name        = "rule0"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "10.240.0.0/24"
+    destination = "10.240.128.0/24"
+    tcp {
+      port_min = 9000
+      port_max = 9000
+    }
+  }
+  # Internal. response (exact inverse) to required-connections[2]: (segment frontend-lbs)->(instance test-vpc/fe); allowed-protocols[0]
+  rules {
+    name        = "rule1"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "10.240.128.0/24"
+    destination = "10.240.0.0/24"
+    tcp {
+      source_port_min = 9000
+      source_port_max = 9000
+    }
+  }
+  # Internal. required-connections[2]: (segment frontend-lbs)->(instance test-vpc/fe); allowed-protocols[0]
+  rules {
+    name        = "rule2"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "10.240.64.0/24"
+    destination = "10.240.128.0/24"
+    tcp {
+      port_min = 9000
+      port_max = 9000
+    }
+  }
+  # Internal. response (exact inverse) to required-connections[2]: (segment frontend-lbs)->(instance test-vpc/fe); allowed-protocols[0]
+  rules {
+    name        = "rule3"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "10.240.128.0/24"
+    destination = "10.240.64.0/24"
+    tcp {
+      source_port_min = 9000
+      source_port_max = 9000
+    }
+  }
+}
+
+# Attached subnets: test-vpc/sub3
+resource "ibm_is_network_acl" "test-vpc--sub3" {
+  name           = "test-vpc--sub3"
+  resource_group = local.acl_synth_resource_group_id
+  vpc            = local.acl_synth_test-vpc_id
+  # Internal. required-connections[1]: (instance test-vpc/proxy)->(load_balancer test-vpc/fe-lb); allowed-protocols[0]
+  rules {
+    name        = "rule0"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "10.240.0.0/24"
+    destination = "10.240.64.0/24"
+    tcp {
+      port_min = 443
+      port_max = 443
+    }
+  }
+  # Internal. response (exact inverse) to required-connections[1]: (instance test-vpc/proxy)->(load_balancer test-vpc/fe-lb); allowed-protocols[0]
+  rules {
+    name        = "rule1"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "10.240.64.0/24"
+    destination = "10.240.0.0/24"
+    tcp {
+      source_port_min = 443
+      source_port_max = 443
+    }
+  }
+  # Internal. required-connections[2]: (segment frontend-lbs)->(instance test-vpc/fe); allowed-protocols[0]
+  rules {
+    name        = "rule2"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "10.240.64.0/24"
+    destination = "10.240.128.0/24"
+    tcp {
+      port_min = 9000
+      port_max = 9000
+    }
+  }
+  # Internal. response (exact inverse) to required-connections[2]: (segment frontend-lbs)->(instance test-vpc/fe); allowed-protocols[0]
+  rules {
+    name        = "rule3"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "10.240.128.0/24"
+    destination = "10.240.64.0/24"
+    tcp {
+      source_port_min = 9000
+      source_port_max = 9000
+    }
+  }
+}
@@ -35,7 +35,7 @@ resource "ibm_is_network_acl" "test-vpc0--subnet0" {
     source      = "10.240.0.0/24"
     destination = "10.240.5.0/24"
   }
-  # Internal. response to required-connections[2]: (segment instanceSegment)->(segment nifSegment); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[2]: (segment instanceSegment)->(segment nifSegment); allowed-protocols[0]
   rules {
     name        = "rule3"
     action      = "allow"
@@ -51,7 +51,7 @@ resource "ibm_is_network_acl" "test-vpc0--subnet0" {
     source      = "10.240.0.0/24"
     destination = "10.240.192.0/24"
   }
-  # Internal. response to required-connections[2]: (segment instanceSegment)->(segment nifSegment); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[2]: (segment instanceSegment)->(segment nifSegment); allowed-protocols[0]
   rules {
     name        = "rule5"
     action      = "allow"
@@ -121,7 +121,7 @@ resource "ibm_is_network_acl" "test-vpc0--subnet2" {
     source      = "10.240.4.0/24"
     destination = "10.240.5.0/24"
   }
-  # Internal. response to required-connections[2]: (segment instanceSegment)->(segment nifSegment); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[2]: (segment instanceSegment)->(segment nifSegment); allowed-protocols[0]
   rules {
     name        = "rule3"
     action      = "allow"
@@ -137,7 +137,7 @@ resource "ibm_is_network_acl" "test-vpc0--subnet2" {
     source      = "10.240.4.0/24"
     destination = "10.240.192.0/24"
   }
-  # Internal. response to required-connections[2]: (segment instanceSegment)->(segment nifSegment); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[2]: (segment instanceSegment)->(segment nifSegment); allowed-protocols[0]
   rules {
     name        = "rule5"
     action      = "allow"
@@ -160,7 +160,7 @@ resource "ibm_is_network_acl" "test-vpc0--subnet3" {
     source      = "10.240.5.0/24"
     destination = "10.240.192.0/24"
   }
-  # Internal. response to required-connections[1]: (segment nifSegment)->(segment nifSegment); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[1]: (segment nifSegment)->(segment nifSegment); allowed-protocols[0]
   rules {
     name        = "rule1"
     action      = "allow"
@@ -176,7 +176,7 @@ resource "ibm_is_network_acl" "test-vpc0--subnet3" {
     source      = "10.240.0.0/24"
     destination = "10.240.5.0/24"
   }
-  # Internal. response to required-connections[2]: (segment instanceSegment)->(segment nifSegment); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[2]: (segment instanceSegment)->(segment nifSegment); allowed-protocols[0]
   rules {
     name        = "rule3"
     action      = "allow"
@@ -192,7 +192,7 @@ resource "ibm_is_network_acl" "test-vpc0--subnet3" {
     source      = "10.240.4.0/24"
     destination = "10.240.5.0/24"
   }
-  # Internal. response to required-connections[2]: (segment instanceSegment)->(segment nifSegment); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[2]: (segment instanceSegment)->(segment nifSegment); allowed-protocols[0]
   rules {
     name        = "rule5"
     action      = "allow"
@@ -330,7 +330,7 @@ resource "ibm_is_network_acl" "test-vpc3--subnet30" {
     source      = "10.240.192.0/24"
     destination = "10.240.5.0/24"
   }
-  # Internal. response to required-connections[1]: (segment nifSegment)->(segment nifSegment); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[1]: (segment nifSegment)->(segment nifSegment); allowed-protocols[0]
   rules {
     name        = "rule1"
     action      = "allow"
@@ -346,7 +346,7 @@ resource "ibm_is_network_acl" "test-vpc3--subnet30" {
     source      = "10.240.0.0/24"
     destination = "10.240.192.0/24"
   }
-  # Internal. response to required-connections[2]: (segment instanceSegment)->(segment nifSegment); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[2]: (segment instanceSegment)->(segment nifSegment); allowed-protocols[0]
   rules {
     name        = "rule3"
     action      = "allow"
@@ -362,7 +362,7 @@ resource "ibm_is_network_acl" "test-vpc3--subnet30" {
     source      = "10.240.4.0/24"
     destination = "10.240.192.0/24"
   }
-  # Internal. response to required-connections[2]: (segment instanceSegment)->(segment nifSegment); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[2]: (segment instanceSegment)->(segment nifSegment); allowed-protocols[0]
   rules {
     name        = "rule5"
     action      = "allow"
@@ -14,7 +14,7 @@ resource "ibm_is_network_acl" "test-vpc0--subnet0" {
       type = 0
     }
   }
-  # Internal. response to required-connections[0]: (subnet test-vpc0/subnet4)->(instance test-vpc0/vsi0-subnet0); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[0]: (subnet test-vpc0/subnet4)->(instance test-vpc0/vsi0-subnet0); allowed-protocols[0]
   rules {
     name        = "rule1"
     action      = "allow"
@@ -42,7 +42,7 @@ resource "ibm_is_network_acl" "test-vpc0--subnet1" {
     tcp {
     }
   }
-  # Internal. response to required-connections[1]: (nif test-vpc0/vsi0-subnet1/scale-clambake-endearing-abridged)->(subnet test-vpc0/subnet4); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[1]: (nif test-vpc0/vsi0-subnet1/scale-clambake-endearing-abridged)->(subnet test-vpc0/subnet4); allowed-protocols[0]
   rules {
     name        = "rule1"
     action      = "allow"
@@ -116,7 +116,7 @@ resource "ibm_is_network_acl" "test-vpc0--subnet4" {
       type = 0
     }
   }
-  # Internal. response to required-connections[0]: (subnet test-vpc0/subnet4)->(instance test-vpc0/vsi0-subnet0); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[0]: (subnet test-vpc0/subnet4)->(instance test-vpc0/vsi0-subnet0); allowed-protocols[0]
   rules {
     name        = "rule1"
     action      = "allow"
@@ -137,7 +137,7 @@ resource "ibm_is_network_acl" "test-vpc0--subnet4" {
     tcp {
     }
   }
-  # Internal. response to required-connections[1]: (nif test-vpc0/vsi0-subnet1/scale-clambake-endearing-abridged)->(subnet test-vpc0/subnet4); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[1]: (nif test-vpc0/vsi0-subnet1/scale-clambake-endearing-abridged)->(subnet test-vpc0/subnet4); allowed-protocols[0]
   rules {
     name        = "rule3"
     action      = "allow"
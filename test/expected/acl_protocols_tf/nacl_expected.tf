@@ -13,7 +13,7 @@ resource "ibm_is_network_acl" "test-vpc0--subnet0" {
     tcp {
     }
   }
-  # Internal. response to required-connections[0]: (subnet test-vpc0/subnet0)->(subnet test-vpc0/subnet1); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[0]: (subnet test-vpc0/subnet0)->(subnet test-vpc0/subnet1); allowed-protocols[0]
   rules {
     name        = "rule1"
     action      = "allow"
@@ -33,7 +33,7 @@ resource "ibm_is_network_acl" "test-vpc0--subnet0" {
     icmp {
     }
   }
-  # Internal. response to required-connections[0]: (subnet test-vpc0/subnet0)->(subnet test-vpc0/subnet1); allowed-protocols[1]
+  # Internal. response (exact inverse) to required-connections[0]: (subnet test-vpc0/subnet0)->(subnet test-vpc0/subnet1); allowed-protocols[1]
   rules {
     name        = "rule3"
     action      = "allow"
@@ -51,7 +51,7 @@ resource "ibm_is_network_acl" "test-vpc0--subnet0" {
     source      = "10.240.0.0/24"
     destination = "10.240.9.0/24"
   }
-  # Internal. response to required-connections[4]: (subnet test-vpc0/subnet0)->(subnet test-vpc0/subnet5); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[4]: (subnet test-vpc0/subnet0)->(subnet test-vpc0/subnet5); allowed-protocols[0]
   rules {
     name        = "rule5"
     action      = "allow"
@@ -76,7 +76,7 @@ resource "ibm_is_network_acl" "test-vpc0--subnet1" {
     tcp {
     }
   }
-  # Internal. response to required-connections[0]: (subnet test-vpc0/subnet0)->(subnet test-vpc0/subnet1); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[0]: (subnet test-vpc0/subnet0)->(subnet test-vpc0/subnet1); allowed-protocols[0]
   rules {
     name        = "rule1"
     action      = "allow"
@@ -96,7 +96,7 @@ resource "ibm_is_network_acl" "test-vpc0--subnet1" {
     icmp {
     }
   }
-  # Internal. response to required-connections[0]: (subnet test-vpc0/subnet0)->(subnet test-vpc0/subnet1); allowed-protocols[1]
+  # Internal. response (exact inverse) to required-connections[0]: (subnet test-vpc0/subnet0)->(subnet test-vpc0/subnet1); allowed-protocols[1]
   rules {
     name        = "rule3"
     action      = "allow"
@@ -125,7 +125,7 @@ resource "ibm_is_network_acl" "test-vpc0--subnet2" {
       port_max = 8080
     }
   }
-  # Internal. response to required-connections[1]: (subnet test-vpc0/subnet2)->(subnet test-vpc0/subnet3); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[1]: (subnet test-vpc0/subnet2)->(subnet test-vpc0/subnet3); allowed-protocols[0]
   rules {
     name        = "rule1"
     action      = "allow"
@@ -168,7 +168,7 @@ resource "ibm_is_network_acl" "test-vpc0--subnet3" {
       port_max = 8080
     }
   }
-  # Internal. response to required-connections[1]: (subnet test-vpc0/subnet2)->(subnet test-vpc0/subnet3); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[1]: (subnet test-vpc0/subnet2)->(subnet test-vpc0/subnet3); allowed-protocols[0]
   rules {
     name        = "rule1"
     action      = "allow"
@@ -210,7 +210,7 @@ resource "ibm_is_network_acl" "test-vpc0--subnet4" {
       type = 15
     }
   }
-  # Internal. response to required-connections[2]: (subnet test-vpc0/subnet4)->(subnet test-vpc0/subnet5); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[2]: (subnet test-vpc0/subnet4)->(subnet test-vpc0/subnet5); allowed-protocols[0]
   rules {
     name        = "rule1"
     action      = "allow"
@@ -249,7 +249,7 @@ resource "ibm_is_network_acl" "test-vpc0--subnet5" {
       type = 15
     }
   }
-  # Internal. response to required-connections[2]: (subnet test-vpc0/subnet4)->(subnet test-vpc0/subnet5); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[2]: (subnet test-vpc0/subnet4)->(subnet test-vpc0/subnet5); allowed-protocols[0]
   rules {
     name        = "rule1"
     action      = "allow"
@@ -278,7 +278,7 @@ resource "ibm_is_network_acl" "test-vpc0--subnet5" {
     source      = "10.240.0.0/24"
     destination = "10.240.9.0/24"
   }
-  # Internal. response to required-connections[4]: (subnet test-vpc0/subnet0)->(subnet test-vpc0/subnet5); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[4]: (subnet test-vpc0/subnet0)->(subnet test-vpc0/subnet5); allowed-protocols[0]
   rules {
     name        = "rule4"
     action      = "allow"
@@ -34,7 +34,7 @@ resource "ibm_is_network_acl" "testacl5-vpc--sub1-2" {
     source      = "10.240.2.0/24"
     destination = "10.240.2.0/23"
   }
-  # Internal. response to required-connections[0]: (segment cidrSegment)->(segment cidrSegment); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[0]: (segment cidrSegment)->(segment cidrSegment); allowed-protocols[0]
   rules {
     name        = "rule1"
     action      = "allow"
@@ -50,7 +50,7 @@ resource "ibm_is_network_acl" "testacl5-vpc--sub1-2" {
     source      = "10.240.2.0/24"
     destination = "10.240.64.0/24"
   }
-  # Internal. response to required-connections[1]: (segment cidrSegment)->(segment subnetSegment); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[1]: (segment cidrSegment)->(segment subnetSegment); allowed-protocols[0]
   rules {
     name        = "rule3"
     action      = "allow"
@@ -66,7 +66,7 @@ resource "ibm_is_network_acl" "testacl5-vpc--sub1-2" {
     source      = "10.240.2.0/24"
     destination = "10.240.65.0/24"
   }
-  # Internal. response to required-connections[1]: (segment cidrSegment)->(segment subnetSegment); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[1]: (segment cidrSegment)->(segment subnetSegment); allowed-protocols[0]
   rules {
     name        = "rule5"
     action      = "allow"
@@ -89,7 +89,7 @@ resource "ibm_is_network_acl" "testacl5-vpc--sub1-3" {
     source      = "10.240.3.0/24"
     destination = "10.240.2.0/23"
   }
-  # Internal. response to required-connections[0]: (segment cidrSegment)->(segment cidrSegment); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[0]: (segment cidrSegment)->(segment cidrSegment); allowed-protocols[0]
   rules {
     name        = "rule1"
     action      = "allow"
@@ -105,7 +105,7 @@ resource "ibm_is_network_acl" "testacl5-vpc--sub1-3" {
     source      = "10.240.3.0/24"
     destination = "10.240.64.0/24"
   }
-  # Internal. response to required-connections[1]: (segment cidrSegment)->(segment subnetSegment); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[1]: (segment cidrSegment)->(segment subnetSegment); allowed-protocols[0]
   rules {
     name        = "rule3"
     action      = "allow"
@@ -121,7 +121,7 @@ resource "ibm_is_network_acl" "testacl5-vpc--sub1-3" {
     source      = "10.240.3.0/24"
     destination = "10.240.65.0/24"
   }
-  # Internal. response to required-connections[1]: (segment cidrSegment)->(segment subnetSegment); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[1]: (segment cidrSegment)->(segment subnetSegment); allowed-protocols[0]
   rules {
     name        = "rule5"
     action      = "allow"
@@ -144,7 +144,7 @@ resource "ibm_is_network_acl" "testacl5-vpc--sub2-1" {
     source      = "10.240.2.0/23"
     destination = "10.240.64.0/24"
   }
-  # Internal. response to required-connections[1]: (segment cidrSegment)->(segment subnetSegment); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[1]: (segment cidrSegment)->(segment subnetSegment); allowed-protocols[0]
   rules {
     name        = "rule1"
     action      = "allow"
@@ -160,7 +160,7 @@ resource "ibm_is_network_acl" "testacl5-vpc--sub2-1" {
     source      = "10.240.64.0/24"
     destination = "10.240.65.0/24"
   }
-  # Internal. response to required-connections[2]: (segment subnetSegment)->(segment subnetSegment); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[2]: (segment subnetSegment)->(segment subnetSegment); allowed-protocols[0]
   rules {
     name        = "rule3"
     action      = "allow"
@@ -183,7 +183,7 @@ resource "ibm_is_network_acl" "testacl5-vpc--sub2-2" {
     source      = "10.240.2.0/23"
     destination = "10.240.65.0/24"
   }
-  # Internal. response to required-connections[1]: (segment cidrSegment)->(segment subnetSegment); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[1]: (segment cidrSegment)->(segment subnetSegment); allowed-protocols[0]
   rules {
     name        = "rule1"
     action      = "allow"
@@ -199,7 +199,7 @@ resource "ibm_is_network_acl" "testacl5-vpc--sub2-2" {
     source      = "10.240.65.0/24"
     destination = "10.240.64.0/24"
   }
-  # Internal. response to required-connections[2]: (segment subnetSegment)->(segment subnetSegment); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[2]: (segment subnetSegment)->(segment subnetSegment); allowed-protocols[0]
   rules {
     name        = "rule3"
     action      = "allow"
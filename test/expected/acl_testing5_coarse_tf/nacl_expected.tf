@@ -0,0 +1,281 @@
+# Attached subnets: testacl5-vpc/sub1-1
+resource "ibm_is_network_acl" "testacl5-vpc--sub1-1" {
+  name           = "testacl5-vpc--sub1-1"
+  resource_group = local.acl_synth_resource_group_id
+  vpc            = local.acl_synth_testacl5-vpc_id
+  # External. response (exact inverse) to required-connections[1]: (segment need-dns)->(external dns); allowed-protocols[0]
+  rules {
+    name        = "rule0"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "8.8.8.8"
+    destination = "10.240.1.0/24"
+  }
+  # Internal. response (exact inverse) to required-connections[3]: (subnet testacl5-vpc/sub1-1)->(subnet testacl5-vpc/sub1-2); allowed-protocols[0] | Internal. response (exact inverse) to required-connections[4]: (subnet testacl5-vpc/sub1-1)->(subnet testacl5-vpc/sub1-3); allowed-protocols[0]
+  rules {
+    name        = "rule1"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "10.240.2.0/23"
+    destination = "10.240.1.0/24"
+  }
+  # Internal. response (exact inverse) to required-connections[0]: (segment need-dns)->(segment need-dns); allowed-protocols[0]
+  rules {
+    name        = "rule2"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "10.240.64.0/24"
+    destination = "10.240.1.0/24"
+  }
+  # Internal. response (exact inverse) to required-connections[2]: (segment need-dns)->(subnet testacl5-vpc/sub3-1); allowed-protocols[0]
+  rules {
+    name        = "rule3"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "10.240.128.0/24"
+    destination = "10.240.1.0/24"
+  }
+  # External. required-connections[1]: (segment need-dns)->(external dns); allowed-protocols[0]
+  rules {
+    name        = "rule4"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "10.240.1.0/24"
+    destination = "8.8.8.8"
+  }
+  # Internal. required-connections[3]: (subnet testacl5-vpc/sub1-1)->(subnet testacl5-vpc/sub1-2); allowed-protocols[0] | Internal. required-connections[4]: (subnet testacl5-vpc/sub1-1)->(subnet testacl5-vpc/sub1-3); allowed-protocols[0]
+  rules {
+    name        = "rule5"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "10.240.1.0/24"
+    destination = "10.240.2.0/23"
+  }
+  # Internal. required-connections[0]: (segment need-dns)->(segment need-dns); allowed-protocols[0]
+  rules {
+    name        = "rule6"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "10.240.1.0/24"
+    destination = "10.240.64.0/24"
+  }
+  # Internal. required-connections[2]: (segment need-dns)->(subnet testacl5-vpc/sub3-1); allowed-protocols[0]
+  rules {
+    name        = "rule7"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "10.240.1.0/24"
+    destination = "10.240.128.0/24"
+  }
+}
+
+# Attached subnets: testacl5-vpc/sub1-2
+resource "ibm_is_network_acl" "testacl5-vpc--sub1-2" {
+  name           = "testacl5-vpc--sub1-2"
+  resource_group = local.acl_synth_resource_group_id
+  vpc            = local.acl_synth_testacl5-vpc_id
+  # Internal. required-connections[3]: (subnet testacl5-vpc/sub1-1)->(subnet testacl5-vpc/sub1-2); allowed-protocols[0]
+  rules {
+    name        = "rule0"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "10.240.1.0/24"
+    destination = "10.240.2.0/24"
+  }
+  # Internal. response (exact inverse) to required-connections[5]: (subnet testacl5-vpc/sub1-2)->(subnet testacl5-vpc/sub1-3); allowed-protocols[0]
+  rules {
+    name        = "rule1"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "10.240.3.0/24"
+    destination = "10.240.2.0/24"
+  }
+  # Internal. response (exact inverse) to required-connections[3]: (subnet testacl5-vpc/sub1-1)->(subnet testacl5-vpc/sub1-2); allowed-protocols[0]
+  rules {
+    name        = "rule2"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "10.240.2.0/24"
+    destination = "10.240.1.0/24"
+  }
+  # Internal. required-connections[5]: (subnet testacl5-vpc/sub1-2)->(subnet testacl5-vpc/sub1-3); allowed-protocols[0]
+  rules {
+    name        = "rule3"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "10.240.2.0/24"
+    destination = "10.240.3.0/24"
+  }
+}
+
+# Attached subnets: testacl5-vpc/sub1-3
+resource "ibm_is_network_acl" "testacl5-vpc--sub1-3" {
+  name           = "testacl5-vpc--sub1-3"
+  resource_group = local.acl_synth_resource_group_id
+  vpc            = local.acl_synth_testacl5-vpc_id
+  # Internal. required-connections[4]: (subnet testacl5-vpc/sub1-1)->(subnet testacl5-vpc/sub1-3); allowed-protocols[0]
+  rules {
+    name        = "rule0"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "10.240.1.0/24"
+    destination = "10.240.3.0/24"
+  }
+  # Internal. required-connections[5]: (subnet testacl5-vpc/sub1-2)->(subnet testacl5-vpc/sub1-3); allowed-protocols[0]
+  rules {
+    name        = "rule1"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "10.240.2.0/24"
+    destination = "10.240.3.0/24"
+  }
+  # Internal. response (exact inverse) to required-connections[4]: (subnet testacl5-vpc/sub1-1)->(subnet testacl5-vpc/sub1-3); allowed-protocols[0]
+  rules {
+    name        = "rule2"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "10.240.3.0/24"
+    destination = "10.240.1.0/24"
+  }
+  # Internal. response (exact inverse) to required-connections[5]: (subnet testacl5-vpc/sub1-2)->(subnet testacl5-vpc/sub1-3); allowed-protocols[0]
+  rules {
+    name        = "rule3"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "10.240.3.0/24"
+    destination = "10.240.2.0/24"
+  }
+}
+
+# Attached subnets: testacl5-vpc/sub2-1
+resource "ibm_is_network_acl" "testacl5-vpc--sub2-1" {
+  name           = "testacl5-vpc--sub2-1"
+  resource_group = local.acl_synth_resource_group_id
+  vpc            = local.acl_synth_testacl5-vpc_id
+  # External. response (exact inverse) to required-connections[1]: (segment need-dns)->(external dns); allowed-protocols[0]
+  rules {
+    name        = "rule0"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "8.8.8.8"
+    destination = "10.240.64.0/24"
+  }
+  # Internal. response (exact inverse) to required-connections[0]: (segment need-dns)->(segment need-dns); allowed-protocols[0]
+  rules {
+    name        = "rule1"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "10.240.1.0/24"
+    destination = "10.240.64.0/24"
+  }
+  # Internal. response (exact inverse) to required-connections[6]: (subnet testacl5-vpc/sub2-1)->(subnet testacl5-vpc/sub2-2); allowed-protocols[0]
+  rules {
+    name        = "rule2"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "10.240.65.0/24"
+    destination = "10.240.64.0/24"
+  }
+  # Internal. response (exact inverse) to required-connections[2]: (segment need-dns)->(subnet testacl5-vpc/sub3-1); allowed-protocols[0]
+  rules {
+    name        = "rule3"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "10.240.128.0/24"
+    destination = "10.240.64.0/24"
+  }
+  # External. required-connections[1]: (segment need-dns)->(external dns); allowed-protocols[0]
+  rules {
+    name        = "rule4"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "10.240.64.0/24"
+    destination = "8.8.8.8"
+  }
+  # Internal. required-connections[0]: (segment need-dns)->(segment need-dns); allowed-protocols[0]
+  rules {
+    name        = "rule5"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "10.240.64.0/24"
+    destination = "10.240.1.0/24"
+  }
+  # Internal. required-connections[6]: (subnet testacl5-vpc/sub2-1)->(subnet testacl5-vpc/sub2-2); allowed-protocols[0]
+  rules {
+    name        = "rule6"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "10.240.64.0/24"
+    destination = "10.240.65.0/24"
+  }
+  # Internal. required-connections[2]: (segment need-dns)->(subnet testacl5-vpc/sub3-1); allowed-protocols[0]
+  rules {
+    name        = "rule7"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "10.240.64.0/24"
+    destination = "10.240.128.0/24"
+  }
+}
+
+# Attached subnets: testacl5-vpc/sub2-2
+resource "ibm_is_network_acl" "testacl5-vpc--sub2-2" {
+  name           = "testacl5-vpc--sub2-2"
+  resource_group = local.acl_synth_resource_group_id
+  vpc            = local.acl_synth_testacl5-vpc_id
+  # Internal. required-connections[6]: (subnet testacl5-vpc/sub2-1)->(subnet testacl5-vpc/sub2-2); allowed-protocols[0]
+  rules {
+    name        = "rule0"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "10.240.64.0/24"
+    destination = "10.240.65.0/24"
+  }
+  # Internal. response (exact inverse) to required-connections[6]: (subnet testacl5-vpc/sub2-1)->(subnet testacl5-vpc/sub2-2); allowed-protocols[0]
+  rules {
+    name        = "rule1"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "10.240.65.0/24"
+    destination = "10.240.64.0/24"
+  }
+}
+
+# Attached subnets: testacl5-vpc/sub3-1
+resource "ibm_is_network_acl" "testacl5-vpc--sub3-1" {
+  name           = "testacl5-vpc--sub3-1"
+  resource_group = local.acl_synth_resource_group_id
+  vpc            = local.acl_synth_testacl5-vpc_id
+  # Internal. required-connections[2]: (segment need-dns)->(subnet testacl5-vpc/sub3-1); allowed-protocols[0]
+  rules {
+    name        = "rule0"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "10.240.1.0/24"
+    destination = "10.240.128.0/24"
+  }
+  # Internal. required-connections[2]: (segment need-dns)->(subnet testacl5-vpc/sub3-1); allowed-protocols[0]
+  rules {
+    name        = "rule1"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "10.240.64.0/24"
+    destination = "10.240.128.0/24"
+  }
+  # Internal. response (exact inverse) to required-connections[2]: (segment need-dns)->(subnet testacl5-vpc/sub3-1); allowed-protocols[0]
+  rules {
+    name        = "rule2"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "10.240.128.0/24"
+    destination = "10.240.1.0/24"
+  }
+  # Internal. response (exact inverse) to required-connections[2]: (segment need-dns)->(subnet testacl5-vpc/sub3-1); allowed-protocols[0]
+  rules {
+    name        = "rule3"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "10.240.128.0/24"
+    destination = "10.240.64.0/24"
+  }
+}
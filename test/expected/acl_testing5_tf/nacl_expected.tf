@@ -11,7 +11,7 @@ resource "ibm_is_network_acl" "testacl5-vpc--sub1-1" {
     source      = "10.240.1.0/24"
     destination = "10.240.64.0/24"
   }
-  # Internal. response to required-connections[0]: (segment need-dns)->(segment need-dns); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[0]: (segment need-dns)->(segment need-dns); allowed-protocols[0]
   rules {
     name        = "rule1"
     action      = "allow"
@@ -30,7 +30,7 @@ resource "ibm_is_network_acl" "testacl5-vpc--sub1-1" {
       type = 0
     }
   }
-  # Internal. response to required-connections[2]: (segment need-dns)->(subnet testacl5-vpc/sub3-1); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[2]: (segment need-dns)->(subnet testacl5-vpc/sub3-1); allowed-protocols[0]
   rules {
     name        = "rule3"
     action      = "allow"
@@ -51,7 +51,7 @@ resource "ibm_is_network_acl" "testacl5-vpc--sub1-1" {
     tcp {
     }
   }
-  # Internal. response to required-connections[3]: (subnet testacl5-vpc/sub1-1)->(subnet testacl5-vpc/sub1-2); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[3]: (subnet testacl5-vpc/sub1-1)->(subnet testacl5-vpc/sub1-2); allowed-protocols[0]
   rules {
     name        = "rule5"
     action      = "allow"
@@ -71,7 +71,7 @@ resource "ibm_is_network_acl" "testacl5-vpc--sub1-1" {
     tcp {
     }
   }
-  # Internal. response to required-connections[4]: (subnet testacl5-vpc/sub1-1)->(subnet testacl5-vpc/sub1-3); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[4]: (subnet testacl5-vpc/sub1-1)->(subnet testacl5-vpc/sub1-3); allowed-protocols[0]
   rules {
     name        = "rule7"
     action      = "allow"
@@ -81,153 +81,41 @@ resource "ibm_is_network_acl" "testacl5-vpc--sub1-1" {
     tcp {
     }
   }
-  # Deny other internal communication; see rfc1918#3; item 0,0
+  # Deny other internal communication; internal address space item 0
   rules {
     name        = "rule8"
     action      = "deny"
     direction   = "outbound"
-    source      = "10.0.0.0/8"
-    destination = "10.0.0.0/8"
+    source      = "0.0.0.0/0"
+    destination = "10.240.0.0/17"
   }
-  # Deny other internal communication; see rfc1918#3; item 0,0
+  # Deny other internal communication; internal address space item 0
   rules {
     name        = "rule9"
     action      = "deny"
     direction   = "inbound"
-    source      = "10.0.0.0/8"
-    destination = "10.0.0.0/8"
+    source      = "10.240.0.0/17"
+    destination = "0.0.0.0/0"
   }
-  # Deny other internal communication; see rfc1918#3; item 0,1
+  # Deny other internal communication; internal address space item 1
   rules {
     name        = "rule10"
     action      = "deny"
     direction   = "outbound"
-    source      = "10.0.0.0/8"
-    destination = "172.16.0.0/12"
+    source      = "0.0.0.0/0"
+    destination = "10.240.128.0/18"
   }
-  # Deny other internal communication; see rfc1918#3; item 0,1
+  # Deny other internal communication; internal address space item 1
   rules {
     name        = "rule11"
     action      = "deny"
     direction   = "inbound"
-    source      = "172.16.0.0/12"
-    destination = "10.0.0.0/8"
-  }
-  # Deny other internal communication; see rfc1918#3; item 0,2
-  rules {
-    name        = "rule12"
-    action      = "deny"
-    direction   = "outbound"
-    source      = "10.0.0.0/8"
-    destination = "192.168.0.0/16"
-  }
-  # Deny other internal communication; see rfc1918#3; item 0,2
-  rules {
-    name        = "rule13"
-    action      = "deny"
-    direction   = "inbound"
-    source      = "192.168.0.0/16"
-    destination = "10.0.0.0/8"
-  }
-  # Deny other internal communication; see rfc1918#3; item 1,0
-  rules {
-    name        = "rule14"
-    action      = "deny"
-    direction   = "outbound"
-    source      = "172.16.0.0/12"
-    destination = "10.0.0.0/8"
-  }
-  # Deny other internal communication; see rfc1918#3; item 1,0
-  rules {
-    name        = "rule15"
-    action      = "deny"
-    direction   = "inbound"
-    source      = "10.0.0.0/8"
-    destination = "172.16.0.0/12"
-  }
-  # Deny other internal communication; see rfc1918#3; item 1,1
-  rules {
-    name        = "rule16"
-    action      = "deny"
-    direction   = "outbound"
-    source      = "172.16.0.0/12"
-    destination = "172.16.0.0/12"
-  }
-  # Deny other internal communication; see rfc1918#3; item 1,1
-  rules {
-    name        = "rule17"
-    action      = "deny"
-    direction   = "inbound"
-    source      = "172.16.0.0/12"
-    destination = "172.16.0.0/12"
-  }
-  # Deny other internal communication; see rfc1918#3; item 1,2
-  rules {
-    name        = "rule18"
-    action      = "deny"
-    direction   = "outbound"
-    source      = "172.16.0.0/12"
-    destination = "192.168.0.0/16"
-  }
-  # Deny other internal communication; see rfc1918#3; item 1,2
-  rules {
-    name        = "rule19"
-    action      = "deny"
-    direction   = "inbound"
-    source      = "192.168.0.0/16"
-    destination = "172.16.0.0/12"
-  }
-  # Deny other internal communication; see rfc1918#3; item 2,0
-  rules {
-    name        = "rule20"
-    action      = "deny"
-    direction   = "outbound"
-    source      = "192.168.0.0/16"
-    destination = "10.0.0.0/8"
-  }
-  # Deny other internal communication; see rfc1918#3; item 2,0
-  rules {
-    name        = "rule21"
-    action      = "deny"
-    direction   = "inbound"
-    source      = "10.0.0.0/8"
-    destination = "192.168.0.0/16"
-  }
-  # Deny other internal communication; see rfc1918#3; item 2,1
-  rules {
-    name        = "rule22"
-    action      = "deny"
-    direction   = "outbound"
-    source      = "192.168.0.0/16"
-    destination = "172.16.0.0/12"
-  }
-  # Deny other internal communication; see rfc1918#3; item 2,1
-  rules {
-    name        = "rule23"
-    action      = "deny"
-    direction   = "inbound"
-    source      = "172.16.0.0/12"
-    destination = "192.168.0.0/16"
-  }
-  # Deny other internal communication; see rfc1918#3; item 2,2
-  rules {
-    name        = "rule24"
-    action      = "deny"
-    direction   = "outbound"
-    source      = "192.168.0.0/16"
-    destination = "192.168.0.0/16"
-  }
-  # Deny other internal communication; see rfc1918#3; item 2,2
-  rules {
-    name        = "rule25"
-    action      = "deny"
-    direction   = "inbound"
-    source      = "192.168.0.0/16"
-    destination = "192.168.0.0/16"
+    source      = "10.240.128.0/18"
+    destination = "0.0.0.0/0"
   }
   # External. required-connections[1]: (segment need-dns)->(external dns); allowed-protocols[0]
   rules {
-    name        = "rule26"
+    name        = "rule12"
     action      = "allow"
     direction   = "outbound"
     source      = "10.240.1.0/24"
@@ -254,7 +142,7 @@ resource "ibm_is_network_acl" "testacl5-vpc--sub1-2" {
     tcp {
     }
   }
-  # Internal. response to required-connections[3]: (subnet testacl5-vpc/sub1-1)->(subnet testacl5-vpc/sub1-2); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[3]: (subnet testacl5-vpc/sub1-1)->(subnet testacl5-vpc/sub1-2); allowed-protocols[0]
   rules {
     name        = "rule1"
     action      = "allow"
@@ -274,7 +162,7 @@ resource "ibm_is_network_acl" "testacl5-vpc--sub1-2" {
     tcp {
     }
   }
-  # Internal. response to required-connections[5]: (subnet testacl5-vpc/sub1-2)->(subnet testacl5-vpc/sub1-3); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[5]: (subnet testacl5-vpc/sub1-2)->(subnet testacl5-vpc/sub1-3); allowed-protocols[0]
   rules {
     name        = "rule3"
     action      = "allow"
@@ -301,7 +189,7 @@ resource "ibm_is_network_acl" "testacl5-vpc--sub1-3" {
     tcp {
     }
   }
-  # Internal. response to required-connections[4]: (subnet testacl5-vpc/sub1-1)->(subnet testacl5-vpc/sub1-3); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[4]: (subnet testacl5-vpc/sub1-1)->(subnet testacl5-vpc/sub1-3); allowed-protocols[0]
   rules {
     name        = "rule1"
     action      = "allow"
@@ -321,7 +209,7 @@ resource "ibm_is_network_acl" "testacl5-vpc--sub1-3" {
     tcp {
     }
   }
-  # Internal. response to required-connections[5]: (subnet testacl5-vpc/sub1-2)->(subnet testacl5-vpc/sub1-3); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[5]: (subnet testacl5-vpc/sub1-2)->(subnet testacl5-vpc/sub1-3); allowed-protocols[0]
   rules {
     name        = "rule3"
     action      = "allow"
@@ -346,7 +234,7 @@ resource "ibm_is_network_acl" "testacl5-vpc--sub2-1" {
     source      = "10.240.64.0/24"
     destination = "10.240.1.0/24"
   }
-  # Internal. response to required-connections[0]: (segment need-dns)->(segment need-dns); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[0]: (segment need-dns)->(segment need-dns); allowed-protocols[0]
   rules {
     name        = "rule1"
     action      = "allow"
@@ -365,7 +253,7 @@ resource "ibm_is_network_acl" "testacl5-vpc--sub2-1" {
       type = 0
     }
   }
-  # Internal. response to required-connections[2]: (segment need-dns)->(subnet testacl5-vpc/sub3-1); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[2]: (segment need-dns)->(subnet testacl5-vpc/sub3-1); allowed-protocols[0]
   rules {
     name        = "rule3"
     action      = "allow"
@@ -384,7 +272,7 @@ resource "ibm_is_network_acl" "testacl5-vpc--sub2-1" {
     source      = "10.240.64.0/24"
     destination = "10.240.65.0/24"
   }
-  # Internal. response to required-connections[6]: (subnet testacl5-vpc/sub2-1)->(subnet testacl5-vpc/sub2-2); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[6]: (subnet testacl5-vpc/sub2-1)->(subnet testacl5-vpc/sub2-2); allowed-protocols[0]
   rules {
     name        = "rule5"
     action      = "allow"
@@ -404,7 +292,7 @@ resource "ibm_is_network_acl" "testacl5-vpc--sub2-1" {
       port_max = 443
     }
   }
-  # Internal. response to required-connections[7]: (subnet testacl5-vpc/sub3-1)->(subnet testacl5-vpc/sub2-1); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[7]: (subnet testacl5-vpc/sub3-1)->(subnet testacl5-vpc/sub2-1); allowed-protocols[0]
   rules {
     name        = "rule7"
     action      = "allow"
@@ -416,153 +304,41 @@ resource "ibm_is_network_acl" "testacl5-vpc--sub2-1" {
       source_port_max = 443
     }
   }
-  # Deny other internal communication; see rfc1918#3; item 0,0
+  # Deny other internal communication; internal address space item 0
   rules {
     name        = "rule8"
     action      = "deny"
     direction   = "outbound"
-    source      = "10.0.0.0/8"
-    destination = "10.0.0.0/8"
+    source      = "0.0.0.0/0"
+    destination = "10.240.0.0/17"
   }
-  # Deny other internal communication; see rfc1918#3; item 0,0
+  # Deny other internal communication; internal address space item 0
   rules {
     name        = "rule9"
     action      = "deny"
     direction   = "inbound"
-    source      = "10.0.0.0/8"
-    destination = "10.0.0.0/8"
+    source      = "10.240.0.0/17"
+    destination = "0.0.0.0/0"
   }
-  # Deny other internal communication; see rfc1918#3; item 0,1
+  # Deny other internal communication; internal address space item 1
   rules {
     name        = "rule10"
     action      = "deny"
     direction   = "outbound"
-    source      = "10.0.0.0/8"
-    destination = "172.16.0.0/12"
+    source      = "0.0.0.0/0"
+    destination = "10.240.128.0/18"
   }
-  # Deny other internal communication; see rfc1918#3; item 0,1
+  # Deny other internal communication; internal address space item 1
   rules {
     name        = "rule11"
     action      = "deny"
     direction   = "inbound"
-    source      = "172.16.0.0/12"
-    destination = "10.0.0.0/8"
-  }
-  # Deny other internal communication; see rfc1918#3; item 0,2
-  rules {
-    name        = "rule12"
-    action      = "deny"
-    direction   = "outbound"
-    source      = "10.0.0.0/8"
-    destination = "192.168.0.0/16"
-  }
-  # Deny other internal communication; see rfc1918#3; item 0,2
-  rules {
-    name        = "rule13"
-    action      = "deny"
-    direction   = "inbound"
-    source      = "192.168.0.0/16"
-    destination = "10.0.0.0/8"
-  }
-  # Deny other internal communication; see rfc1918#3; item 1,0
-  rules {
-    name        = "rule14"
-    action      = "deny"
-    direction   = "outbound"
-    source      = "172.16.0.0/12"
-    destination = "10.0.0.0/8"
-  }
-  # Deny other internal communication; see rfc1918#3; item 1,0
-  rules {
-    name        = "rule15"
-    action      = "deny"
-    direction   = "inbound"
-    source      = "10.0.0.0/8"
-    destination = "172.16.0.0/12"
-  }
-  # Deny other internal communication; see rfc1918#3; item 1,1
-  rules {
-    name        = "rule16"
-    action      = "deny"
-    direction   = "outbound"
-    source      = "172.16.0.0/12"
-    destination = "172.16.0.0/12"
-  }
-  # Deny other internal communication; see rfc1918#3; item 1,1
-  rules {
-    name        = "rule17"
-    action      = "deny"
-    direction   = "inbound"
-    source      = "172.16.0.0/12"
-    destination = "172.16.0.0/12"
-  }
-  # Deny other internal communication; see rfc1918#3; item 1,2
-  rules {
-    name        = "rule18"
-    action      = "deny"
-    direction   = "outbound"
-    source      = "172.16.0.0/12"
-    destination = "192.168.0.0/16"
-  }
-  # Deny other internal communication; see rfc1918#3; item 1,2
-  rules {
-    name        = "rule19"
-    action      = "deny"
-    direction   = "inbound"
-    source      = "192.168.0.0/16"
-    destination = "172.16.0.0/12"
-  }
-  # Deny other internal communication; see rfc1918#3; item 2,0
-  rules {
-    name        = "rule20"
-    action      = "deny"
-    direction   = "outbound"
-    source      = "192.168.0.0/16"
-    destination = "10.0.0.0/8"
-  }
-  # Deny other internal communication; see rfc1918#3; item 2,0
-  rules {
-    name        = "rule21"
-    action      = "deny"
-    direction   = "inbound"
-    source      = "10.0.0.0/8"
-    destination = "192.168.0.0/16"
-  }
-  # Deny other internal communication; see rfc1918#3; item 2,1
-  rules {
-    name        = "rule22"
-    action      = "deny"
-    direction   = "outbound"
-    source      = "192.168.0.0/16"
-    destination = "172.16.0.0/12"
-  }
-  # Deny other internal communication; see rfc1918#3; item 2,1
-  rules {
-    name        = "rule23"
-    action      = "deny"
-    direction   = "inbound"
-    source      = "172.16.0.0/12"
-    destination = "192.168.0.0/16"
-  }
-  # Deny other internal communication; see rfc1918#3; item 2,2
-  rules {
-    name        = "rule24"
-    action      = "deny"
-    direction   = "outbound"
-    source      = "192.168.0.0/16"
-    destination = "192.168.0.0/16"
-  }
-  # Deny other internal communication; see rfc1918#3; item 2,2
-  rules {
-    name        = "rule25"
-    action      = "deny"
-    direction   = "inbound"
-    source      = "192.168.0.0/16"
-    destination = "192.168.0.0/16"
+    source      = "10.240.128.0/18"
+    destination = "0.0.0.0/0"
   }
   # External. required-connections[1]: (segment need-dns)->(external dns); allowed-protocols[0]
   rules {
-    name        = "rule26"
+    name        = "rule12"
     action      = "allow"
     direction   = "outbound"
     source      = "10.240.64.0/24"
@@ -587,7 +363,7 @@ resource "ibm_is_network_acl" "testacl5-vpc--sub2-2" {
     source      = "10.240.64.0/24"
     destination = "10.240.65.0/24"
   }
-  # Internal. response to required-connections[6]: (subnet testacl5-vpc/sub2-1)->(subnet testacl5-vpc/sub2-2); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[6]: (subnet testacl5-vpc/sub2-1)->(subnet testacl5-vpc/sub2-2); allowed-protocols[0]
   rules {
     name        = "rule1"
     action      = "allow"
@@ -613,7 +389,7 @@ resource "ibm_is_network_acl" "testacl5-vpc--sub3-1" {
       type = 0
     }
   }
-  # Internal. response to required-connections[2]: (segment need-dns)->(subnet testacl5-vpc/sub3-1); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[2]: (segment need-dns)->(subnet testacl5-vpc/sub3-1); allowed-protocols[0]
   rules {
     name        = "rule1"
     action      = "allow"
@@ -635,7 +411,7 @@ resource "ibm_is_network_acl" "testacl5-vpc--sub3-1" {
       type = 0
     }
   }
-  # Internal. response to required-connections[2]: (segment need-dns)->(subnet testacl5-vpc/sub3-1); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[2]: (segment need-dns)->(subnet testacl5-vpc/sub3-1); allowed-protocols[0]
   rules {
     name        = "rule3"
     action      = "allow"
@@ -658,7 +434,7 @@ resource "ibm_is_network_acl" "testacl5-vpc--sub3-1" {
       port_max = 443
     }
   }
-  # Internal. response to required-connections[7]: (subnet testacl5-vpc/sub3-1)->(subnet testacl5-vpc/sub2-1); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[7]: (subnet testacl5-vpc/sub3-1)->(subnet testacl5-vpc/sub2-1); allowed-protocols[0]
   rules {
     name        = "rule5"
     action      = "allow"
@@ -11,7 +11,7 @@ resource "ibm_is_network_acl" "testacl5-vpc--singleACL" {
     source      = "10.240.1.0/24"
     destination = "10.240.64.0/24"
   }
-  # Internal. response to required-connections[0]: (segment need-dns)->(segment need-dns); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[0]: (segment need-dns)->(segment need-dns); allowed-protocols[0]
   rules {
     name        = "rule1"
     action      = "allow"
@@ -27,7 +27,7 @@ resource "ibm_is_network_acl" "testacl5-vpc--singleACL" {
     source      = "10.240.64.0/24"
     destination = "10.240.1.0/24"
   }
-  # Internal. response to required-connections[0]: (segment need-dns)->(segment need-dns); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[0]: (segment need-dns)->(segment need-dns); allowed-protocols[0]
   rules {
     name        = "rule3"
     action      = "allow"
@@ -46,7 +46,7 @@ resource "ibm_is_network_acl" "testacl5-vpc--singleACL" {
       type = 0
     }
   }
-  # Internal. response to required-connections[2]: (segment need-dns)->(subnet testacl5-vpc/sub3-1); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[2]: (segment need-dns)->(subnet testacl5-vpc/sub3-1); allowed-protocols[0]
   rules {
     name        = "rule5"
     action      = "allow"
@@ -68,7 +68,7 @@ resource "ibm_is_network_acl" "testacl5-vpc--singleACL" {
       type = 0
     }
   }
-  # Internal. response to required-connections[2]: (segment need-dns)->(subnet testacl5-vpc/sub3-1); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[2]: (segment need-dns)->(subnet testacl5-vpc/sub3-1); allowed-protocols[0]
   rules {
     name        = "rule7"
     action      = "allow"
@@ -90,7 +90,7 @@ resource "ibm_is_network_acl" "testacl5-vpc--singleACL" {
       type = 0
     }
   }
-  # Internal. response to required-connections[2]: (segment need-dns)->(subnet testacl5-vpc/sub3-1); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[2]: (segment need-dns)->(subnet testacl5-vpc/sub3-1); allowed-protocols[0]
   rules {
     name        = "rule9"
     action      = "allow"
@@ -112,7 +112,7 @@ resource "ibm_is_network_acl" "testacl5-vpc--singleACL" {
       type = 0
     }
   }
-  # Internal. response to required-connections[2]: (segment need-dns)->(subnet testacl5-vpc/sub3-1); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[2]: (segment need-dns)->(subnet testacl5-vpc/sub3-1); allowed-protocols[0]
   rules {
     name        = "rule11"
     action      = "allow"
@@ -133,7 +133,7 @@ resource "ibm_is_network_acl" "testacl5-vpc--singleACL" {
     tcp {
     }
   }
-  # Internal. response to required-connections[3]: (subnet testacl5-vpc/sub1-1)->(subnet testacl5-vpc/sub1-2); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[3]: (subnet testacl5-vpc/sub1-1)->(subnet testacl5-vpc/sub1-2); allowed-protocols[0]
   rules {
     name        = "rule13"
     action      = "allow"
@@ -153,7 +153,7 @@ resource "ibm_is_network_acl" "testacl5-vpc--singleACL" {
     tcp {
     }
   }
-  # Internal. response to required-connections[3]: (subnet testacl5-vpc/sub1-1)->(subnet testacl5-vpc/sub1-2); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[3]: (subnet testacl5-vpc/sub1-1)->(subnet testacl5-vpc/sub1-2); allowed-protocols[0]
   rules {
     name        = "rule15"
     action      = "allow"
@@ -173,7 +173,7 @@ resource "ibm_is_network_acl" "testacl5-vpc--singleACL" {
     tcp {
     }
   }
-  # Internal. response to required-connections[4]: (subnet testacl5-vpc/sub1-1)->(subnet testacl5-vpc/sub1-3); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[4]: (subnet testacl5-vpc/sub1-1)->(subnet testacl5-vpc/sub1-3); allowed-protocols[0]
   rules {
     name        = "rule17"
     action      = "allow"
@@ -193,7 +193,7 @@ resource "ibm_is_network_acl" "testacl5-vpc--singleACL" {
     tcp {
     }
   }
-  # Internal. response to required-connections[4]: (subnet testacl5-vpc/sub1-1)->(subnet testacl5-vpc/sub1-3); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[4]: (subnet testacl5-vpc/sub1-1)->(subnet testacl5-vpc/sub1-3); allowed-protocols[0]
   rules {
     name        = "rule19"
     action      = "allow"
@@ -213,7 +213,7 @@ resource "ibm_is_network_acl" "testacl5-vpc--singleACL" {
     tcp {
     }
   }
-  # Internal. response to required-connections[5]: (subnet testacl5-vpc/sub1-2)->(subnet testacl5-vpc/sub1-3); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[5]: (subnet testacl5-vpc/sub1-2)->(subnet testacl5-vpc/sub1-3); allowed-protocols[0]
   rules {
     name        = "rule21"
     action      = "allow"
@@ -233,7 +233,7 @@ resource "ibm_is_network_acl" "testacl5-vpc--singleACL" {
     tcp {
     }
   }
-  # Internal. response to required-connections[5]: (subnet testacl5-vpc/sub1-2)->(subnet testacl5-vpc/sub1-3); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[5]: (subnet testacl5-vpc/sub1-2)->(subnet testacl5-vpc/sub1-3); allowed-protocols[0]
   rules {
     name        = "rule23"
     action      = "allow"
@@ -251,7 +251,7 @@ resource "ibm_is_network_acl" "testacl5-vpc--singleACL" {
     source      = "10.240.64.0/24"
     destination = "10.240.65.0/24"
   }
-  # Internal. response to required-connections[6]: (subnet testacl5-vpc/sub2-1)->(subnet testacl5-vpc/sub2-2); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[6]: (subnet testacl5-vpc/sub2-1)->(subnet testacl5-vpc/sub2-2); allowed-protocols[0]
   rules {
     name        = "rule25"
     action      = "allow"
@@ -267,7 +267,7 @@ resource "ibm_is_network_acl" "testacl5-vpc--singleACL" {
     source      = "10.240.64.0/24"
     destination = "10.240.65.0/24"
   }
-  # Internal. response to required-connections[6]: (subnet testacl5-vpc/sub2-1)->(subnet testacl5-vpc/sub2-2); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[6]: (subnet testacl5-vpc/sub2-1)->(subnet testacl5-vpc/sub2-2); allowed-protocols[0]
   rules {
     name        = "rule27"
     action      = "allow"
@@ -287,7 +287,7 @@ resource "ibm_is_network_acl" "testacl5-vpc--singleACL" {
       port_max = 443
     }
   }
-  # Internal. response to required-connections[7]: (subnet testacl5-vpc/sub3-1)->(subnet testacl5-vpc/sub2-1); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[7]: (subnet testacl5-vpc/sub3-1)->(subnet testacl5-vpc/sub2-1); allowed-protocols[0]
   rules {
     name        = "rule29"
     action      = "allow"
@@ -311,7 +311,7 @@ resource "ibm_is_network_acl" "testacl5-vpc--singleACL" {
       port_max = 443
     }
   }
-  # Internal. response to required-connections[7]: (subnet testacl5-vpc/sub3-1)->(subnet testacl5-vpc/sub2-1); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[7]: (subnet testacl5-vpc/sub3-1)->(subnet testacl5-vpc/sub2-1); allowed-protocols[0]
   rules {
     name        = "rule31"
     action      = "allow"
@@ -323,153 +323,41 @@ resource "ibm_is_network_acl" "testacl5-vpc--singleACL" {
       source_port_max = 443
     }
   }
-  # Deny other internal communication; see rfc1918#3; item 0,0
+  # Deny other internal communication; internal address space item 0
   rules {
     name        = "rule32"
     action      = "deny"
     direction   = "outbound"
-    source      = "10.0.0.0/8"
-    destination = "10.0.0.0/8"
+    source      = "0.0.0.0/0"
+    destination = "10.240.0.0/17"
   }
-  # Deny other internal communication; see rfc1918#3; item 0,0
+  # Deny other internal communication; internal address space item 0
   rules {
     name        = "rule33"
     action      = "deny"
     direction   = "inbound"
-    source      = "10.0.0.0/8"
-    destination = "10.0.0.0/8"
+    source      = "10.240.0.0/17"
+    destination = "0.0.0.0/0"
   }
-  # Deny other internal communication; see rfc1918#3; item 0,1
+  # Deny other internal communication; internal address space item 1
   rules {
     name        = "rule34"
     action      = "deny"
     direction   = "outbound"
-    source      = "10.0.0.0/8"
-    destination = "172.16.0.0/12"
+    source      = "0.0.0.0/0"
+    destination = "10.240.128.0/18"
   }
-  # Deny other internal communication; see rfc1918#3; item 0,1
+  # Deny other internal communication; internal address space item 1
   rules {
     name        = "rule35"
     action      = "deny"
     direction   = "inbound"
-    source      = "172.16.0.0/12"
-    destination = "10.0.0.0/8"
-  }
-  # Deny other internal communication; see rfc1918#3; item 0,2
-  rules {
-    name        = "rule36"
-    action      = "deny"
-    direction   = "outbound"
-    source      = "10.0.0.0/8"
-    destination = "192.168.0.0/16"
-  }
-  # Deny other internal communication; see rfc1918#3; item 0,2
-  rules {
-    name        = "rule37"
-    action      = "deny"
-    direction   = "inbound"
-    source      = "192.168.0.0/16"
-    destination = "10.0.0.0/8"
-  }
-  # Deny other internal communication; see rfc1918#3; item 1,0
-  rules {
-    name        = "rule38"
-    action      = "deny"
-    direction   = "outbound"
-    source      = "172.16.0.0/12"
-    destination = "10.0.0.0/8"
-  }
-  # Deny other internal communication; see rfc1918#3; item 1,0
-  rules {
-    name        = "rule39"
-    action      = "deny"
-    direction   = "inbound"
-    source      = "10.0.0.0/8"
-    destination = "172.16.0.0/12"
-  }
-  # Deny other internal communication; see rfc1918#3; item 1,1
-  rules {
-    name        = "rule40"
-    action      = "deny"
-    direction   = "outbound"
-    source      = "172.16.0.0/12"
-    destination = "172.16.0.0/12"
-  }
-  # Deny other internal communication; see rfc1918#3; item 1,1
-  rules {
-    name        = "rule41"
-    action      = "deny"
-    direction   = "inbound"
-    source      = "172.16.0.0/12"
-    destination = "172.16.0.0/12"
-  }
-  # Deny other internal communication; see rfc1918#3; item 1,2
-  rules {
-    name        = "rule42"
-    action      = "deny"
-    direction   = "outbound"
-    source      = "172.16.0.0/12"
-    destination = "192.168.0.0/16"
-  }
-  # Deny other internal communication; see rfc1918#3; item 1,2
-  rules {
-    name        = "rule43"
-    action      = "deny"
-    direction   = "inbound"
-    source      = "192.168.0.0/16"
-    destination = "172.16.0.0/12"
-  }
-  # Deny other internal communication; see rfc1918#3; item 2,0
-  rules {
-    name        = "rule44"
-    action      = "deny"
-    direction   = "outbound"
-    source      = "192.168.0.0/16"
-    destination = "10.0.0.0/8"
-  }
-  # Deny other internal communication; see rfc1918#3; item 2,0
-  rules {
-    name        = "rule45"
-    action      = "deny"
-    direction   = "inbound"
-    source      = "10.0.0.0/8"
-    destination = "192.168.0.0/16"
-  }
-  # Deny other internal communication; see rfc1918#3; item 2,1
-  rules {
-    name        = "rule46"
-    action      = "deny"
-    direction   = "outbound"
-    source      = "192.168.0.0/16"
-    destination = "172.16.0.0/12"
-  }
-  # Deny other internal communication; see rfc1918#3; item 2,1
-  rules {
-    name        = "rule47"
-    action      = "deny"
-    direction   = "inbound"
-    source      = "172.16.0.0/12"
-    destination = "192.168.0.0/16"
-  }
-  # Deny other internal communication; see rfc1918#3; item 2,2
-  rules {
-    name        = "rule48"
-    action      = "deny"
-    direction   = "outbound"
-    source      = "192.168.0.0/16"
-    destination = "192.168.0.0/16"
-  }
-  # Deny other internal communication; see rfc1918#3; item 2,2
-  rules {
-    name        = "rule49"
-    action      = "deny"
-    direction   = "inbound"
-    source      = "192.168.0.0/16"
-    destination = "192.168.0.0/16"
+    source      = "10.240.128.0/18"
+    destination = "0.0.0.0/0"
   }
   # External. required-connections[1]: (segment need-dns)->(external dns); allowed-protocols[0]
   rules {
-    name        = "rule50"
+    name        = "rule36"
     action      = "allow"
     direction   = "outbound"
     source      = "10.240.1.0/24"
@@ -481,7 +369,7 @@ resource "ibm_is_network_acl" "testacl5-vpc--singleACL" {
   }
   # External. required-connections[1]: (segment need-dns)->(external dns); allowed-protocols[0]
   rules {
-    name        = "rule51"
+    name        = "rule37"
     action      = "allow"
     direction   = "outbound"
     source      = "10.240.64.0/24"
@@ -11,7 +11,7 @@ resource "ibm_is_network_acl" "test-vpc0--subnet0" {
     source      = "10.240.0.0/24"
     destination = "10.240.4.0/24"
   }
-  # Internal. response to required-connections[0]: (segment segment1)->(segment segment1); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[0]: (segment segment1)->(segment segment1); allowed-protocols[0]
   rules {
     name        = "rule1"
     action      = "allow"
@@ -69,7 +69,7 @@ resource "ibm_is_network_acl" "test-vpc0--subnet2" {
     source      = "10.240.4.0/24"
     destination = "10.240.0.0/24"
   }
-  # Internal. response to required-connections[0]: (segment segment1)->(segment segment1); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[0]: (segment segment1)->(segment segment1); allowed-protocols[0]
   rules {
     name        = "rule1"
     action      = "allow"
@@ -14,7 +14,7 @@ resource "ibm_is_network_acl" "test-vpc1--subnet10" {
       type = 0
     }
   }
-  # Internal. response to required-connections[3]: (subnet test-vpc1/subnet10)->(subnet test-vpc1/subnet11); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[3]: (subnet test-vpc1/subnet10)->(subnet test-vpc1/subnet11); allowed-protocols[0]
   rules {
     name        = "rule1"
     action      = "allow"
@@ -43,7 +43,7 @@ resource "ibm_is_network_acl" "test-vpc1--subnet11" {
       type = 0
     }
   }
-  # Internal. response to required-connections[3]: (subnet test-vpc1/subnet10)->(subnet test-vpc1/subnet11); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[3]: (subnet test-vpc1/subnet10)->(subnet test-vpc1/subnet11); allowed-protocols[0]
   rules {
     name        = "rule1"
     action      = "allow"
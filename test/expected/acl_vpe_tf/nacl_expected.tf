@@ -11,7 +11,7 @@ resource "ibm_is_network_acl" "test-vpc--sub1" {
     source      = "10.240.64.0/24"
     destination = "10.240.0.0/24"
   }
-  # Internal. response to required-connections[1]: (vpe test-vpc/policydb-endpoint-gateway)->(subnet test-vpc/sub1); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[1]: (vpe test-vpc/policydb-endpoint-gateway)->(subnet test-vpc/sub1); allowed-protocols[0]
   rules {
     name        = "rule1"
     action      = "allow"
@@ -27,7 +27,7 @@ resource "ibm_is_network_acl" "test-vpc--sub1" {
     source      = "10.240.128.0/24"
     destination = "10.240.0.0/24"
   }
-  # Internal. response to required-connections[1]: (vpe test-vpc/policydb-endpoint-gateway)->(subnet test-vpc/sub1); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[1]: (vpe test-vpc/policydb-endpoint-gateway)->(subnet test-vpc/sub1); allowed-protocols[0]
   rules {
     name        = "rule3"
     action      = "allow"
@@ -35,161 +35,49 @@ resource "ibm_is_network_acl" "test-vpc--sub1" {
     source      = "10.240.0.0/24"
     destination = "10.240.128.0/24"
   }
-  # Deny other internal communication; see rfc1918#3; item 0,0
+  # Deny other internal communication; internal address space item 0
   rules {
     name        = "rule4"
     action      = "deny"
     direction   = "outbound"
-    source      = "10.0.0.0/8"
-    destination = "10.0.0.0/8"
+    source      = "0.0.0.0/0"
+    destination = "10.240.0.0/17"
   }
-  # Deny other internal communication; see rfc1918#3; item 0,0
+  # Deny other internal communication; internal address space item 0
   rules {
     name        = "rule5"
     action      = "deny"
     direction   = "inbound"
-    source      = "10.0.0.0/8"
-    destination = "10.0.0.0/8"
+    source      = "10.240.0.0/17"
+    destination = "0.0.0.0/0"
   }
-  # Deny other internal communication; see rfc1918#3; item 0,1
+  # Deny other internal communication; internal address space item 1
   rules {
     name        = "rule6"
     action      = "deny"
     direction   = "outbound"
-    source      = "10.0.0.0/8"
-    destination = "172.16.0.0/12"
+    source      = "0.0.0.0/0"
+    destination = "10.240.128.0/18"
   }
-  # Deny other internal communication; see rfc1918#3; item 0,1
+  # Deny other internal communication; internal address space item 1
   rules {
     name        = "rule7"
     action      = "deny"
     direction   = "inbound"
-    source      = "172.16.0.0/12"
-    destination = "10.0.0.0/8"
-  }
-  # Deny other internal communication; see rfc1918#3; item 0,2
-  rules {
-    name        = "rule8"
-    action      = "deny"
-    direction   = "outbound"
-    source      = "10.0.0.0/8"
-    destination = "192.168.0.0/16"
-  }
-  # Deny other internal communication; see rfc1918#3; item 0,2
-  rules {
-    name        = "rule9"
-    action      = "deny"
-    direction   = "inbound"
-    source      = "192.168.0.0/16"
-    destination = "10.0.0.0/8"
-  }
-  # Deny other internal communication; see rfc1918#3; item 1,0
-  rules {
-    name        = "rule10"
-    action      = "deny"
-    direction   = "outbound"
-    source      = "172.16.0.0/12"
-    destination = "10.0.0.0/8"
-  }
-  # Deny other internal communication; see rfc1918#3; item 1,0
-  rules {
-    name        = "rule11"
-    action      = "deny"
-    direction   = "inbound"
-    source      = "10.0.0.0/8"
-    destination = "172.16.0.0/12"
-  }
-  # Deny other internal communication; see rfc1918#3; item 1,1
-  rules {
-    name        = "rule12"
-    action      = "deny"
-    direction   = "outbound"
-    source      = "172.16.0.0/12"
-    destination = "172.16.0.0/12"
-  }
-  # Deny other internal communication; see rfc1918#3; item 1,1
-  rules {
-    name        = "rule13"
-    action      = "deny"
-    direction   = "inbound"
-    source      = "172.16.0.0/12"
-    destination = "172.16.0.0/12"
-  }
-  # Deny other internal communication; see rfc1918#3; item 1,2
-  rules {
-    name        = "rule14"
-    action      = "deny"
-    direction   = "outbound"
-    source      = "172.16.0.0/12"
-    destination = "192.168.0.0/16"
-  }
-  # Deny other internal communication; see rfc1918#3; item 1,2
-  rules {
-    name        = "rule15"
-    action      = "deny"
-    direction   = "inbound"
-    source      = "192.168.0.0/16"
-    destination = "172.16.0.0/12"
-  }
-  # Deny other internal communication; see rfc1918#3; item 2,0
-  rules {
-    name        = "rule16"
-    action      = "deny"
-    direction   = "outbound"
-    source      = "192.168.0.0/16"
-    destination = "10.0.0.0/8"
-  }
-  # Deny other internal communication; see rfc1918#3; item 2,0
-  rules {
-    name        = "rule17"
-    action      = "deny"
-    direction   = "inbound"
-    source      = "10.0.0.0/8"
-    destination = "192.168.0.0/16"
-  }
-  # Deny other internal communication; see rfc1918#3; item 2,1
-  rules {
-    name        = "rule18"
-    action      = "deny"
-    direction   = "outbound"
-    source      = "192.168.0.0/16"
-    destination = "172.16.0.0/12"
-  }
-  # Deny other internal communication; see rfc1918#3; item 2,1
-  rules {
-    name        = "rule19"
-    action      = "deny"
-    direction   = "inbound"
-    source      = "172.16.0.0/12"
-    destination = "192.168.0.0/16"
-  }
-  # Deny other internal communication; see rfc1918#3; item 2,2
-  rules {
-    name        = "rule20"
-    action      = "deny"
-    direction   = "outbound"
-    source      = "192.168.0.0/16"
-    destination = "192.168.0.0/16"
-  }
-  # Deny other internal communication; see rfc1918#3; item 2,2
-  rules {
-    name        = "rule21"
-    action      = "deny"
-    direction   = "inbound"
-    source      = "192.168.0.0/16"
-    destination = "192.168.0.0/16"
+    source      = "10.240.128.0/18"
+    destination = "0.0.0.0/0"
   }
   # External. required-connections[0]: (external public internet)->(vpe test-vpc/appdata-endpoint-gateway); allowed-protocols[0]
   rules {
-    name        = "rule22"
+    name        = "rule8"
     action      = "allow"
     direction   = "inbound"
     source      = "0.0.0.0/0"
     destination = "10.240.0.0/24"
   }
-  # External. response to required-connections[0]: (external public internet)->(vpe test-vpc/appdata-endpoint-gateway); allowed-protocols[0]
+  # External. response (exact inverse) to required-connections[0]: (external public internet)->(vpe test-vpc/appdata-endpoint-gateway); allowed-protocols[0]
   rules {
-    name        = "rule23"
+    name        = "rule9"
     action      = "allow"
     direction   = "outbound"
     source      = "10.240.0.0/24"
@@ -210,7 +98,7 @@ resource "ibm_is_network_acl" "test-vpc--sub2" {
     source      = "10.240.128.0/24"
     destination = "10.240.0.0/24"
   }
-  # Internal. response to required-connections[1]: (vpe test-vpc/policydb-endpoint-gateway)->(subnet test-vpc/sub1); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[1]: (vpe test-vpc/policydb-endpoint-gateway)->(subnet test-vpc/sub1); allowed-protocols[0]
   rules {
     name        = "rule1"
     action      = "allow"
@@ -218,161 +106,49 @@ resource "ibm_is_network_acl" "test-vpc--sub2" {
     source      = "10.240.0.0/24"
     destination = "10.240.128.0/24"
   }
-  # Deny other internal communication; see rfc1918#3; item 0,0
+  # Deny other internal communication; internal address space item 0
   rules {
     name        = "rule2"
     action      = "deny"
     direction   = "outbound"
-    source      = "10.0.0.0/8"
-    destination = "10.0.0.0/8"
+    source      = "0.0.0.0/0"
+    destination = "10.240.0.0/17"
   }
-  # Deny other internal communication; see rfc1918#3; item 0,0
+  # Deny other internal communication; internal address space item 0
   rules {
     name        = "rule3"
     action      = "deny"
     direction   = "inbound"
-    source      = "10.0.0.0/8"
-    destination = "10.0.0.0/8"
+    source      = "10.240.0.0/17"
+    destination = "0.0.0.0/0"
   }
-  # Deny other internal communication; see rfc1918#3; item 0,1
+  # Deny other internal communication; internal address space item 1
   rules {
     name        = "rule4"
     action      = "deny"
     direction   = "outbound"
-    source      = "10.0.0.0/8"
-    destination = "172.16.0.0/12"
+    source      = "0.0.0.0/0"
+    destination = "10.240.128.0/18"
   }
-  # Deny other internal communication; see rfc1918#3; item 0,1
+  # Deny other internal communication; internal address space item 1
   rules {
     name        = "rule5"
     action      = "deny"
     direction   = "inbound"
-    source      = "172.16.0.0/12"
-    destination = "10.0.0.0/8"
-  }
-  # Deny other internal communication; see rfc1918#3; item 0,2
-  rules {
-    name        = "rule6"
-    action      = "deny"
-    direction   = "outbound"
-    source      = "10.0.0.0/8"
-    destination = "192.168.0.0/16"
-  }
-  # Deny other internal communication; see rfc1918#3; item 0,2
-  rules {
-    name        = "rule7"
-    action      = "deny"
-    direction   = "inbound"
-    source      = "192.168.0.0/16"
-    destination = "10.0.0.0/8"
-  }
-  # Deny other internal communication; see rfc1918#3; item 1,0
-  rules {
-    name        = "rule8"
-    action      = "deny"
-    direction   = "outbound"
-    source      = "172.16.0.0/12"
-    destination = "10.0.0.0/8"
-  }
-  # Deny other internal communication; see rfc1918#3; item 1,0
-  rules {
-    name        = "rule9"
-    action      = "deny"
-    direction   = "inbound"
-    source      = "10.0.0.0/8"
-    destination = "172.16.0.0/12"
-  }
-  # Deny other internal communication; see rfc1918#3; item 1,1
-  rules {
-    name        = "rule10"
-    action      = "deny"
-    direction   = "outbound"
-    source      = "172.16.0.0/12"
-    destination = "172.16.0.0/12"
-  }
-  # Deny other internal communication; see rfc1918#3; item 1,1
-  rules {
-    name        = "rule11"
-    action      = "deny"
-    direction   = "inbound"
-    source      = "172.16.0.0/12"
-    destination = "172.16.0.0/12"
-  }
-  # Deny other internal communication; see rfc1918#3; item 1,2
-  rules {
-    name        = "rule12"
-    action      = "deny"
-    direction   = "outbound"
-    source      = "172.16.0.0/12"
-    destination = "192.168.0.0/16"
-  }
-  # Deny other internal communication; see rfc1918#3; item 1,2
-  rules {
-    name        = "rule13"
-    action      = "deny"
-    direction   = "inbound"
-    source      = "192.168.0.0/16"
-    destination = "172.16.0.0/12"
-  }
-  # Deny other internal communication; see rfc1918#3; item 2,0
-  rules {
-    name        = "rule14"
-    action      = "deny"
-    direction   = "outbound"
-    source      = "192.168.0.0/16"
-    destination = "10.0.0.0/8"
-  }
-  # Deny other internal communication; see rfc1918#3; item 2,0
-  rules {
-    name        = "rule15"
-    action      = "deny"
-    direction   = "inbound"
-    source      = "10.0.0.0/8"
-    destination = "192.168.0.0/16"
-  }
-  # Deny other internal communication; see rfc1918#3; item 2,1
-  rules {
-    name        = "rule16"
-    action      = "deny"
-    direction   = "outbound"
-    source      = "192.168.0.0/16"
-    destination = "172.16.0.0/12"
-  }
-  # Deny other internal communication; see rfc1918#3; item 2,1
-  rules {
-    name        = "rule17"
-    action      = "deny"
-    direction   = "inbound"
-    source      = "172.16.0.0/12"
-    destination = "192.168.0.0/16"
-  }
-  # Deny other internal communication; see rfc1918#3; item 2,2
-  rules {
-    name        = "rule18"
-    action      = "deny"
-    direction   = "outbound"
-    source      = "192.168.0.0/16"
-    destination = "192.168.0.0/16"
-  }
-  # Deny other internal communication; see rfc1918#3; item 2,2
-  rules {
-    name        = "rule19"
-    action      = "deny"
-    direction   = "inbound"
-    source      = "192.168.0.0/16"
-    destination = "192.168.0.0/16"
+    source      = "10.240.128.0/18"
+    destination = "0.0.0.0/0"
   }
   # External. required-connections[0]: (external public internet)->(vpe test-vpc/appdata-endpoint-gateway); allowed-protocols[0]
   rules {
-    name        = "rule20"
+    name        = "rule6"
     action      = "allow"
     direction   = "inbound"
     source      = "0.0.0.0/0"
     destination = "10.240.128.0/24"
   }
-  # External. response to required-connections[0]: (external public internet)->(vpe test-vpc/appdata-endpoint-gateway); allowed-protocols[0]
+  # External. response (exact inverse) to required-connections[0]: (external public internet)->(vpe test-vpc/appdata-endpoint-gateway); allowed-protocols[0]
   rules {
-    name        = "rule21"
+    name        = "rule7"
     action      = "allow"
     direction   = "outbound"
     source      = "10.240.128.0/24"
@@ -393,7 +169,7 @@ resource "ibm_is_network_acl" "test-vpc--sub3" {
     source      = "10.240.64.0/24"
     destination = "10.240.0.0/24"
   }
-  # Internal. response to required-connections[1]: (vpe test-vpc/policydb-endpoint-gateway)->(subnet test-vpc/sub1); allowed-protocols[0]
+  # Internal. response (exact inverse) to required-connections[1]: (vpe test-vpc/policydb-endpoint-gateway)->(subnet test-vpc/sub1); allowed-protocols[0]
   rules {
     name        = "rule1"
     action      = "allow"
@@ -0,0 +1,213 @@
+# Attached subnets: testacl5-vpc/sub1-1
+resource "ibm_is_network_acl" "testacl5-vpc--sub1-1" {
+  name           = "testacl5-vpc--sub1-1"
+  resource_group = local.acl_synth_resource_group_id
+  vpc            = local.acl_synth_testacl5-vpc_id
+  # Forbidden. forbidden-connections[0]: (subnet testacl5-vpc/sub1-2)->(subnet testacl5-vpc/sub1-1); allowed-protocols[0]
+  rules {
+    name        = "rule0"
+    action      = "deny"
+    direction   = "inbound"
+    source      = "10.240.2.0/24"
+    destination = "10.240.1.0/24"
+    tcp {
+      port_min = 22
+      port_max = 22
+    }
+  }
+  # Internal. required-connections[0]: (subnet testacl5-vpc/sub1-1)->(subnet testacl5-vpc/sub1-2); allowed-protocols[0]
+  rules {
+    name        = "rule1"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "10.240.1.0/24"
+    destination = "10.240.2.0/24"
+    tcp {
+    }
+  }
+  # Internal. response (exact inverse) to required-connections[0]: (subnet testacl5-vpc/sub1-1)->(subnet testacl5-vpc/sub1-2); allowed-protocols[0]
+  rules {
+    name        = "rule2"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "10.240.2.0/24"
+    destination = "10.240.1.0/24"
+    tcp {
+    }
+  }
+  # Deny other internal communication; internal address space item 0
+  rules {
+    name        = "rule3"
+    action      = "deny"
+    direction   = "outbound"
+    source      = "0.0.0.0/0"
+    destination = "10.240.0.0/17"
+  }
+  # Deny other internal communication; internal address space item 0
+  rules {
+    name        = "rule4"
+    action      = "deny"
+    direction   = "inbound"
+    source      = "10.240.0.0/17"
+    destination = "0.0.0.0/0"
+  }
+  # Deny other internal communication; internal address space item 1
+  rules {
+    name        = "rule5"
+    action      = "deny"
+    direction   = "outbound"
+    source      = "0.0.0.0/0"
+    destination = "10.240.128.0/18"
+  }
+  # Deny other internal communication; internal address space item 1
+  rules {
+    name        = "rule6"
+    action      = "deny"
+    direction   = "inbound"
+    source      = "10.240.128.0/18"
+    destination = "0.0.0.0/0"
+  }
+  # External. required-connections[1]: (subnet testacl5-vpc/sub1-1)->(external dns); allowed-protocols[0]
+  rules {
+    name        = "rule7"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "10.240.1.0/24"
+    destination = "8.8.8.8"
+    udp {
+      port_min = 53
+      port_max = 53
+    }
+  }
+}
+
+# Attached subnets: testacl5-vpc/sub1-2
+resource "ibm_is_network_acl" "testacl5-vpc--sub1-2" {
+  name           = "testacl5-vpc--sub1-2"
+  resource_group = local.acl_synth_resource_group_id
+  vpc            = local.acl_synth_testacl5-vpc_id
+  # Forbidden. forbidden-connections[0]: (subnet testacl5-vpc/sub1-2)->(subnet testacl5-vpc/sub1-1); allowed-protocols[0]
+  rules {
+    name        = "rule0"
+    action      = "deny"
+    direction   = "outbound"
+    source      = "10.240.2.0/24"
+    destination = "10.240.1.0/24"
+    tcp {
+      port_min = 22
+      port_max = 22
+    }
+  }
+  # Internal. required-connections[0]: (subnet testacl5-vpc/sub1-1)->(subnet testacl5-vpc/sub1-2); allowed-protocols[0]
+  rules {
+    name        = "rule1"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "10.240.1.0/24"
+    destination = "10.240.2.0/24"
+    tcp {
+    }
+  }
+  # Internal. response (exact inverse) to required-connections[0]: (subnet testacl5-vpc/sub1-1)->(subnet testacl5-vpc/sub1-2); allowed-protocols[0]
+  rules {
+    name        = "rule2"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "10.240.2.0/24"
+    destination = "10.240.1.0/24"
+    tcp {
+    }
+  }
+}
+
+# Attached subnets: testacl5-vpc/sub1-3
+resource "ibm_is_network_acl" "testacl5-vpc--sub1-3" {
+  name           = "testacl5-vpc--sub1-3"
+  resource_group = local.acl_synth_resource_group_id
+  vpc            = local.acl_synth_testacl5-vpc_id
+  # Deny all communication; subnet testacl5-vpc/sub1-3[10.240.3.0/24] does not have required connections
+  rules {
+    name        = "rule0"
+    action      = "deny"
+    direction   = "inbound"
+    source      = "0.0.0.0/0"
+    destination = "10.240.3.0/24"
+  }
+  # Deny all communication; subnet testacl5-vpc/sub1-3[10.240.3.0/24] does not have required connections
+  rules {
+    name        = "rule1"
+    action      = "deny"
+    direction   = "outbound"
+    source      = "10.240.3.0/24"
+    destination = "0.0.0.0/0"
+  }
+}
+
+# Attached subnets: testacl5-vpc/sub2-1
+resource "ibm_is_network_acl" "testacl5-vpc--sub2-1" {
+  name           = "testacl5-vpc--sub2-1"
+  resource_group = local.acl_synth_resource_group_id
+  vpc            = local.acl_synth_testacl5-vpc_id
+  # Deny all communication; subnet testacl5-vpc/sub2-1[10.240.64.0/24] does not have required connections
+  rules {
+    name        = "rule0"
+    action      = "deny"
+    direction   = "inbound"
+    source      = "0.0.0.0/0"
+    destination = "10.240.64.0/24"
+  }
+  # Deny all communication; subnet testacl5-vpc/sub2-1[10.240.64.0/24] does not have required connections
+  rules {
+    name        = "rule1"
+    action      = "deny"
+    direction   = "outbound"
+    source      = "10.240.64.0/24"
+    destination = "0.0.0.0/0"
+  }
+}
+
+# Attached subnets: testacl5-vpc/sub2-2
+resource "ibm_is_network_acl" "testacl5-vpc--sub2-2" {
+  name           = "testacl5-vpc--sub2-2"
+  resource_group = local.acl_synth_resource_group_id
+  vpc            = local.acl_synth_testacl5-vpc_id
+  # Deny all communication; subnet testacl5-vpc/sub2-2[10.240.65.0/24] does not have required connections
+  rules {
+    name        = "rule0"
+    action      = "deny"
+    direction   = "inbound"
+    source      = "0.0.0.0/0"
+    destination = "10.240.65.0/24"
+  }
+  # Deny all communication; subnet testacl5-vpc/sub2-2[10.240.65.0/24] does not have required connections
+  rules {
+    name        = "rule1"
+    action      = "deny"
+    direction   = "outbound"
+    source      = "10.240.65.0/24"
+    destination = "0.0.0.0/0"
+  }
+}
+
+# Attached subnets: testacl5-vpc/sub3-1
+resource "ibm_is_network_acl" "testacl5-vpc--sub3-1" {
+  name           = "testacl5-vpc--sub3-1"
+  resource_group = local.acl_synth_resource_group_id
+  vpc            = local.acl_synth_testacl5-vpc_id
+  # Deny all communication; subnet testacl5-vpc/sub3-1[10.240.128.0/24] does not have required connections
+  rules {
+    name        = "rule0"
+    action      = "deny"
+    direction   = "inbound"
+    source      = "0.0.0.0/0"
+    destination = "10.240.128.0/24"
+  }
+  # Deny all communication; subnet testacl5-vpc/sub3-1[10.240.128.0/24] does not have required connections
+  rules {
+    name        = "rule1"
+    action      = "deny"
+    direction   = "outbound"
+    source      = "10.240.128.0/24"
+    destination = "0.0.0.0/0"
+  }
+}
@@ -0,0 +1,527 @@
+# Attached subnets: test-vpc0/subnet0
+resource "ibm_is_network_acl" "test-vpc0--subnet0" {
+  name           = "test-vpc0--subnet0"
+  resource_group = local.acl_synth_resource_group_id
+  vpc            = local.acl_synth_test-vpc0_id
+  # Internal. response (exact inverse) to required-connections[0]: (instance test-vpc0/vsi0-subnet0)->(instance test-vpc0/vsi0-subnet1); allowed-protocols[0]
+  rules {
+    name        = "rule0"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "10.240.1.0/24"
+    destination = "10.240.0.0/24"
+  }
+  # Internal. required-connections[0]: (instance test-vpc0/vsi0-subnet0)->(instance test-vpc0/vsi0-subnet1); allowed-protocols[0]
+  rules {
+    name        = "rule1"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "10.240.0.0/24"
+    destination = "10.240.1.0/24"
+  }
+}
+
+# Attached subnets: test-vpc0/subnet1
+resource "ibm_is_network_acl" "test-vpc0--subnet1" {
+  name           = "test-vpc0--subnet1"
+  resource_group = local.acl_synth_resource_group_id
+  vpc            = local.acl_synth_test-vpc0_id
+  # Internal. required-connections[0]: (instance test-vpc0/vsi0-subnet0)->(instance test-vpc0/vsi0-subnet1); allowed-protocols[0]
+  rules {
+    name        = "rule0"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "10.240.0.0/24"
+    destination = "10.240.1.0/24"
+  }
+  # Internal. response (exact inverse) to required-connections[0]: (instance test-vpc0/vsi0-subnet0)->(instance test-vpc0/vsi0-subnet1); allowed-protocols[0]
+  rules {
+    name        = "rule1"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "10.240.1.0/24"
+    destination = "10.240.0.0/24"
+  }
+}
+
+# Attached subnets: test-vpc0/subnet2
+resource "ibm_is_network_acl" "test-vpc0--subnet2" {
+  name           = "test-vpc0--subnet2"
+  resource_group = local.acl_synth_resource_group_id
+  vpc            = local.acl_synth_test-vpc0_id
+  # Internal. response (exact inverse) to required-connections[2]: (nif test-vpc0/vsi0-subnet2/graveyard-handmade-ransack-acquaint)->(nif test-vpc0/vsi0-subnet3/icky-balsamic-outgoing-leached); allowed-protocols[0]
+  rules {
+    name        = "rule0"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "10.240.5.0/24"
+    destination = "10.240.4.0/24"
+  }
+  # Internal. required-connections[2]: (nif test-vpc0/vsi0-subnet2/graveyard-handmade-ransack-acquaint)->(nif test-vpc0/vsi0-subnet3/icky-balsamic-outgoing-leached); allowed-protocols[0]
+  rules {
+    name        = "rule1"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "10.240.4.0/24"
+    destination = "10.240.5.0/24"
+  }
+}
+
+# Attached subnets: test-vpc0/subnet3
+resource "ibm_is_network_acl" "test-vpc0--subnet3" {
+  name           = "test-vpc0--subnet3"
+  resource_group = local.acl_synth_resource_group_id
+  vpc            = local.acl_synth_test-vpc0_id
+  # Internal. required-connections[2]: (nif test-vpc0/vsi0-subnet2/graveyard-handmade-ransack-acquaint)->(nif test-vpc0/vsi0-subnet3/icky-balsamic-outgoing-leached); allowed-protocols[0]
+  rules {
+    name        = "rule0"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "10.240.4.0/24"
+    destination = "10.240.5.0/24"
+  }
+  # Internal. response (exact inverse) to required-connections[2]: (nif test-vpc0/vsi0-subnet2/graveyard-handmade-ransack-acquaint)->(nif test-vpc0/vsi0-subnet3/icky-balsamic-outgoing-leached); allowed-protocols[0]
+  rules {
+    name        = "rule1"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "10.240.5.0/24"
+    destination = "10.240.4.0/24"
+  }
+}
+
+# Attached subnets: test-vpc0/subnet4
+resource "ibm_is_network_acl" "test-vpc0--subnet4" {
+  name           = "test-vpc0--subnet4"
+  resource_group = local.acl_synth_resource_group_id
+  vpc            = local.acl_synth_test-vpc0_id
+}
+
+# Attached subnets: test-vpc0/subnet5
+resource "ibm_is_network_acl" "test-vpc0--subnet5" {
+  name           = "test-vpc0--subnet5"
+  resource_group = local.acl_synth_resource_group_id
+  vpc            = local.acl_synth_test-vpc0_id
+}
+
+# Attached subnets: test-vpc1/subnet10
+resource "ibm_is_network_acl" "test-vpc1--subnet10" {
+  name           = "test-vpc1--subnet10"
+  resource_group = local.acl_synth_resource_group_id
+  vpc            = local.acl_synth_test-vpc1_id
+  # External. response (exact inverse) to required-connections[3]: (instance test-vpc1/vsi0-subnet10)->(external dns); allowed-protocols[0]
+  rules {
+    name        = "rule0"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "8.8.8.8"
+    destination = "10.240.64.0/24"
+  }
+  # External. required-connections[3]: (instance test-vpc1/vsi0-subnet10)->(external dns); allowed-protocols[0]
+  rules {
+    name        = "rule1"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "10.240.64.0/24"
+    destination = "8.8.8.8"
+  }
+}
+
+# Attached subnets: test-vpc1/subnet11
+resource "ibm_is_network_acl" "test-vpc1--subnet11" {
+  name           = "test-vpc1--subnet11"
+  resource_group = local.acl_synth_resource_group_id
+  vpc            = local.acl_synth_test-vpc1_id
+}
+
+# Attached subnets: test-vpc2/subnet20
+resource "ibm_is_network_acl" "test-vpc2--subnet20" {
+  name           = "test-vpc2--subnet20"
+  resource_group = local.acl_synth_resource_group_id
+  vpc            = local.acl_synth_test-vpc2_id
+  # Deny other internal communication; internal address space item 0
+  rules {
+    name        = "rule0"
+    action      = "deny"
+    direction   = "inbound"
+    source      = "10.240.0.0/21"
+    destination = "0.0.0.0/0"
+  }
+  # Deny other internal communication; internal address space item 1
+  rules {
+    name        = "rule1"
+    action      = "deny"
+    direction   = "inbound"
+    source      = "10.240.8.0/22"
+    destination = "0.0.0.0/0"
+  }
+  # Deny other internal communication; internal address space item 2
+  rules {
+    name        = "rule2"
+    action      = "deny"
+    direction   = "inbound"
+    source      = "10.240.64.0/19"
+    destination = "0.0.0.0/0"
+  }
+  # Deny other internal communication; internal address space item 3
+  rules {
+    name        = "rule3"
+    action      = "deny"
+    direction   = "inbound"
+    source      = "10.240.128.0/20"
+    destination = "0.0.0.0/0"
+  }
+  # Deny other internal communication; internal address space item 4
+  rules {
+    name        = "rule4"
+    action      = "deny"
+    direction   = "inbound"
+    source      = "10.240.192.0/20"
+    destination = "0.0.0.0/0"
+  }
+  # External. response (exact inverse) to required-connections[4]: (instance test-vpc2/vsi1-subnet20)->(external public internet); allowed-protocols[0]
+  rules {
+    name        = "rule5"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "0.0.0.0/0"
+    destination = "10.240.128.0/24"
+  }
+  # Deny other internal communication; internal address space item 0
+  rules {
+    name        = "rule6"
+    action      = "deny"
+    direction   = "outbound"
+    source      = "0.0.0.0/0"
+    destination = "10.240.0.0/21"
+  }
+  # Deny other internal communication; internal address space item 1
+  rules {
+    name        = "rule7"
+    action      = "deny"
+    direction   = "outbound"
+    source      = "0.0.0.0/0"
+    destination = "10.240.8.0/22"
+  }
+  # Deny other internal communication; internal address space item 2
+  rules {
+    name        = "rule8"
+    action      = "deny"
+    direction   = "outbound"
+    source      = "0.0.0.0/0"
+    destination = "10.240.64.0/19"
+  }
+  # Deny other internal communication; internal address space item 3
+  rules {
+    name        = "rule9"
+    action      = "deny"
+    direction   = "outbound"
+    source      = "0.0.0.0/0"
+    destination = "10.240.128.0/20"
+  }
+  # Deny other internal communication; internal address space item 4
+  rules {
+    name        = "rule10"
+    action      = "deny"
+    direction   = "outbound"
+    source      = "0.0.0.0/0"
+    destination = "10.240.192.0/20"
+  }
+  # External. required-connections[4]: (instance test-vpc2/vsi1-subnet20)->(external public internet); allowed-protocols[0]
+  rules {
+    name        = "rule11"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "10.240.128.0/24"
+    destination = "0.0.0.0/0"
+  }
+}
+
+# Attached subnets: test-vpc3/subnet30
+resource "ibm_is_network_acl" "test-vpc3--subnet30" {
+  name           = "test-vpc3--subnet30"
+  resource_group = local.acl_synth_resource_group_id
+  vpc            = local.acl_synth_test-vpc3_id
+}
+
+### SG test-vpc0--vsi0-subnet0 is attached to test-vpc0/vsi0-subnet0
+resource "ibm_is_security_group" "test-vpc0--vsi0-subnet0" {
+  name           = "sg-test-vpc0--vsi0-subnet0"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc0_id
+}
+# Internal. required-connections[0]: (instance test-vpc0/vsi0-subnet0)->(instance test-vpc0/vsi0-subnet1); allowed-protocols[0]
+resource "ibm_is_security_group_rule" "test-vpc0--vsi0-subnet0-0" {
+  group     = ibm_is_security_group.test-vpc0--vsi0-subnet0.id
+  direction = "outbound"
+  local     = "0.0.0.0/0"
+  remote    = ibm_is_security_group.test-vpc0--vsi0-subnet1.id
+  udp {
+  }
+}
+# Internal. required-connections[0]: (instance test-vpc0/vsi0-subnet0)->(instance test-vpc0/vsi0-subnet1); allowed-protocols[1]
+resource "ibm_is_security_group_rule" "test-vpc0--vsi0-subnet0-1" {
+  group     = ibm_is_security_group.test-vpc0--vsi0-subnet0.id
+  direction = "outbound"
+  local     = "0.0.0.0/0"
+  remote    = ibm_is_security_group.test-vpc0--vsi0-subnet1.id
+  icmp {
+  }
+}
+
+### SG test-vpc0--vsi0-subnet1 is attached to test-vpc0/vsi0-subnet1
+resource "ibm_is_security_group" "test-vpc0--vsi0-subnet1" {
+  name           = "sg-test-vpc0--vsi0-subnet1"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc0_id
+}
+# Internal. required-connections[0]: (instance test-vpc0/vsi0-subnet0)->(instance test-vpc0/vsi0-subnet1); allowed-protocols[0]
+resource "ibm_is_security_group_rule" "test-vpc0--vsi0-subnet1-0" {
+  group     = ibm_is_security_group.test-vpc0--vsi0-subnet1.id
+  direction = "inbound"
+  local     = "0.0.0.0/0"
+  remote    = ibm_is_security_group.test-vpc0--vsi0-subnet0.id
+  udp {
+  }
+}
+# Internal. required-connections[0]: (instance test-vpc0/vsi0-subnet0)->(instance test-vpc0/vsi0-subnet1); allowed-protocols[1]
+resource "ibm_is_security_group_rule" "test-vpc0--vsi0-subnet1-1" {
+  group     = ibm_is_security_group.test-vpc0--vsi0-subnet1.id
+  direction = "inbound"
+  local     = "0.0.0.0/0"
+  remote    = ibm_is_security_group.test-vpc0--vsi0-subnet0.id
+  icmp {
+  }
+}
+
+### SG test-vpc0--vsi0-subnet2 is attached to test-vpc0/vsi0-subnet2
+resource "ibm_is_security_group" "test-vpc0--vsi0-subnet2" {
+  name           = "sg-test-vpc0--vsi0-subnet2"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc0_id
+}
+# Internal. required-connections[2]: (nif test-vpc0/vsi0-subnet2/graveyard-handmade-ransack-acquaint)->(nif test-vpc0/vsi0-subnet3/icky-balsamic-outgoing-leached); allowed-protocols[0]
+resource "ibm_is_security_group_rule" "test-vpc0--vsi0-subnet2-0" {
+  group     = ibm_is_security_group.test-vpc0--vsi0-subnet2.id
+  direction = "outbound"
+  local     = "0.0.0.0/0"
+  remote    = ibm_is_security_group.test-vpc0--vsi0-subnet3.id
+  tcp {
+  }
+}
+# Internal. required-connections[2]: (nif test-vpc0/vsi0-subnet2/graveyard-handmade-ransack-acquaint)->(nif test-vpc0/vsi0-subnet3/icky-balsamic-outgoing-leached); allowed-protocols[1]
+resource "ibm_is_security_group_rule" "test-vpc0--vsi0-subnet2-1" {
+  group     = ibm_is_security_group.test-vpc0--vsi0-subnet2.id
+  direction = "outbound"
+  local     = "0.0.0.0/0"
+  remote    = ibm_is_security_group.test-vpc0--vsi0-subnet3.id
+  icmp {
+    type = 11
+    code = 1
+  }
+}
+
+### SG test-vpc0--vsi0-subnet3 is attached to test-vpc0/vsi0-subnet3
+resource "ibm_is_security_group" "test-vpc0--vsi0-subnet3" {
+  name           = "sg-test-vpc0--vsi0-subnet3"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc0_id
+}
+# Internal. required-connections[2]: (nif test-vpc0/vsi0-subnet2/graveyard-handmade-ransack-acquaint)->(nif test-vpc0/vsi0-subnet3/icky-balsamic-outgoing-leached); allowed-protocols[0]
+resource "ibm_is_security_group_rule" "test-vpc0--vsi0-subnet3-0" {
+  group     = ibm_is_security_group.test-vpc0--vsi0-subnet3.id
+  direction = "inbound"
+  local     = "0.0.0.0/0"
+  remote    = ibm_is_security_group.test-vpc0--vsi0-subnet2.id
+  tcp {
+  }
+}
+# Internal. required-connections[2]: (nif test-vpc0/vsi0-subnet2/graveyard-handmade-ransack-acquaint)->(nif test-vpc0/vsi0-subnet3/icky-balsamic-outgoing-leached); allowed-protocols[1]
+resource "ibm_is_security_group_rule" "test-vpc0--vsi0-subnet3-1" {
+  group     = ibm_is_security_group.test-vpc0--vsi0-subnet3.id
+  direction = "inbound"
+  local     = "0.0.0.0/0"
+  remote    = ibm_is_security_group.test-vpc0--vsi0-subnet2.id
+  icmp {
+    type = 11
+    code = 1
+  }
+}
+
+### SG test-vpc0--vsi0-subnet4 is attached to test-vpc0/vsi0-subnet4
+resource "ibm_is_security_group" "test-vpc0--vsi0-subnet4" {
+  name           = "sg-test-vpc0--vsi0-subnet4"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc0_id
+}
+
+### SG test-vpc0--vsi0-subnet5 is attached to test-vpc0/vsi0-subnet5
+resource "ibm_is_security_group" "test-vpc0--vsi0-subnet5" {
+  name           = "sg-test-vpc0--vsi0-subnet5"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc0_id
+}
+
+### SG test-vpc0--vsi1-subnet0 is attached to test-vpc0/vsi1-subnet0
+resource "ibm_is_security_group" "test-vpc0--vsi1-subnet0" {
+  name           = "sg-test-vpc0--vsi1-subnet0"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc0_id
+}
+# Internal. required-connections[1]: (instance test-vpc0/vsi1-subnet0)->(instance test-vpc0/vsi1-subnet1); allowed-protocols[0]
+resource "ibm_is_security_group_rule" "test-vpc0--vsi1-subnet0-0" {
+  group     = ibm_is_security_group.test-vpc0--vsi1-subnet0.id
+  direction = "outbound"
+  local     = "0.0.0.0/0"
+  remote    = ibm_is_security_group.test-vpc0--vsi1-subnet1.id
+  tcp {
+    port_min = 8080
+    port_max = 8080
+  }
+}
+# Internal. required-connections[1]: (instance test-vpc0/vsi1-subnet0)->(instance test-vpc0/vsi1-subnet1); allowed-protocols[1]
+resource "ibm_is_security_group_rule" "test-vpc0--vsi1-subnet0-1" {
+  group     = ibm_is_security_group.test-vpc0--vsi1-subnet0.id
+  direction = "outbound"
+  local     = "0.0.0.0/0"
+  remote    = ibm_is_security_group.test-vpc0--vsi1-subnet1.id
+  udp {
+    port_min = 53
+    port_max = 53
+  }
+}
+# Internal. required-connections[1]: (instance test-vpc0/vsi1-subnet0)->(instance test-vpc0/vsi1-subnet1); allowed-protocols[2]
+resource "ibm_is_security_group_rule" "test-vpc0--vsi1-subnet0-2" {
+  group     = ibm_is_security_group.test-vpc0--vsi1-subnet0.id
+  direction = "outbound"
+  local     = "0.0.0.0/0"
+  remote    = ibm_is_security_group.test-vpc0--vsi1-subnet1.id
+  icmp {
+    type = 8
+  }
+}
+
+### SG test-vpc0--vsi1-subnet1 is attached to test-vpc0/vsi1-subnet1
+resource "ibm_is_security_group" "test-vpc0--vsi1-subnet1" {
+  name           = "sg-test-vpc0--vsi1-subnet1"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc0_id
+}
+# Internal. required-connections[1]: (instance test-vpc0/vsi1-subnet0)->(instance test-vpc0/vsi1-subnet1); allowed-protocols[0]
+resource "ibm_is_security_group_rule" "test-vpc0--vsi1-subnet1-0" {
+  group     = ibm_is_security_group.test-vpc0--vsi1-subnet1.id
+  direction = "inbound"
+  local     = "0.0.0.0/0"
+  remote    = ibm_is_security_group.test-vpc0--vsi1-subnet0.id
+  tcp {
+    port_min = 8080
+    port_max = 8080
+  }
+}
+# Internal. required-connections[1]: (instance test-vpc0/vsi1-subnet0)->(instance test-vpc0/vsi1-subnet1); allowed-protocols[1]
+resource "ibm_is_security_group_rule" "test-vpc0--vsi1-subnet1-1" {
+  group     = ibm_is_security_group.test-vpc0--vsi1-subnet1.id
+  direction = "inbound"
+  local     = "0.0.0.0/0"
+  remote    = ibm_is_security_group.test-vpc0--vsi1-subnet0.id
+  udp {
+    port_min = 53
+    port_max = 53
+  }
+}
+# Internal. required-connections[1]: (instance test-vpc0/vsi1-subnet0)->(instance test-vpc0/vsi1-subnet1); allowed-protocols[2]
+resource "ibm_is_security_group_rule" "test-vpc0--vsi1-subnet1-2" {
+  group     = ibm_is_security_group.test-vpc0--vsi1-subnet1.id
+  direction = "inbound"
+  local     = "0.0.0.0/0"
+  remote    = ibm_is_security_group.test-vpc0--vsi1-subnet0.id
+  icmp {
+    type = 8
+  }
+}
+
+### SG test-vpc0--vsi1-subnet2 is attached to test-vpc0/vsi1-subnet2
+resource "ibm_is_security_group" "test-vpc0--vsi1-subnet2" {
+  name           = "sg-test-vpc0--vsi1-subnet2"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc0_id
+}
+
+### SG test-vpc0--vsi1-subnet3 is attached to test-vpc0/vsi1-subnet3
+resource "ibm_is_security_group" "test-vpc0--vsi1-subnet3" {
+  name           = "sg-test-vpc0--vsi1-subnet3"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc0_id
+}
+
+### SG test-vpc0--vsi1-subnet4 is attached to test-vpc0/vsi1-subnet4
+resource "ibm_is_security_group" "test-vpc0--vsi1-subnet4" {
+  name           = "sg-test-vpc0--vsi1-subnet4"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc0_id
+}
+
+### SG test-vpc0--vsi1-subnet5 is attached to test-vpc0/vsi1-subnet5
+resource "ibm_is_security_group" "test-vpc0--vsi1-subnet5" {
+  name           = "sg-test-vpc0--vsi1-subnet5"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc0_id
+}
+
+### SG test-vpc1--vsi0-subnet10 is attached to test-vpc1/vsi0-subnet10
+resource "ibm_is_security_group" "test-vpc1--vsi0-subnet10" {
+  name           = "sg-test-vpc1--vsi0-subnet10"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc1_id
+}
+# External. required-connections[3]: (instance test-vpc1/vsi0-subnet10)->(external dns); allowed-protocols[0]
+resource "ibm_is_security_group_rule" "test-vpc1--vsi0-subnet10-0" {
+  group     = ibm_is_security_group.test-vpc1--vsi0-subnet10.id
+  direction = "outbound"
+  local     = "0.0.0.0/0"
+  remote    = "8.8.8.8"
+  tcp {
+  }
+}
+
+### SG test-vpc1--vsi0-subnet11 is attached to test-vpc1/vsi0-subnet11
+resource "ibm_is_security_group" "test-vpc1--vsi0-subnet11" {
+  name           = "sg-test-vpc1--vsi0-subnet11"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc1_id
+}
+
+### SG test-vpc2--vsi0-subnet20 is attached to test-vpc2/vsi0-subnet20
+resource "ibm_is_security_group" "test-vpc2--vsi0-subnet20" {
+  name           = "sg-test-vpc2--vsi0-subnet20"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc2_id
+}
+
+### SG test-vpc2--vsi1-subnet20 is attached to test-vpc2/vsi1-subnet20
+resource "ibm_is_security_group" "test-vpc2--vsi1-subnet20" {
+  name           = "sg-test-vpc2--vsi1-subnet20"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc2_id
+}
+# External. required-connections[4]: (instance test-vpc2/vsi1-subnet20)->(external public internet); allowed-protocols[0]
+resource "ibm_is_security_group_rule" "test-vpc2--vsi1-subnet20-0" {
+  group     = ibm_is_security_group.test-vpc2--vsi1-subnet20.id
+  direction = "outbound"
+  local     = "0.0.0.0/0"
+  remote    = "0.0.0.0/0"
+}
+
+### SG test-vpc2--vsi2-subnet20 is attached to test-vpc2/vsi2-subnet20
+resource "ibm_is_security_group" "test-vpc2--vsi2-subnet20" {
+  name           = "sg-test-vpc2--vsi2-subnet20"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc2_id
+}
+
+### SG test-vpc3--vsi0-subnet30 is attached to test-vpc3/vsi0-subnet30
+resource "ibm_is_security_group" "test-vpc3--vsi0-subnet30" {
+  name           = "sg-test-vpc3--vsi0-subnet30"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc3_id
+}
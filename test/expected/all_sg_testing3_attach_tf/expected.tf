@@ -0,0 +1,350 @@
+data "ibm_is_subnet" "test-vpc--sub1" {
+  name = "sub1"
+  vpc  = local.acl_synth_test-vpc_id
+}
+data "ibm_is_subnet" "test-vpc--sub2" {
+  name = "sub2"
+  vpc  = local.acl_synth_test-vpc_id
+}
+data "ibm_is_subnet" "test-vpc--sub3" {
+  name = "sub3"
+  vpc  = local.acl_synth_test-vpc_id
+}
+data "ibm_is_virtual_endpoint_gateway" "test-vpc--appdata-endpoint-gateway" {
+  name = "appdata-endpoint-gateway"
+}
+data "ibm_is_instance_network_interface" "test-vpc--be--captain-captivity-shorty-crown" {
+  instance_name          = "be"
+  network_interface_name = "captain-captivity-shorty-crown"
+}
+data "ibm_is_instance_network_interface" "test-vpc--fe--litigate-bullfrog-improve-shandy" {
+  instance_name          = "fe"
+  network_interface_name = "litigate-bullfrog-improve-shandy"
+}
+data "ibm_is_instance_network_interface" "test-vpc--opa--left-pebble-agonizing-wharf" {
+  instance_name          = "opa"
+  network_interface_name = "left-pebble-agonizing-wharf"
+}
+data "ibm_is_virtual_endpoint_gateway" "test-vpc--policydb-endpoint-gateway" {
+  name = "policydb-endpoint-gateway"
+}
+data "ibm_is_instance_network_interface" "test-vpc--proxy--bouncing-serpent-graffiti-evasion" {
+  instance_name          = "proxy"
+  network_interface_name = "bouncing-serpent-graffiti-evasion"
+}
+
+# Attached subnets: test-vpc/sub1
+resource "ibm_is_network_acl" "test-vpc--sub1" {
+  name           = "test-vpc--sub1"
+  resource_group = local.acl_synth_resource_group_id
+  vpc            = local.acl_synth_test-vpc_id
+  # Internal. required-connections[1]: (instance test-vpc/proxy)->(instance test-vpc/fe); allowed-protocols[0]
+  rules {
+    name        = "rule0"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "10.240.0.0/24"
+    destination = "10.240.128.0/24"
+    tcp {
+      port_min = 9000
+      port_max = 9000
+    }
+  }
+  # Internal. response (exact inverse) to required-connections[1]: (instance test-vpc/proxy)->(instance test-vpc/fe); allowed-protocols[0]
+  rules {
+    name        = "rule1"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "10.240.128.0/24"
+    destination = "10.240.0.0/24"
+    tcp {
+      source_port_min = 9000
+      source_port_max = 9000
+    }
+  }
+  # Deny other internal communication; internal address space item 0
+  rules {
+    name        = "rule2"
+    action      = "deny"
+    direction   = "outbound"
+    source      = "0.0.0.0/0"
+    destination = "10.240.0.0/17"
+  }
+  # Deny other internal communication; internal address space item 0
+  rules {
+    name        = "rule3"
+    action      = "deny"
+    direction   = "inbound"
+    source      = "10.240.0.0/17"
+    destination = "0.0.0.0/0"
+  }
+  # Deny other internal communication; internal address space item 1
+  rules {
+    name        = "rule4"
+    action      = "deny"
+    direction   = "outbound"
+    source      = "0.0.0.0/0"
+    destination = "10.240.128.0/18"
+  }
+  # Deny other internal communication; internal address space item 1
+  rules {
+    name        = "rule5"
+    action      = "deny"
+    direction   = "inbound"
+    source      = "10.240.128.0/18"
+    destination = "0.0.0.0/0"
+  }
+  # External. required-connections[0]: (external public internet)->(instance test-vpc/proxy); allowed-protocols[0]
+  rules {
+    name        = "rule6"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "0.0.0.0/0"
+    destination = "10.240.0.0/24"
+  }
+  # External. response (exact inverse) to required-connections[0]: (external public internet)->(instance test-vpc/proxy); allowed-protocols[0]
+  rules {
+    name        = "rule7"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "10.240.0.0/24"
+    destination = "0.0.0.0/0"
+  }
+}
+resource "ibm_is_subnet_network_acl_attachment" "test-vpc--sub1" {
+  subnet      = data.ibm_is_subnet.test-vpc--sub1.id
+  network_acl = ibm_is_network_acl.test-vpc--sub1.id
+}
+
+# Attached subnets: test-vpc/sub2
+resource "ibm_is_network_acl" "test-vpc--sub2" {
+  name           = "test-vpc--sub2"
+  resource_group = local.acl_synth_resource_group_id
+  vpc            = local.acl_synth_test-vpc_id
+  # Internal. required-connections[1]: (instance test-vpc/proxy)->(instance test-vpc/fe); allowed-protocols[0]
+  rules {
+    name        = "rule0"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "10.240.0.0/24"
+    destination = "10.240.128.0/24"
+    tcp {
+      port_min = 9000
+      port_max = 9000
+    }
+  }
+  # Internal. response (exact inverse) to required-connections[1]: (instance test-vpc/proxy)->(instance test-vpc/fe); allowed-protocols[0]
+  rules {
+    name        = "rule1"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "10.240.128.0/24"
+    destination = "10.240.0.0/24"
+    tcp {
+      source_port_min = 9000
+      source_port_max = 9000
+    }
+  }
+  # Internal. required-connections[4]: (instance test-vpc/be)->(vpe test-vpc/policydb-endpoint-gateway); allowed-protocols[0]
+  rules {
+    name        = "rule2"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "10.240.128.0/24"
+    destination = "10.240.64.0/24"
+  }
+  # Internal. response (exact inverse) to required-connections[4]: (instance test-vpc/be)->(vpe test-vpc/policydb-endpoint-gateway); allowed-protocols[0]
+  rules {
+    name        = "rule3"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "10.240.64.0/24"
+    destination = "10.240.128.0/24"
+  }
+}
+resource "ibm_is_subnet_network_acl_attachment" "test-vpc--sub2" {
+  subnet      = data.ibm_is_subnet.test-vpc--sub2.id
+  network_acl = ibm_is_network_acl.test-vpc--sub2.id
+}
+
+# Attached subnets: test-vpc/sub3
+resource "ibm_is_network_acl" "test-vpc--sub3" {
+  name           = "test-vpc--sub3"
+  resource_group = local.acl_synth_resource_group_id
+  vpc            = local.acl_synth_test-vpc_id
+  # Internal. required-connections[4]: (instance test-vpc/be)->(vpe test-vpc/policydb-endpoint-gateway); allowed-protocols[0]
+  rules {
+    name        = "rule0"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "10.240.128.0/24"
+    destination = "10.240.64.0/24"
+  }
+  # Internal. response (exact inverse) to required-connections[4]: (instance test-vpc/be)->(vpe test-vpc/policydb-endpoint-gateway); allowed-protocols[0]
+  rules {
+    name        = "rule1"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "10.240.64.0/24"
+    destination = "10.240.128.0/24"
+  }
+}
+resource "ibm_is_subnet_network_acl_attachment" "test-vpc--sub3" {
+  subnet      = data.ibm_is_subnet.test-vpc--sub3.id
+  network_acl = ibm_is_network_acl.test-vpc--sub3.id
+}
+
+### SG test-vpc--appdata-endpoint-gateway is attached to test-vpc/appdata-endpoint-gateway
+resource "ibm_is_security_group" "test-vpc--appdata-endpoint-gateway" {
+  name           = "sg-test-vpc--appdata-endpoint-gateway"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc_id
+}
+resource "ibm_is_security_group_target" "test-vpc--appdata-endpoint-gateway--appdata-endpoint-gateway" {
+  security_group = ibm_is_security_group.test-vpc--appdata-endpoint-gateway.id
+  target         = data.ibm_is_virtual_endpoint_gateway.test-vpc--appdata-endpoint-gateway.id
+}
+
+### SG test-vpc--be is attached to test-vpc/be
+resource "ibm_is_security_group" "test-vpc--be" {
+  name           = "sg-test-vpc--be"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc_id
+}
+# Internal. required-connections[2]: (instance test-vpc/fe)->(instance test-vpc/be); allowed-protocols[0]
+resource "ibm_is_security_group_rule" "test-vpc--be-0" {
+  group     = ibm_is_security_group.test-vpc--be.id
+  direction = "inbound"
+  local     = "0.0.0.0/0"
+  remote    = ibm_is_security_group.test-vpc--fe.id
+  tcp {
+  }
+}
+# Internal. required-connections[3]: (instance test-vpc/be)->(instance test-vpc/opa); allowed-protocols[0]
+resource "ibm_is_security_group_rule" "test-vpc--be-1" {
+  group     = ibm_is_security_group.test-vpc--be.id
+  direction = "outbound"
+  local     = "0.0.0.0/0"
+  remote    = ibm_is_security_group.test-vpc--opa.id
+}
+# Internal. required-connections[4]: (instance test-vpc/be)->(vpe test-vpc/policydb-endpoint-gateway); allowed-protocols[0]
+resource "ibm_is_security_group_rule" "test-vpc--be-2" {
+  group     = ibm_is_security_group.test-vpc--be.id
+  direction = "outbound"
+  local     = "0.0.0.0/0"
+  remote    = ibm_is_security_group.test-vpc--policydb-endpoint-gateway.id
+}
+resource "ibm_is_security_group_target" "test-vpc--be--be--captain-captivity-shorty-crown" {
+  security_group = ibm_is_security_group.test-vpc--be.id
+  target         = data.ibm_is_instance_network_interface.test-vpc--be--captain-captivity-shorty-crown.id
+}
+
+### SG test-vpc--fe is attached to test-vpc/fe
+resource "ibm_is_security_group" "test-vpc--fe" {
+  name           = "sg-test-vpc--fe"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc_id
+}
+# Internal. required-connections[1]: (instance test-vpc/proxy)->(instance test-vpc/fe); allowed-protocols[0]
+resource "ibm_is_security_group_rule" "test-vpc--fe-0" {
+  group     = ibm_is_security_group.test-vpc--fe.id
+  direction = "inbound"
+  local     = "0.0.0.0/0"
+  remote    = ibm_is_security_group.test-vpc--proxy.id
+  tcp {
+    port_min = 9000
+    port_max = 9000
+  }
+}
+# Internal. required-connections[2]: (instance test-vpc/fe)->(instance test-vpc/be); allowed-protocols[0]
+resource "ibm_is_security_group_rule" "test-vpc--fe-1" {
+  group     = ibm_is_security_group.test-vpc--fe.id
+  direction = "outbound"
+  local     = "0.0.0.0/0"
+  remote    = ibm_is_security_group.test-vpc--be.id
+  tcp {
+  }
+}
+resource "ibm_is_security_group_target" "test-vpc--fe--fe--litigate-bullfrog-improve-shandy" {
+  security_group = ibm_is_security_group.test-vpc--fe.id
+  target         = data.ibm_is_instance_network_interface.test-vpc--fe--litigate-bullfrog-improve-shandy.id
+}
+
+### SG test-vpc--opa is attached to test-vpc/opa
+resource "ibm_is_security_group" "test-vpc--opa" {
+  name           = "sg-test-vpc--opa"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc_id
+}
+# Internal. required-connections[3]: (instance test-vpc/be)->(instance test-vpc/opa); allowed-protocols[0]
+resource "ibm_is_security_group_rule" "test-vpc--opa-0" {
+  group     = ibm_is_security_group.test-vpc--opa.id
+  direction = "inbound"
+  local     = "0.0.0.0/0"
+  remote    = ibm_is_security_group.test-vpc--be.id
+}
+# Internal. required-connections[5]: (instance test-vpc/opa)->(vpe test-vpc/policydb-endpoint-gateway); allowed-protocols[0]
+resource "ibm_is_security_group_rule" "test-vpc--opa-1" {
+  group     = ibm_is_security_group.test-vpc--opa.id
+  direction = "outbound"
+  local     = "0.0.0.0/0"
+  remote    = ibm_is_security_group.test-vpc--policydb-endpoint-gateway.id
+}
+resource "ibm_is_security_group_target" "test-vpc--opa--opa--left-pebble-agonizing-wharf" {
+  security_group = ibm_is_security_group.test-vpc--opa.id
+  target         = data.ibm_is_instance_network_interface.test-vpc--opa--left-pebble-agonizing-wharf.id
+}
+
+### SG test-vpc--policydb-endpoint-gateway is attached to test-vpc/policydb-endpoint-gateway
+resource "ibm_is_security_group" "test-vpc--policydb-endpoint-gateway" {
+  name           = "sg-test-vpc--policydb-endpoint-gateway"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc_id
+}
+# Internal. required-connections[4]: (instance test-vpc/be)->(vpe test-vpc/policydb-endpoint-gateway); allowed-protocols[0]
+resource "ibm_is_security_group_rule" "test-vpc--policydb-endpoint-gateway-0" {
+  group     = ibm_is_security_group.test-vpc--policydb-endpoint-gateway.id
+  direction = "inbound"
+  local     = "0.0.0.0/0"
+  remote    = ibm_is_security_group.test-vpc--be.id
+}
+# Internal. required-connections[5]: (instance test-vpc/opa)->(vpe test-vpc/policydb-endpoint-gateway); allowed-protocols[0]
+resource "ibm_is_security_group_rule" "test-vpc--policydb-endpoint-gateway-1" {
+  group     = ibm_is_security_group.test-vpc--policydb-endpoint-gateway.id
+  direction = "inbound"
+  local     = "0.0.0.0/0"
+  remote    = ibm_is_security_group.test-vpc--opa.id
+}
+resource "ibm_is_security_group_target" "test-vpc--policydb-endpoint-gateway--policydb-endpoint-gateway" {
+  security_group = ibm_is_security_group.test-vpc--policydb-endpoint-gateway.id
+  target         = data.ibm_is_virtual_endpoint_gateway.test-vpc--policydb-endpoint-gateway.id
+}
+
+### SG test-vpc--proxy is attached to test-vpc/proxy
+resource "ibm_is_security_group" "test-vpc--proxy" {
+  name           = "sg-test-vpc--proxy"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc_id
+}
+# External. required-connections[0]: (external public internet)->(instance test-vpc/proxy); allowed-protocols[0]
+resource "ibm_is_security_group_rule" "test-vpc--proxy-0" {
+  group     = ibm_is_security_group.test-vpc--proxy.id
+  direction = "inbound"
+  local     = "0.0.0.0/0"
+  remote    = "0.0.0.0/0"
+}
+# Internal. required-connections[1]: (instance test-vpc/proxy)->(instance test-vpc/fe); allowed-protocols[0]
+resource "ibm_is_security_group_rule" "test-vpc--proxy-1" {
+  group     = ibm_is_security_group.test-vpc--proxy.id
+  direction = "outbound"
+  local     = "0.0.0.0/0"
+  remote    = ibm_is_security_group.test-vpc--fe.id
+  tcp {
+    port_min = 9000
+    port_max = 9000
+  }
+}
+resource "ibm_is_security_group_target" "test-vpc--proxy--proxy--bouncing-serpent-graffiti-evasion" {
+  security_group = ibm_is_security_group.test-vpc--proxy.id
+  target         = data.ibm_is_instance_network_interface.test-vpc--proxy--bouncing-serpent-graffiti-evasion.id
+}
@@ -0,0 +1,59 @@
+### SG sg1 is attached to ni3a, ni3b, ni1, ni2
+resource "ibm_is_security_group" "sg1" {
+  name           = "sg-sg1"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc1_id
+}
+resource "ibm_is_security_group_rule" "sg1-0" {
+  group     = ibm_is_security_group.sg1.id
+  direction = "inbound"
+  local     = "0.0.0.0/0"
+  remote    = "0.0.0.0/0"
+}
+resource "ibm_is_security_group_rule" "sg1-1" {
+  group     = ibm_is_security_group.sg1.id
+  direction = "outbound"
+  local     = "0.0.0.0/0"
+  remote    = "0.0.0.0/0"
+}
+resource "ibm_is_security_group_rule" "sg1-2" {
+  group      = ibm_is_security_group.sg1.id
+  direction  = "outbound"
+  local      = "::/0"
+  remote     = "2001:db8:0:20::/64"
+  ip_version = "ipv6"
+  tcp {
+    port_min = 443
+    port_max = 443
+  }
+}
+resource "ibm_is_security_group_rule" "sg1-3" {
+  group      = ibm_is_security_group.sg1.id
+  direction  = "outbound"
+  local      = "::/0"
+  remote     = "::/0"
+  ip_version = "ipv6"
+  udp {
+    port_min = 53
+    port_max = 53
+  }
+}
+
+### SG wombat-hesitate-scorn-subprime is not attached to anything
+resource "ibm_is_security_group" "wombat-hesitate-scorn-subprime" {
+  name           = "sg-wombat-hesitate-scorn-subprime"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc1_id
+}
+resource "ibm_is_security_group_rule" "wombat-hesitate-scorn-subprime-0" {
+  group     = ibm_is_security_group.wombat-hesitate-scorn-subprime.id
+  direction = "inbound"
+  local     = "0.0.0.0/0"
+  remote    = ibm_is_security_group.wombat-hesitate-scorn-subprime.id
+}
+resource "ibm_is_security_group_rule" "wombat-hesitate-scorn-subprime-1" {
+  group     = ibm_is_security_group.wombat-hesitate-scorn-subprime.id
+  direction = "outbound"
+  local     = "0.0.0.0/0"
+  remote    = "0.0.0.0/0"
+}
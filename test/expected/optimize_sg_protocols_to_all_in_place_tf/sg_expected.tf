@@ -0,0 +1,147 @@
+### SG sg1 is not attached to anything
+resource "ibm_is_security_group" "sg1" {
+  name           = "sg-sg1"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc1_id
+}
+import {
+  to = ibm_is_security_group.sg1
+  id = "id:135"
+}
+resource "ibm_is_security_group_rule" "sg1-0" {
+  group     = ibm_is_security_group.sg1.id
+  direction = "inbound"
+  local     = "0.0.0.0/0"
+  remote    = "0.0.0.0/0"
+}
+resource "ibm_is_security_group_rule" "sg1-1" {
+  group     = ibm_is_security_group.sg1.id
+  direction = "outbound"
+  local     = "0.0.0.0/0"
+  remote    = "0.0.0.0/0"
+}
+import {
+  to = ibm_is_security_group_rule.sg1-0
+  id = "id:135.id:137"
+}
+import {
+  to = ibm_is_security_group_rule.sg1-1
+  id = "id:135.id:139"
+}
+
+### SG test-vpc1--vsi1 is attached to ni1
+resource "ibm_is_security_group" "test-vpc1--vsi1" {
+  name           = "sg-test-vpc1--vsi1"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc1_id
+}
+import {
+  to = ibm_is_security_group.test-vpc1--vsi1
+  id = "fake:id:2"
+}
+resource "ibm_is_security_group_rule" "test-vpc1--vsi1-0" {
+  group     = ibm_is_security_group.test-vpc1--vsi1.id
+  direction = "outbound"
+  local     = "0.0.0.0/0"
+  remote    = ibm_is_security_group.test-vpc1--vsi2.id
+}
+resource "ibm_is_security_group_rule" "test-vpc1--vsi1-1" {
+  group     = ibm_is_security_group.test-vpc1--vsi1.id
+  direction = "outbound"
+  local     = "0.0.0.0/0"
+  remote    = "0.0.0.0/30"
+  icmp {
+  }
+}
+resource "ibm_is_security_group_rule" "test-vpc1--vsi1-2" {
+  group     = ibm_is_security_group.test-vpc1--vsi1.id
+  direction = "outbound"
+  local     = "0.0.0.0/0"
+  remote    = "0.0.0.0/31"
+}
+resource "ibm_is_security_group_rule" "test-vpc1--vsi1-3" {
+  group     = ibm_is_security_group.test-vpc1--vsi1.id
+  direction = "outbound"
+  local     = "10.240.0.0/16"
+  remote    = ibm_is_security_group.test-vpc1--vsi3a.id
+}
+import {
+  to = ibm_is_security_group_rule.test-vpc1--vsi1-1
+  id = "fake:id:2.fake:id:8"
+}
+
+### SG test-vpc1--vsi2 is attached to ni2
+resource "ibm_is_security_group" "test-vpc1--vsi2" {
+  name           = "sg-test-vpc1--vsi2"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc1_id
+}
+import {
+  to = ibm_is_security_group.test-vpc1--vsi2
+  id = "fake:id:7"
+}
+resource "ibm_is_security_group_rule" "test-vpc1--vsi2-0" {
+  group     = ibm_is_security_group.test-vpc1--vsi2.id
+  direction = "inbound"
+  local     = "0.0.0.0/0"
+  remote    = ibm_is_security_group.test-vpc1--vsi1.id
+}
+
+### SG test-vpc1--vsi3a is attached to ni3a
+resource "ibm_is_security_group" "test-vpc1--vsi3a" {
+  name           = "sg-test-vpc1--vsi3a"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc1_id
+}
+import {
+  to = ibm_is_security_group.test-vpc1--vsi3a
+  id = "fake:id:19"
+}
+resource "ibm_is_security_group_rule" "test-vpc1--vsi3a-0" {
+  group     = ibm_is_security_group.test-vpc1--vsi3a.id
+  direction = "inbound"
+  local     = "10.240.0.0/16"
+  remote    = ibm_is_security_group.test-vpc1--vsi1.id
+}
+
+### SG test-vpc1--vsi3b is attached to ni3b
+resource "ibm_is_security_group" "test-vpc1--vsi3b" {
+  name           = "sg-test-vpc1--vsi3b"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc1_id
+}
+import {
+  to = ibm_is_security_group.test-vpc1--vsi3b
+  id = "fake:id:18"
+}
+
+### SG wombat-hesitate-scorn-subprime is not attached to anything
+resource "ibm_is_security_group" "wombat-hesitate-scorn-subprime" {
+  name           = "sg-wombat-hesitate-scorn-subprime"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc1_id
+}
+import {
+  to = ibm_is_security_group.wombat-hesitate-scorn-subprime
+  id = "id:15"
+}
+resource "ibm_is_security_group_rule" "wombat-hesitate-scorn-subprime-0" {
+  group     = ibm_is_security_group.wombat-hesitate-scorn-subprime.id
+  direction = "inbound"
+  local     = "0.0.0.0/0"
+  remote    = ibm_is_security_group.wombat-hesitate-scorn-subprime.id
+}
+resource "ibm_is_security_group_rule" "wombat-hesitate-scorn-subprime-1" {
+  group     = ibm_is_security_group.wombat-hesitate-scorn-subprime.id
+  direction = "outbound"
+  local     = "0.0.0.0/0"
+  remote    = "0.0.0.0/0"
+}
+import {
+  to = ibm_is_security_group_rule.wombat-hesitate-scorn-subprime-0
+  id = "id:15.id:143"
+}
+import {
+  to = ibm_is_security_group_rule.wombat-hesitate-scorn-subprime-1
+  id = "id:15.id:141"
+}
@@ -0,0 +1,236 @@
+### SG appdata-sg is attached to appdata-endpoint-gateway
+resource "ibm_is_security_group" "appdata-sg" {
+  name           = "sg-appdata-sg"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc_id
+}
+moved {
+  from = ibm_is_security_group.appdata_sg
+  to   = ibm_is_security_group.appdata-sg
+}
+resource "ibm_is_security_group_rule" "appdata-sg-0" {
+  group     = ibm_is_security_group.appdata-sg.id
+  direction = "inbound"
+  local     = "0.0.0.0/0"
+  remote    = "0.0.0.0/0"
+  tcp {
+  }
+}
+
+### SG appdata-vpe is attached to captain-captivity-shorty-crown
+resource "ibm_is_security_group" "appdata-vpe" {
+  name           = "sg-appdata-vpe"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc_id
+}
+moved {
+  from = ibm_is_security_group.appdata_vpe
+  to   = ibm_is_security_group.appdata-vpe
+}
+resource "ibm_is_security_group_rule" "appdata-vpe-0" {
+  group     = ibm_is_security_group.appdata-vpe.id
+  direction = "outbound"
+  local     = "0.0.0.0/0"
+  remote    = "10.240.128.8"
+  tcp {
+  }
+}
+moved {
+  from = ibm_is_security_group_rule.appdata_vpe_0
+  to   = ibm_is_security_group_rule.appdata-vpe-0
+}
+
+### SG be-sg is attached to captain-captivity-shorty-crown
+resource "ibm_is_security_group" "be-sg" {
+  name           = "sg-be-sg"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc_id
+}
+moved {
+  from = ibm_is_security_group.be_sg
+  to   = ibm_is_security_group.be-sg
+}
+resource "ibm_is_security_group_rule" "be-sg-0" {
+  group     = ibm_is_security_group.be-sg.id
+  direction = "inbound"
+  local     = "0.0.0.0/0"
+  remote    = ibm_is_security_group.fe-sg.id
+  tcp {
+  }
+}
+resource "ibm_is_security_group_rule" "be-sg-1" {
+  group     = ibm_is_security_group.be-sg.id
+  direction = "outbound"
+  local     = "0.0.0.0/0"
+  remote    = ibm_is_security_group.opa-sg.id
+  tcp {
+    port_min = 8181
+    port_max = 8181
+  }
+}
+moved {
+  from = ibm_is_security_group_rule.be_sg_0
+  to   = ibm_is_security_group_rule.be-sg-0
+}
+moved {
+  from = ibm_is_security_group_rule.be_sg_1
+  to   = ibm_is_security_group_rule.be-sg-1
+}
+
+### SG fe-sg is attached to litigate-bullfrog-improve-shandy
+resource "ibm_is_security_group" "fe-sg" {
+  name           = "sg-fe-sg"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc_id
+}
+moved {
+  from = ibm_is_security_group.fe_sg
+  to   = ibm_is_security_group.fe-sg
+}
+resource "ibm_is_security_group_rule" "fe-sg-0" {
+  group     = ibm_is_security_group.fe-sg.id
+  direction = "inbound"
+  local     = "0.0.0.0/0"
+  remote    = ibm_is_security_group.proxy-sg.id
+  udp {
+    port_min = 9000
+    port_max = 9000
+  }
+}
+resource "ibm_is_security_group_rule" "fe-sg-1" {
+  group     = ibm_is_security_group.fe-sg.id
+  direction = "outbound"
+  local     = "0.0.0.0/0"
+  remote    = ibm_is_security_group.be-sg.id
+  tcp {
+  }
+}
+
+### SG impart-oxidize-chive-escapade is not attached to anything
+resource "ibm_is_security_group" "impart-oxidize-chive-escapade" {
+  name           = "sg-impart-oxidize-chive-escapade"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc_id
+}
+moved {
+  from = ibm_is_security_group.impart_oxidize_chive_escapade
+  to   = ibm_is_security_group.impart-oxidize-chive-escapade
+}
+resource "ibm_is_security_group_rule" "impart-oxidize-chive-escapade-0" {
+  group     = ibm_is_security_group.impart-oxidize-chive-escapade.id
+  direction = "inbound"
+  local     = "0.0.0.0/0"
+  remote    = ibm_is_security_group.impart-oxidize-chive-escapade.id
+}
+resource "ibm_is_security_group_rule" "impart-oxidize-chive-escapade-1" {
+  group     = ibm_is_security_group.impart-oxidize-chive-escapade.id
+  direction = "outbound"
+  local     = "0.0.0.0/0"
+  remote    = "0.0.0.0/0"
+}
+
+### SG opa-sg is attached to left-pebble-agonizing-wharf
+resource "ibm_is_security_group" "opa-sg" {
+  name           = "sg-opa-sg"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc_id
+}
+moved {
+  from = ibm_is_security_group.opa_sg
+  to   = ibm_is_security_group.opa-sg
+}
+resource "ibm_is_security_group_rule" "opa-sg-0" {
+  group     = ibm_is_security_group.opa-sg.id
+  direction = "inbound"
+  local     = "0.0.0.0/0"
+  remote    = ibm_is_security_group.be-sg.id
+  tcp {
+    port_min = 8181
+    port_max = 8181
+  }
+}
+
+### SG policydb-sg is attached to policydb-endpoint-gateway
+resource "ibm_is_security_group" "policydb-sg" {
+  name           = "sg-policydb-sg"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc_id
+}
+moved {
+  from = ibm_is_security_group.policydb_sg
+  to   = ibm_is_security_group.policydb-sg
+}
+resource "ibm_is_security_group_rule" "policydb-sg-0" {
+  group     = ibm_is_security_group.policydb-sg.id
+  direction = "inbound"
+  local     = "0.0.0.0/0"
+  remote    = "0.0.0.0/0"
+  tcp {
+  }
+}
+moved {
+  from = ibm_is_security_group_rule.policydb_sg_0
+  to   = ibm_is_security_group_rule.policydb-sg-0
+}
+
+### SG policydb-vpe is attached to captain-captivity-shorty-crown
+resource "ibm_is_security_group" "policydb-vpe" {
+  name           = "sg-policydb-vpe"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc_id
+}
+moved {
+  from = ibm_is_security_group.policydb_vpe
+  to   = ibm_is_security_group.policydb-vpe
+}
+resource "ibm_is_security_group_rule" "policydb-vpe-0" {
+  group     = ibm_is_security_group.policydb-vpe.id
+  direction = "outbound"
+  local     = "0.0.0.0/0"
+  remote    = "10.240.128.7"
+  tcp {
+  }
+}
+resource "ibm_is_security_group_rule" "policydb-vpe-1" {
+  group     = ibm_is_security_group.policydb-vpe.id
+  direction = "outbound"
+  local     = "0.0.0.0/0"
+  remote    = "10.240.64.4"
+  tcp {
+  }
+}
+
+### SG proxy-sg is attached to bouncing-serpent-graffiti-evasion
+resource "ibm_is_security_group" "proxy-sg" {
+  name           = "sg-proxy-sg"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc_id
+}
+moved {
+  from = ibm_is_security_group.proxy_sg
+  to   = ibm_is_security_group.proxy-sg
+}
+resource "ibm_is_security_group_rule" "proxy-sg-0" {
+  group     = ibm_is_security_group.proxy-sg.id
+  direction = "inbound"
+  local     = "0.0.0.0/0"
+  remote    = "0.0.0.0/0"
+}
+resource "ibm_is_security_group_rule" "proxy-sg-1" {
+  group     = ibm_is_security_group.proxy-sg.id
+  direction = "outbound"
+  local     = "0.0.0.0/0"
+  remote    = ibm_is_security_group.fe-sg.id
+  udp {
+    port_min = 9000
+    port_max = 9000
+  }
+}
+moved {
+  from = ibm_is_security_group_rule.proxy_sg_0
+  to   = ibm_is_security_group_rule.proxy-sg-0
+}
+moved {
+  from = ibm_is_security_group_rule.proxy_sg_1
+  to   = ibm_is_security_group_rule.proxy-sg-1
+}
@@ -0,0 +1,110 @@
+### SG test-vpc1--vsi1 is attached to test-vpc1/vsi1
+resource "ibm_is_security_group" "test-vpc1--vsi1" {
+  name           = "sg-test-vpc1--vsi1"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc1_id
+}
+# External. required-connections[1]: (instance test-vpc1/vsi1)->(external ipv4 dns); allowed-protocols[0]
+resource "ibm_is_security_group_rule" "test-vpc1--vsi1-0" {
+  group     = ibm_is_security_group.test-vpc1--vsi1.id
+  direction = "outbound"
+  local     = "0.0.0.0/0"
+  remote    = "8.8.8.8"
+  udp {
+    port_min = 53
+    port_max = 53
+  }
+}
+# Internal. required-connections[2]: (instance test-vpc1/vsi1)->(instance test-vpc1/vsi2); allowed-protocols[0]
+resource "ibm_is_security_group_rule" "test-vpc1--vsi1-1" {
+  group     = ibm_is_security_group.test-vpc1--vsi1.id
+  direction = "outbound"
+  local     = "0.0.0.0/0"
+  remote    = ibm_is_security_group.test-vpc1--vsi2.id
+  tcp {
+    port_min = 443
+    port_max = 443
+  }
+}
+# External. required-connections[0]: (instance test-vpc1/vsi1)->(external ipv6 dns); allowed-protocols[0]
+resource "ibm_is_security_group_rule" "test-vpc1--vsi1-2" {
+  group      = ibm_is_security_group.test-vpc1--vsi1.id
+  direction  = "outbound"
+  local      = "::/0"
+  remote     = "2001:4860:4860::8888"
+  ip_version = "ipv6"
+  udp {
+    port_min = 53
+    port_max = 53
+  }
+}
+# Internal. required-connections[2]: (instance test-vpc1/vsi1)->(instance test-vpc1/vsi2); allowed-protocols[0]
+resource "ibm_is_security_group_rule" "test-vpc1--vsi1-3" {
+  group      = ibm_is_security_group.test-vpc1--vsi1.id
+  direction  = "outbound"
+  local      = "::/0"
+  remote     = ibm_is_security_group.test-vpc1--vsi2.id
+  ip_version = "ipv6"
+  tcp {
+    port_min = 443
+    port_max = 443
+  }
+}
+
+### SG test-vpc1--vsi2 is attached to test-vpc1/vsi2
+resource "ibm_is_security_group" "test-vpc1--vsi2" {
+  name           = "sg-test-vpc1--vsi2"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc1_id
+}
+# Internal. required-connections[2]: (instance test-vpc1/vsi1)->(instance test-vpc1/vsi2); allowed-protocols[0]
+resource "ibm_is_security_group_rule" "test-vpc1--vsi2-0" {
+  group     = ibm_is_security_group.test-vpc1--vsi2.id
+  direction = "inbound"
+  local     = "0.0.0.0/0"
+  remote    = ibm_is_security_group.test-vpc1--vsi1.id
+  tcp {
+    port_min = 443
+    port_max = 443
+  }
+}
+# Internal. required-connections[2]: (instance test-vpc1/vsi1)->(instance test-vpc1/vsi2); allowed-protocols[0]
+resource "ibm_is_security_group_rule" "test-vpc1--vsi2-1" {
+  group      = ibm_is_security_group.test-vpc1--vsi2.id
+  direction  = "inbound"
+  local      = "::/0"
+  remote     = ibm_is_security_group.test-vpc1--vsi1.id
+  ip_version = "ipv6"
+  tcp {
+    port_min = 443
+    port_max = 443
+  }
+}
+# Internal. required-connections[3]: (instance test-vpc1/vsi2)->(instance test-vpc1/vsi3a); allowed-protocols[0]
+resource "ibm_is_security_group_rule" "test-vpc1--vsi2-2" {
+  group     = ibm_is_security_group.test-vpc1--vsi2.id
+  direction = "outbound"
+  local     = "0.0.0.0/0"
+  remote    = ibm_is_security_group.test-vpc1--vsi3a.id
+}
+
+### SG test-vpc1--vsi3a is attached to test-vpc1/vsi3a
+resource "ibm_is_security_group" "test-vpc1--vsi3a" {
+  name           = "sg-test-vpc1--vsi3a"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc1_id
+}
+# Internal. required-connections[3]: (instance test-vpc1/vsi2)->(instance test-vpc1/vsi3a); allowed-protocols[0]
+resource "ibm_is_security_group_rule" "test-vpc1--vsi3a-0" {
+  group     = ibm_is_security_group.test-vpc1--vsi3a.id
+  direction = "inbound"
+  local     = "0.0.0.0/0"
+  remote    = ibm_is_security_group.test-vpc1--vsi2.id
+}
+
+### SG test-vpc1--vsi3b is attached to test-vpc1/vsi3b
+resource "ibm_is_security_group" "test-vpc1--vsi3b" {
+  name           = "sg-test-vpc1--vsi3b"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc1_id
+}
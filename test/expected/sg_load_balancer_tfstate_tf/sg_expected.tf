@@ -0,0 +1,99 @@
+### SG test-vpc--appdata-endpoint-gateway is attached to test-vpc/appdata-endpoint-gateway
+resource "ibm_is_security_group" "test-vpc--appdata-endpoint-gateway" {
+  name           = "sg-test-vpc--appdata-endpoint-gateway"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc_id
+}
+
+### SG test-vpc--be is attached to test-vpc/be
+resource "ibm_is_security_group" "test-vpc--be" {
+  name           = "sg-test-vpc--be"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc_id
+}
+
+### SG test-vpc--fe is attached to test-vpc/fe
+resource "ibm_is_security_group" "test-vpc--fe" {
+  name           = "sg-test-vpc--fe"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc_id
+}
+# Internal. required-connections[2]: (segment frontend-lbs)->(instance test-vpc/fe); allowed-protocols[0]
+resource "ibm_is_security_group_rule" "test-vpc--fe-0" {
+  group     = ibm_is_security_group.test-vpc--fe.id
+  direction = "inbound"
+  local     = "0.0.0.0/0"
+  remote    = ibm_is_security_group.test-vpc--fe-lb.id
+  tcp {
+    port_min = 9000
+    port_max = 9000
+  }
+}
+
+### SG test-vpc--fe-lb is attached to test-vpc/fe-lb
+resource "ibm_is_security_group" "test-vpc--fe-lb" {
+  name           = "sg-test-vpc--fe-lb"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc_id
+}
+# Internal. required-connections[1]: (instance test-vpc/proxy)->(load_balancer test-vpc/fe-lb); allowed-protocols[0]
+resource "ibm_is_security_group_rule" "test-vpc--fe-lb-0" {
+  group     = ibm_is_security_group.test-vpc--fe-lb.id
+  direction = "inbound"
+  local     = "0.0.0.0/0"
+  remote    = ibm_is_security_group.test-vpc--proxy.id
+  tcp {
+    port_min = 443
+    port_max = 443
+  }
+}
+# Internal. required-connections[2]: (segment frontend-lbs)->(instance test-vpc/fe); allowed-protocols[0]
+resource "ibm_is_security_group_rule" "test-vpc--fe-lb-1" {
+  group     = ibm_is_security_group.test-vpc--fe-lb.id
+  direction = "outbound"
+  local     = "0.0.0.0/0"
+  remote    = ibm_is_security_group.test-vpc--fe.id
+  tcp {
+    port_min = 9000
+    port_max = 9000
+  }
+}
+
+### SG test-vpc--opa is attached to test-vpc/opa
+resource "ibm_is_security_group" "test-vpc--opa" {
+  name           = "sg-test-vpc--opa"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc_id
+}
+
+### SG test-vpc--policydb-endpoint-gateway is attached to test-vpc/policydb-endpoint-gateway
+resource "ibm_is_security_group" "test-vpc--policydb-endpoint-gateway" {
+  name           = "sg-test-vpc--policydb-endpoint-gateway"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc_id
+}
+
+### SG test-vpc--proxy is attached to test-vpc/proxy
+resource "ibm_is_security_group" "test-vpc--proxy" {
+  name           = "sg-test-vpc--proxy"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc_id
+}
+# External. required-connections[0]: (external public internet)->(instance test-vpc/proxy); allowed-protocols[0]
+resource "ibm_is_security_group_rule" "test-vpc--proxy-0" {
+  group     = ibm_is_security_group.test-vpc--proxy.id
+  direction = "inbound"
+  local     = "0.0.0.0/0"
+  remote    = "0.0.0.0/0"
+}
+# Internal. required-connections[1]: (instance test-vpc/proxy)->(load_balancer test-vpc/fe-lb); allowed-protocols[0]
+resource "ibm_is_security_group_rule" "test-vpc--proxy-1" {
+  group     = ibm_is_security_group.test-vpc--proxy.id
+  direction = "outbound"
+  local     = "0.0.0.0/0"
+  remote    = ibm_is_security_group.test-vpc--fe-lb.id
+  tcp {
+    port_min = 443
+    port_max = 443
+  }
+}
@@ -0,0 +1,160 @@
+### SG test-vpc0--vsi0-subnet0 is attached to test-vpc0/vsi0-subnet0
+resource "ibm_is_security_group" "test-vpc0--vsi0-subnet0" {
+  name           = "sg-test-vpc0--vsi0-subnet0"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc0_id
+}
+# External. required-connections[0]: (instance test-vpc0/vsi0-subnet0)->(external public internet); allowed-protocols[0]
+resource "ibm_is_security_group_rule" "test-vpc0--vsi0-subnet0-0" {
+  group     = ibm_is_security_group.test-vpc0--vsi0-subnet0.id
+  direction = "outbound"
+  local     = "0.0.0.0/0"
+  remote    = "0.0.0.0/0"
+}
+
+### SG test-vpc0--vsi0-subnet1 is attached to test-vpc0/vsi0-subnet1
+resource "ibm_is_security_group" "test-vpc0--vsi0-subnet1" {
+  name           = "sg-test-vpc0--vsi0-subnet1"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc0_id
+}
+
+### SG test-vpc0--vsi0-subnet2 is attached to test-vpc0/vsi0-subnet2
+resource "ibm_is_security_group" "test-vpc0--vsi0-subnet2" {
+  name           = "sg-test-vpc0--vsi0-subnet2"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc0_id
+}
+
+### SG test-vpc0--vsi0-subnet3 is attached to test-vpc0/vsi0-subnet3
+resource "ibm_is_security_group" "test-vpc0--vsi0-subnet3" {
+  name           = "sg-test-vpc0--vsi0-subnet3"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc0_id
+}
+
+### SG test-vpc0--vsi0-subnet4 is attached to test-vpc0/vsi0-subnet4
+resource "ibm_is_security_group" "test-vpc0--vsi0-subnet4" {
+  name           = "sg-test-vpc0--vsi0-subnet4"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc0_id
+}
+
+### SG test-vpc0--vsi0-subnet5 is attached to test-vpc0/vsi0-subnet5
+resource "ibm_is_security_group" "test-vpc0--vsi0-subnet5" {
+  name           = "sg-test-vpc0--vsi0-subnet5"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc0_id
+}
+
+### SG test-vpc0--vsi1-subnet0 is attached to test-vpc0/vsi1-subnet0
+resource "ibm_is_security_group" "test-vpc0--vsi1-subnet0" {
+  name           = "sg-test-vpc0--vsi1-subnet0"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc0_id
+}
+
+### SG test-vpc0--vsi1-subnet1 is attached to test-vpc0/vsi1-subnet1
+resource "ibm_is_security_group" "test-vpc0--vsi1-subnet1" {
+  name           = "sg-test-vpc0--vsi1-subnet1"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc0_id
+}
+
+### SG test-vpc0--vsi1-subnet2 is attached to test-vpc0/vsi1-subnet2
+resource "ibm_is_security_group" "test-vpc0--vsi1-subnet2" {
+  name           = "sg-test-vpc0--vsi1-subnet2"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc0_id
+}
+
+### SG test-vpc0--vsi1-subnet3 is attached to test-vpc0/vsi1-subnet3
+resource "ibm_is_security_group" "test-vpc0--vsi1-subnet3" {
+  name           = "sg-test-vpc0--vsi1-subnet3"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc0_id
+}
+
+### SG test-vpc0--vsi1-subnet4 is attached to test-vpc0/vsi1-subnet4
+resource "ibm_is_security_group" "test-vpc0--vsi1-subnet4" {
+  name           = "sg-test-vpc0--vsi1-subnet4"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc0_id
+}
+
+### SG test-vpc0--vsi1-subnet5 is attached to test-vpc0/vsi1-subnet5
+resource "ibm_is_security_group" "test-vpc0--vsi1-subnet5" {
+  name           = "sg-test-vpc0--vsi1-subnet5"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc0_id
+}
+
+### SG test-vpc1--vsi0-subnet10 is attached to test-vpc1/vsi0-subnet10
+resource "ibm_is_security_group" "test-vpc1--vsi0-subnet10" {
+  name           = "sg-test-vpc1--vsi0-subnet10"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc1_id
+}
+# External. required-connections[1]: (external public internet)->(instance test-vpc1/vsi0-subnet10); allowed-protocols[0]
+resource "ibm_is_security_group_rule" "test-vpc1--vsi0-subnet10-0" {
+  group     = ibm_is_security_group.test-vpc1--vsi0-subnet10.id
+  direction = "inbound"
+  local     = "0.0.0.0/0"
+  remote    = "0.0.0.0/0"
+}
+
+### SG test-vpc1--vsi0-subnet11 is attached to test-vpc1/vsi0-subnet11
+resource "ibm_is_security_group" "test-vpc1--vsi0-subnet11" {
+  name           = "sg-test-vpc1--vsi0-subnet11"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc1_id
+}
+# External. required-connections[2]: (instance test-vpc1/vsi0-subnet11)->(external public internet); allowed-protocols[0]
+resource "ibm_is_security_group_rule" "test-vpc1--vsi0-subnet11-0" {
+  group     = ibm_is_security_group.test-vpc1--vsi0-subnet11.id
+  direction = "outbound"
+  local     = "0.0.0.0/0"
+  remote    = "0.0.0.0/0"
+}
+# External. required-connections[4]: (instance test-vpc1/vsi0-subnet11)->(external on-prem); allowed-protocols[0]
+resource "ibm_is_security_group_rule" "test-vpc1--vsi0-subnet11-1" {
+  group     = ibm_is_security_group.test-vpc1--vsi0-subnet11.id
+  direction = "outbound"
+  local     = "0.0.0.0/0"
+  remote    = "192.168.0.0/16"
+}
+
+### SG test-vpc2--vsi0-subnet20 is attached to test-vpc2/vsi0-subnet20
+resource "ibm_is_security_group" "test-vpc2--vsi0-subnet20" {
+  name           = "sg-test-vpc2--vsi0-subnet20"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc2_id
+}
+# External. required-connections[3]: (external public internet)->(instance test-vpc2/vsi0-subnet20); allowed-protocols[0]
+resource "ibm_is_security_group_rule" "test-vpc2--vsi0-subnet20-0" {
+  group     = ibm_is_security_group.test-vpc2--vsi0-subnet20.id
+  direction = "inbound"
+  local     = "0.0.0.0/0"
+  remote    = "0.0.0.0/0"
+}
+
+### SG test-vpc2--vsi1-subnet20 is attached to test-vpc2/vsi1-subnet20
+resource "ibm_is_security_group" "test-vpc2--vsi1-subnet20" {
+  name           = "sg-test-vpc2--vsi1-subnet20"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc2_id
+}
+
+### SG test-vpc2--vsi2-subnet20 is attached to test-vpc2/vsi2-subnet20
+resource "ibm_is_security_group" "test-vpc2--vsi2-subnet20" {
+  name           = "sg-test-vpc2--vsi2-subnet20"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc2_id
+}
+
+### SG test-vpc3--vsi0-subnet30 is attached to test-vpc3/vsi0-subnet30
+resource "ibm_is_security_group" "test-vpc3--vsi0-subnet30" {
+  name           = "sg-test-vpc3--vsi0-subnet30"
+  resource_group = local.sg_synth_resource_group_id
+  vpc            = local.sg_synth_test-vpc3_id
+}
@@ -6,6 +6,8 @@ SPDX-License-Identifier: Apache-2.0
 package test
 
 import (
+	"encoding/json"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -45,6 +47,65 @@ func testMain(t *testing.T) {
 	removeGeneratedFiles()
 }
 
+// TestJSONDiagnosticsStdout checks that with json diagnostics, stdout is a JSON list that a CI gate can parse
+func TestJSONDiagnosticsStdout(t *testing.T) {
+	tests := []*command{
+		{
+			cmd:               synthesis,
+			subcmd:            sg,
+			config:            sgTesting3Config,
+			spec:              sgTesting3Spec,
+			format:            "csv",
+			outputFile:        "%s/json_diagnostics_sg.csv",
+			diagnosticsFormat: "json",
+		},
+		{
+			cmd:               verify,
+			subcmd:            acl,
+			config:            aclTesting5Config,
+			spec:              aclTesting5Spec,
+			outputFile:        "%s/json_diagnostics_report.txt",
+			diagnosticsFormat: "json",
+		},
+	}
+	if err := os.MkdirAll(resultsFolder, defaultDirectoryPermission); err != nil {
+		t.Fatalf("error creating folder for results: %v", err)
+	}
+	for _, args := range tests {
+		stdout := captureStdout(t, func() {
+			if _, err := subcmds.Main(args.Args(dataFolder, resultsFolder)); err != nil {
+				t.Errorf("%s %s: unexpected err: %v", args.cmd, args.subcmd, err)
+			}
+		})
+		var diagnostics []map[string]any
+		if err := json.Unmarshal([]byte(stdout), &diagnostics); err != nil {
+			t.Errorf("%s %s: stdout is not a JSON list: %v\n%s", args.cmd, args.subcmd, err, stdout)
+		} else if len(diagnostics) == 0 {
+			t.Errorf("%s %s: expected diagnostics in stdout", args.cmd, args.subcmd)
+		}
+	}
+	removeGeneratedFiles()
+}
+
+// captureStdout returns what f prints to stdout
+func captureStdout(t *testing.T, f func()) string {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	out := make(chan []byte)
+	go func() {
+		data, _ := io.ReadAll(r)
+		out <- data
+	}()
+	f()
+	os.Stdout = stdout
+	w.Close()
+	return string(<-out)
+}
+
 func compareTestResults(t *testing.T, testName string) {
 	expectedSubDirPath := filepath.Join(expectedFolder, testName)
 	expectedDirFiles := readDir(t, expectedSubDirPath)
@@ -26,6 +26,17 @@ const (
 	optimizeACL6Config             = "%s/optimize_acl6_issue248_example1/config_object.json"
 	optimizeACLAnyProtocolConfig   = "%s/optimize_acl_anyProtocol/config_object.json"
 	optimizeSGProtocolsToAllConfig = "%s/optimize_sg_protocols_to_all/config_object.json"
+	tfstateSGTesting3Config        = "%s/tfstate_sg_testing3/tfstate.json"
+	tfstateACLTesting5Config       = "%s/tfstate_acl_testing5/tfstate.json"
+	tfstateTgMultipleConfig        = "%s/tfstate_tg_multiple/tfstate.json"
+	loadBalancerConfig             = "%s/load_balancer/config_object.json"
+	tfstateLoadBalancerConfig      = "%s/tfstate_load_balancer/tfstate.json"
+	virtualNifConfig               = "%s/virtual_nif/config_object.json"
+	tfstateBareMetalConfig         = "%s/tfstate_bare_metal/tfstate.json"
+	secondaryIPsConfig             = "%s/secondary_ips/config_object.json"
+	tfstateSecondaryIPsConfig      = "%s/tfstate_secondary_ips/tfstate.json"
+	multiNifConfig                 = "%s/multi_nif/config_object.json"
+	ipv6Config                     = "%s/ipv6/config_object.json"
 
 	aclExternalsSpec           = "%s/acl_externals/conn_spec.json"
 	aclNifSpec                 = "%s/acl_nif/conn_spec.json"
@@ -33,7 +44,10 @@ const (
 	aclProtocolsSpec           = "%s/acl_protocols/conn_spec.json"
 	aclSubnetCidrSegmentsSpec  = "%s/acl_subnet_cidr_segments/conn_spec.json"
 	aclTesting5Spec            = "%s/acl_testing5/conn_spec.json"
+	aclTesting5YAMLSpec        = "%s/acl_testing5_yaml/conn_spec.yaml"
+	aclForbiddenSpec           = "%s/acl_forbidden/conn_spec.json"
 	aclTgMultipleSpec          = "%s/acl_tg_multiple/conn_spec.json"
+	aclTgRoutingSpec           = "%s/acl_tg_routing/conn_spec.json"
 	aclVpeSpec                 = "%s/acl_vpe/conn_spec.json"
 	sgProtocolsSpec            = "%s/sg_protocols/conn_spec.json"
 	sgSegments1Spec            = "%s/sg_segments1/conn_spec.json"
@@ -42,13 +56,22 @@ const (
 	sgSegments4Spec            = "%s/sg_segments4/conn_spec.json"
 	sgTesting3Spec             = "%s/sg_testing3/conn_spec.json"
 	sgTgMultipleSpec           = "%s/sg_tg_multiple/conn_spec.json"
+	sgPublicPathSpec           = "%s/sg_public_path/conn_spec.json"
+	sgSynthOptimizeSpec        = "%s/sg_synth_optimize/conn_spec.json"
+	loadBalancerSpec           = "%s/load_balancer/conn_spec.json"
+	virtualNifSpec             = "%s/virtual_nif/conn_spec.json"
+	secondaryIPsSpec           = "%s/secondary_ips/conn_spec.json"
+	multiNifSpec               = "%s/multi_nif/conn_spec.json"
+	aclIPv6Spec                = "%s/acl_ipv6/conn_spec.json"
+	sgIPv6Spec                 = "%s/sg_ipv6/conn_spec.json"
 
 	tfOutputFmt = "tf"
 	vsi1        = "test-vpc1--vsi1"
 )
 
 func allMainTests() []testCase {
-	return slices.Concat(synthACLTestsList(), synthSGTestsList(), optimizeSGTestsLists(), optimizeACLTestsLists())
+	return slices.Concat(synthACLTestsList(), synthSGTestsList(), synthAllTestsList(), optimizeSGTestsLists(), optimizeACLTestsLists(),
+		verifyTestsLists(), diffTestsLists(), queryTestsLists(), explainTestsLists())
 }
 
 //nolint:funlen //all acl synthesis tests
@@ -76,6 +99,18 @@ func synthACLTestsList() []testCase {
 			},
 			expectedWarning: utils.Ptr(fmt.Sprint(synth.WarningUnspecifiedACL, "test-vpc1/subnet3")),
 		},
+		{
+			testName: "acl_externals_internal_cidrs_tf",
+			args: &command{
+				cmd:           synthesis,
+				subcmd:        acl,
+				config:        aclTesting4Config,
+				spec:          aclExternalsSpec,
+				internalCidrs: "10.0.0.0/8,172.16.0.0/12",
+				outputFile:    "%s/acl_externals_internal_cidrs_tf/nacl_expected.tf",
+			},
+			expectedWarning: utils.Ptr(fmt.Sprint(synth.WarningUnspecifiedACL, "test-vpc1/subnet3")),
+		},
 
 		// acl nif (scoping)
 		{
@@ -149,6 +184,20 @@ func synthACLTestsList() []testCase {
 			expectedWarning: utils.Ptr(fmt.Sprint(synth.WarningUnspecifiedACL,
 				"test-vpc2/subnet20, test-vpc3/subnet30")),
 		},
+		{
+			testName: "acl_protocols_responses_tf",
+			args: &command{
+				cmd:          synthesis,
+				subcmd:       acl,
+				tcpResponses: "ephemeral",
+				udpResponses: "exact",
+				config:       tgMultipleConfig,
+				spec:         aclProtocolsSpec,
+				outputFile:   "%s/acl_protocols_responses_tf/nacl_expected.tf",
+			},
+			expectedWarning: utils.Ptr(fmt.Sprint(synth.WarningUnspecifiedACL,
+				"test-vpc2/subnet20, test-vpc3/subnet30")),
+		},
 
 		// acl subnet and cidr segments (bidi)
 		{
@@ -197,6 +246,40 @@ func synthACLTestsList() []testCase {
 			},
 			expectedWarning: utils.Ptr(""),
 		},
+		{
+			testName: "acl_testing5_coarse_tf",
+			args: &command{
+				cmd:         synthesis,
+				subcmd:      acl,
+				aclStrategy: "coarse",
+				config:      aclTesting5Config,
+				spec:        aclTesting5Spec,
+				outputFile:  "%s/acl_testing5_coarse_tf/nacl_expected.tf",
+			},
+			expectedWarning: utils.Ptr(""),
+		},
+		{
+			testName: "acl_testing5_yaml_tf",
+			args: &command{
+				cmd:        synthesis,
+				subcmd:     acl,
+				config:     aclTesting5Config,
+				spec:       aclTesting5YAMLSpec,
+				outputFile: "%s/acl_testing5_yaml_tf/nacl_expected.tf",
+			},
+			expectedWarning: utils.Ptr(""),
+		},
+		{
+			testName: "acl_testing5_tfstate_tf",
+			args: &command{
+				cmd:        synthesis,
+				subcmd:     acl,
+				config:     tfstateACLTesting5Config,
+				spec:       aclTesting5Spec,
+				outputFile: "%s/acl_testing5_tfstate_tf/nacl_expected.tf",
+			},
+			expectedWarning: utils.Ptr(""),
+		},
 		{
 			testName: "acl_testing5_tf_single",
 			args: &command{
@@ -208,6 +291,43 @@ func synthACLTestsList() []testCase {
 				outputFile: "%s/acl_testing5_tf_single/nacl_single_expected.tf",
 			},
 		},
+		{
+			testName: "acl_testing5_tf_single_attach",
+			args: &command{
+				cmd:        synthesis,
+				subcmd:     acl,
+				singleacl:  true,
+				attach:     true,
+				config:     aclTesting5Config,
+				spec:       aclTesting5Spec,
+				outputFile: "%s/acl_testing5_tf_single_attach/nacl_single_expected.tf",
+			},
+		},
+
+		// acl forbidden (tf)
+		{
+			testName: "acl_forbidden_tf",
+			args: &command{
+				cmd:        synthesis,
+				subcmd:     acl,
+				config:     aclTesting5Config,
+				spec:       aclForbiddenSpec,
+				outputFile: "%s/acl_forbidden_tf/nacl_expected.tf",
+			},
+		},
+
+		// acl testing 5 optimized (tf)
+		{
+			testName: "acl_testing5_optimize_tf",
+			args: &command{
+				cmd:        synthesis,
+				subcmd:     acl,
+				optimize:   true,
+				config:     aclTesting5Config,
+				spec:       aclTesting5Spec,
+				outputFile: "%s/acl_testing5_optimize_tf/nacl_expected.tf",
+			},
+		},
 
 		// acl tg multiple (json, tf, tf separate)
 		{
@@ -244,6 +364,38 @@ func synthACLTestsList() []testCase {
 			},
 		},
 
+		// acl tg routing    ## tg_multiple config
+		{
+			testName: "acl_tg_routing_tf",
+			args: &command{
+				cmd:        synthesis,
+				subcmd:     acl,
+				config:     tgMultipleConfig,
+				spec:       aclTgRoutingSpec,
+				outputFile: "%s/acl_tg_routing_tf/nacl_expected.tf",
+			},
+			expectedWarning: utils.Ptr(fmt.Sprint(synth.WarningUnroutableConnections,
+				"required-connections[2]: (subnet test-vpc0/subnet4)->(subnet test-vpc1/subnet10), ",
+				"required-connections[4]: (subnet test-vpc1/subnet11)->(subnet test-vpc3/subnet30)\n", synth.WarningUnspecifiedACL,
+				"test-vpc0/subnet1, test-vpc0/subnet3, test-vpc0/subnet5")),
+		},
+
+		// acl tg routing    ## tfstate_tg_multiple config (transit gateways of a terraform state)
+		{
+			testName: "acl_tg_routing_tfstate_tf",
+			args: &command{
+				cmd:        synthesis,
+				subcmd:     acl,
+				config:     tfstateTgMultipleConfig,
+				spec:       aclTgRoutingSpec,
+				outputFile: "%s/acl_tg_routing_tfstate_tf/nacl_expected.tf",
+			},
+			expectedWarning: utils.Ptr(fmt.Sprint(synth.WarningUnroutableConnections,
+				"required-connections[2]: (subnet test-vpc0/subnet4)->(subnet test-vpc1/subnet10), ",
+				"required-connections[4]: (subnet test-vpc1/subnet11)->(subnet test-vpc3/subnet30)\n", synth.WarningUnspecifiedACL,
+				"test-vpc0/subnet1, test-vpc0/subnet3, test-vpc0/subnet5")),
+		},
+
 		// acl vpe    ## sg_testing3 config
 		{
 			testName: "acl_vpe_tf",
@@ -254,8 +406,42 @@ func synthACLTestsList() []testCase {
 				spec:       aclVpeSpec,
 				outputFile: "%s/acl_vpe_tf/nacl_expected.tf",
 			},
+			expectedWarning: utils.Ptr(fmt.Sprint(synth.WarningNoPublicPath,
+				"required-connections[0]: (external public internet)->(vpe test-vpc/appdata-endpoint-gateway)")),
+		},
+		// acl load balancer    ## load_balancer config
+		{
+			testName: "acl_load_balancer_tf",
+			args: &command{
+				cmd:        synthesis,
+				subcmd:     acl,
+				config:     loadBalancerConfig,
+				spec:       loadBalancerSpec,
+				outputFile: "%s/acl_load_balancer_tf/nacl_expected.tf",
+			},
 			expectedWarning: utils.Ptr(""),
 		},
+		// acl ipv6 (dual-stack subnets, ipv6 externals)    ## ipv6 config
+		{
+			testName: "acl_ipv6_json",
+			args: &command{
+				cmd:        synthesis,
+				subcmd:     acl,
+				config:     ipv6Config,
+				spec:       aclIPv6Spec,
+				outputFile: "%s/acl_ipv6_json/nacl_expected.json",
+			},
+		},
+		{
+			testName: "acl_ipv6_tf",
+			args: &command{
+				cmd:        synthesis,
+				subcmd:     acl,
+				config:     ipv6Config,
+				spec:       aclIPv6Spec,
+				outputFile: "%s/acl_ipv6_tf/nacl_expected.tf",
+			},
+		},
 	}
 }
 
@@ -351,7 +537,8 @@ func synthSGTestsList() []testCase {
 				spec:       sgSegments3Spec,
 				outputFile: "%s/sg_segments3_tf/sg_expected.tf",
 			},
-			expectedWarning: utils.Ptr(fmt.Sprint(synth.WarningUnspecifiedSG,
+			expectedWarning: utils.Ptr(fmt.Sprint(synth.WarningUnroutableConnections,
+				"required-connections[0]: (segment subnetSegment)->(segment nifSegment)\n", synth.WarningUnspecifiedSG,
 				"test-vpc0/vsi0-subnet0, test-vpc0/vsi0-subnet1, test-vpc0/vsi0-subnet2, test-vpc0/vsi0-subnet3, ",
 				"test-vpc0/vsi0-subnet5, test-vpc0/vsi1-subnet0, test-vpc0/vsi1-subnet1, test-vpc0/vsi1-subnet2, ",
 				"test-vpc0/vsi1-subnet3, test-vpc0/vsi1-subnet4, test-vpc1/vsi0-subnet11, test-vpc3/vsi0-subnet30")),
@@ -412,6 +599,66 @@ func synthSGTestsList() []testCase {
 			},
 			expectedWarning: utils.Ptr(fmt.Sprint(synth.WarningUnspecifiedSG, "test-vpc/appdata-endpoint-gateway")),
 		},
+		{
+			testName: "sg_testing3_tfstate_tf",
+			args: &command{
+				cmd:        synthesis,
+				subcmd:     sg,
+				config:     tfstateSGTesting3Config,
+				spec:       sgTesting3Spec,
+				outputFile: "%s/sg_testing3_tfstate_tf/sg_expected.tf",
+			},
+			expectedWarning: utils.Ptr(fmt.Sprint(synth.WarningUnspecifiedSG, "test-vpc/appdata-endpoint-gateway")),
+		},
+		{
+			testName: "sg_testing3_attach_tf",
+			args: &command{
+				cmd:        synthesis,
+				subcmd:     sg,
+				attach:     true,
+				config:     sgTesting3Config,
+				spec:       sgTesting3Spec,
+				outputFile: "%s/sg_testing3_attach_tf/sg_expected.tf",
+			},
+			expectedWarning: utils.Ptr(fmt.Sprint(synth.WarningUnspecifiedSG, "test-vpc/appdata-endpoint-gateway")),
+		},
+
+		// sg testing 3 split by the SG rules quota (json, tf)
+		{
+			testName: "sg_testing3_split_json",
+			args: &command{
+				cmd:        synthesis,
+				subcmd:     sg,
+				config:     sgTesting3Config,
+				spec:       sgTesting3Spec,
+				outputFile: "%s/sg_testing3_split_json/sg_expected.json",
+				maxSGRules: 2,
+			},
+		},
+		{
+			testName: "sg_testing3_split_tf",
+			args: &command{
+				cmd:        synthesis,
+				subcmd:     sg,
+				config:     sgTesting3Config,
+				spec:       sgTesting3Spec,
+				outputFile: "%s/sg_testing3_split_tf/sg_expected.tf",
+				maxSGRules: 2,
+			},
+		},
+
+		// sg synth optimize (tf)
+		{
+			testName: "sg_synth_optimize_tf",
+			args: &command{
+				cmd:        synthesis,
+				subcmd:     sg,
+				optimize:   true,
+				config:     sgTesting3Config,
+				spec:       sgSynthOptimizeSpec,
+				outputFile: "%s/sg_synth_optimize_tf/sg_expected.tf",
+			},
+		},
 
 		// sg tg multiple (tf separate)
 		{
@@ -429,11 +676,278 @@ func synthSGTestsList() []testCase {
 				"test-vpc0/vsi0-subnet5, test-vpc0/vsi1-subnet0, test-vpc0/vsi1-subnet1, test-vpc0/vsi1-subnet2,",
 				" test-vpc0/vsi1-subnet3, test-vpc0/vsi1-subnet5, test-vpc2/vsi1-subnet20, test-vpc3/vsi0-subnet30")),
 		},
+
+		// sg public path    ## tg_multiple config
+		{
+			testName: "sg_public_path_tf",
+			args: &command{
+				cmd:        synthesis,
+				subcmd:     sg,
+				config:     tgMultipleConfig,
+				spec:       sgPublicPathSpec,
+				outputFile: "%s/sg_public_path_tf/sg_expected.tf",
+			},
+			expectedWarning: utils.Ptr(fmt.Sprint(synth.WarningNoPublicPath,
+				"required-connections[2]: (instance test-vpc1/vsi0-subnet11)->(external public internet), ",
+				"required-connections[3]: (external public internet)->(instance test-vpc2/vsi0-subnet20)\n", synth.WarningUnspecifiedSG,
+				"test-vpc0/vsi0-subnet1, test-vpc0/vsi0-subnet2, test-vpc0/vsi0-subnet3, test-vpc0/vsi0-subnet4, test-vpc0/vsi0-subnet5, ",
+				"test-vpc0/vsi1-subnet0, test-vpc0/vsi1-subnet1, test-vpc0/vsi1-subnet2, test-vpc0/vsi1-subnet3, test-vpc0/vsi1-subnet4, ",
+				"test-vpc0/vsi1-subnet5, test-vpc2/vsi1-subnet20, test-vpc2/vsi2-subnet20, test-vpc3/vsi0-subnet30")),
+		},
+
+		// sg load balancer    ## load_balancer config (json, tf, tfstate)
+		{
+			testName: "sg_load_balancer_json",
+			args: &command{
+				cmd:        synthesis,
+				subcmd:     sg,
+				config:     loadBalancerConfig,
+				spec:       loadBalancerSpec,
+				outputFile: "%s/sg_load_balancer_json/sg_expected.json",
+			},
+			expectedWarning: utils.Ptr(fmt.Sprint(synth.WarningUnspecifiedSG,
+				"test-vpc/be, test-vpc/opa, test-vpc/appdata-endpoint-gateway, test-vpc/policydb-endpoint-gateway")),
+		},
+		{
+			testName: "sg_load_balancer_tf",
+			args: &command{
+				cmd:        synthesis,
+				subcmd:     sg,
+				config:     loadBalancerConfig,
+				spec:       loadBalancerSpec,
+				outputFile: "%s/sg_load_balancer_tf/sg_expected.tf",
+			},
+			expectedWarning: utils.Ptr(fmt.Sprint(synth.WarningUnspecifiedSG,
+				"test-vpc/be, test-vpc/opa, test-vpc/appdata-endpoint-gateway, test-vpc/policydb-endpoint-gateway")),
+		},
+		{
+			testName: "sg_load_balancer_tfstate_tf",
+			args: &command{
+				cmd:        synthesis,
+				subcmd:     sg,
+				config:     tfstateLoadBalancerConfig,
+				spec:       loadBalancerSpec,
+				outputFile: "%s/sg_load_balancer_tfstate_tf/sg_expected.tf",
+			},
+			expectedWarning: utils.Ptr(fmt.Sprint(synth.WarningUnspecifiedSG,
+				"test-vpc/be, test-vpc/opa, test-vpc/appdata-endpoint-gateway, test-vpc/policydb-endpoint-gateway")),
+		},
+		{
+			testName: "sg_virtual_nif_json",
+			args: &command{
+				cmd:        synthesis,
+				subcmd:     sg,
+				config:     virtualNifConfig,
+				spec:       virtualNifSpec,
+				outputFile: "%s/sg_virtual_nif_json/sg_expected.json",
+			},
+			expectedWarning: utils.Ptr(fmt.Sprint(synth.WarningUnspecifiedSG, "test-vpc/appdata-endpoint-gateway")),
+		},
+		{
+			testName: "sg_virtual_nif_tf",
+			args: &command{
+				cmd:        synthesis,
+				subcmd:     sg,
+				config:     virtualNifConfig,
+				spec:       virtualNifSpec,
+				outputFile: "%s/sg_virtual_nif_tf/sg_expected.tf",
+			},
+			expectedWarning: utils.Ptr(fmt.Sprint(synth.WarningUnspecifiedSG, "test-vpc/appdata-endpoint-gateway")),
+		},
+		{
+			testName: "sg_virtual_nif_attach_tf",
+			args: &command{
+				cmd:        synthesis,
+				subcmd:     sg,
+				attach:     true,
+				config:     virtualNifConfig,
+				spec:       virtualNifSpec,
+				outputFile: "%s/sg_virtual_nif_attach_tf/sg_expected.tf",
+			},
+			expectedWarning: utils.Ptr(fmt.Sprint(synth.WarningUnspecifiedSG, "test-vpc/appdata-endpoint-gateway")),
+		},
+		{
+			testName: "sg_bare_metal_tfstate_tf",
+			args: &command{
+				cmd:        synthesis,
+				subcmd:     sg,
+				config:     tfstateBareMetalConfig,
+				spec:       virtualNifSpec,
+				outputFile: "%s/sg_bare_metal_tfstate_tf/sg_expected.tf",
+			},
+			expectedWarning: utils.Ptr(fmt.Sprint(synth.WarningUnspecifiedSG, "test-vpc/appdata-endpoint-gateway")),
+		},
+		{
+			testName: "sg_secondary_ips_tf",
+			args: &command{
+				cmd:        synthesis,
+				subcmd:     sg,
+				config:     secondaryIPsConfig,
+				spec:       secondaryIPsSpec,
+				outputFile: "%s/sg_secondary_ips_tf/sg_expected.tf",
+			},
+			expectedWarning: utils.Ptr(fmt.Sprint(synth.WarningUnspecifiedSG,
+				"test-vpc/proxy, test-vpc/appdata-endpoint-gateway, test-vpc/policydb-endpoint-gateway")),
+		},
+		{
+			testName: "sg_secondary_ips_tfstate_tf",
+			args: &command{
+				cmd:        synthesis,
+				subcmd:     sg,
+				config:     tfstateSecondaryIPsConfig,
+				spec:       secondaryIPsSpec,
+				outputFile: "%s/sg_secondary_ips_tfstate_tf/sg_expected.tf",
+			},
+			expectedWarning: utils.Ptr(fmt.Sprint(synth.WarningUnspecifiedSG,
+				"test-vpc/proxy, test-vpc/appdata-endpoint-gateway, test-vpc/policydb-endpoint-gateway")),
+		},
+		{
+			testName: "sg_multi_nif_json",
+			args: &command{
+				cmd:        synthesis,
+				subcmd:     sg,
+				config:     multiNifConfig,
+				spec:       multiNifSpec,
+				outputFile: "%s/sg_multi_nif_json/sg_expected.json",
+			},
+			expectedWarning: utils.Ptr(fmt.Sprint(synth.WarningUnspecifiedSG,
+				"test-vpc/proxy, test-vpc/appdata-endpoint-gateway, test-vpc/policydb-endpoint-gateway")),
+		},
+		{
+			testName: "sg_multi_nif_tf",
+			args: &command{
+				cmd:        synthesis,
+				subcmd:     sg,
+				config:     multiNifConfig,
+				spec:       multiNifSpec,
+				outputFile: "%s/sg_multi_nif_tf/sg_expected.tf",
+			},
+			expectedWarning: utils.Ptr(fmt.Sprint(synth.WarningUnspecifiedSG,
+				"test-vpc/proxy, test-vpc/appdata-endpoint-gateway, test-vpc/policydb-endpoint-gateway")),
+		},
+		{
+			testName: "sg_multi_nif_diagnostics_tf",
+			args: &command{
+				cmd:               synthesis,
+				subcmd:            sg,
+				config:            multiNifConfig,
+				spec:              multiNifSpec,
+				outputFile:        "%s/sg_multi_nif_diagnostics_tf/sg_expected.tf",
+				diagnosticsFormat: "json",
+			},
+			expectedWarning: utils.Ptr(fmt.Sprintf(`[
+  {
+    "severity": "warning",
+    "code": "unspecified-sg",
+    "message": "%stest-vpc/proxy, test-vpc/appdata-endpoint-gateway, test-vpc/policydb-endpoint-gateway",
+    "resources": [
+      "test-vpc/proxy",
+      "test-vpc/appdata-endpoint-gateway",
+      "test-vpc/policydb-endpoint-gateway"
+    ]
+  }
+]`, synth.WarningUnspecifiedSG)),
+		},
+		// sg ipv6 (dual-stack nifs, ipv6 externals)    ## ipv6 config
+		{
+			testName: "sg_ipv6_json",
+			args: &command{
+				cmd:        synthesis,
+				subcmd:     sg,
+				config:     ipv6Config,
+				spec:       sgIPv6Spec,
+				outputFile: "%s/sg_ipv6_json/sg_expected.json",
+			},
+		},
+		{
+			testName: "sg_ipv6_tf",
+			args: &command{
+				cmd:        synthesis,
+				subcmd:     sg,
+				config:     ipv6Config,
+				spec:       sgIPv6Spec,
+				outputFile: "%s/sg_ipv6_tf/sg_expected.tf",
+			},
+		},
 	}
 }
 
 // Note1: spec files in data folder are used to create the config object files (acl_testing4 config)
 // Note2: each data folder has a details.txt file with the test explanation
+func synthAllTestsList() []testCase {
+	return []testCase{
+		// the forbidden connections are denied by the nACLs only, since SGs cannot deny traffic
+		{
+			testName: "all_acl_forbidden_tf",
+			args: &command{
+				cmd:        synthesis,
+				subcmd:     all,
+				config:     aclTesting5Config,
+				spec:       aclForbiddenSpec,
+				outputFile: "%s/all_acl_forbidden_tf/expected.tf",
+			},
+			expectedWarning: utils.Ptr(fmt.Sprint(synth.WarningUnspecifiedACL,
+				"testacl5-vpc/sub1-3, testacl5-vpc/sub2-1, testacl5-vpc/sub2-2, testacl5-vpc/sub3-1")),
+		},
+		{
+			testName: "all_sg_testing3_tf",
+			args: &command{
+				cmd:        synthesis,
+				subcmd:     all,
+				config:     sgTesting3Config,
+				spec:       sgTesting3Spec,
+				outputFile: "%s/all_sg_testing3_tf/expected.tf",
+			},
+			expectedWarning: utils.Ptr(fmt.Sprint(synth.WarningUnspecifiedSG, "test-vpc/appdata-endpoint-gateway")),
+		},
+		{
+			testName: "all_sg_testing3_attach_tf",
+			args: &command{
+				cmd:        synthesis,
+				subcmd:     all,
+				attach:     true,
+				config:     sgTesting3Config,
+				spec:       sgTesting3Spec,
+				outputFile: "%s/all_sg_testing3_attach_tf/expected.tf",
+			},
+			expectedWarning: utils.Ptr(fmt.Sprint(synth.WarningUnspecifiedSG, "test-vpc/appdata-endpoint-gateway")),
+		},
+		{
+			testName: "all_sg_testing3_md",
+			args: &command{
+				cmd:        synthesis,
+				subcmd:     all,
+				config:     sgTesting3Config,
+				spec:       sgTesting3Spec,
+				outputFile: "%s/all_sg_testing3_md/expected.md",
+			},
+		},
+		{
+			testName: "all_sg_protocols_coarse_tf",
+			args: &command{
+				cmd:         synthesis,
+				subcmd:      all,
+				aclStrategy: "coarse",
+				config:      tgMultipleConfig,
+				spec:        sgProtocolsSpec,
+				outputFile:  "%s/all_sg_protocols_coarse_tf/expected.tf",
+			},
+		},
+		{
+			testName: "all_sg_segments1_single_optimize_tf",
+			args: &command{
+				cmd:        synthesis,
+				subcmd:     all,
+				singleacl:  true,
+				optimize:   true,
+				config:     tgMultipleConfig,
+				spec:       sgSegments1Spec,
+				outputFile: "%s/all_sg_segments1_single_optimize_tf/expected.tf",
+			},
+		},
+	}
+}
+
 func optimizeSGTestsLists() []testCase {
 	return []testCase{
 		// optimize_sg_protocols_to_all tests also test SG rules with local values different from 0.0.0.0/0
@@ -446,6 +960,36 @@ func optimizeSGTestsLists() []testCase {
 				outputFile: "%s/optimize_sg_protocols_to_all_tf/sg_expected.tf",
 			},
 		},
+		{
+			testName: "optimize_sg_protocols_to_all_attach_tf",
+			args: &command{
+				cmd:        optimize,
+				subcmd:     sg,
+				attach:     true,
+				config:     optimizeSGProtocolsToAllConfig,
+				outputFile: "%s/optimize_sg_protocols_to_all_attach_tf/sg_expected.tf",
+			},
+		},
+		{
+			testName: "optimize_sg_protocols_to_all_in_place_tf",
+			args: &command{
+				cmd:        optimize,
+				subcmd:     sg,
+				inPlace:    true,
+				config:     optimizeSGProtocolsToAllConfig,
+				outputFile: "%s/optimize_sg_protocols_to_all_in_place_tf/sg_expected.tf",
+			},
+		},
+		{
+			testName: "optimize_sg_testing3_tfstate_in_place_tf",
+			args: &command{
+				cmd:        optimize,
+				subcmd:     sg,
+				inPlace:    true,
+				config:     tfstateSGTesting3Config,
+				outputFile: "%s/optimize_sg_testing3_tfstate_in_place_tf/sg_expected.tf",
+			},
+		},
 		{
 			testName: "optimize_sg_protocols_to_all_csv",
 			args: &command{
@@ -512,11 +1056,40 @@ func optimizeSGTestsLists() []testCase {
 				firewallName: vsi1,
 			},
 		},
+		{
+			testName: "optimize_sg_ipv6_tf",
+			args: &command{
+				cmd:        optimize,
+				subcmd:     sg,
+				config:     ipv6Config,
+				outputFile: "%s/optimize_sg_ipv6_tf/sg_expected.tf",
+			},
+		},
 	}
 }
 
 func optimizeACLTestsLists() []testCase {
 	return []testCase{
+		{
+			testName: "optimize_acl_in_place_tf",
+			args: &command{
+				cmd:        optimize,
+				subcmd:     acl,
+				inPlace:    true,
+				config:     optimizeACLConfig,
+				outputFile: "%s/optimize_acl_in_place_tf/nacl_expected.tf",
+			},
+		},
+		{
+			testName: "optimize_acl_testing5_tfstate_in_place_tf",
+			args: &command{
+				cmd:        optimize,
+				subcmd:     acl,
+				inPlace:    true,
+				config:     tfstateACLTesting5Config,
+				outputFile: "%s/optimize_acl_testing5_tfstate_in_place_tf/nacl_expected.tf",
+			},
+		},
 		{
 			testName: "optimize_acl_csv",
 			args: &command{
@@ -554,6 +1127,15 @@ func optimizeACLTestsLists() []testCase {
 				outputFile: "%s/optimize_acl_json/nacl_expected.json",
 			},
 		},
+		{
+			testName: "optimize_acl_ipv6_tf",
+			args: &command{
+				cmd:        optimize,
+				subcmd:     acl,
+				config:     ipv6Config,
+				outputFile: "%s/optimize_acl_ipv6_tf/nacl_expected.tf",
+			},
+		},
 		{
 			testName: "optimize_acl_anyProtocol_tf",
 			args: &command{
@@ -612,3 +1194,191 @@ func optimizeACLTestsLists() []testCase {
 		// },
 	}
 }
+
+func verifyTestsLists() []testCase {
+	return []testCase{
+		{
+			testName: "verify_acl_testing5",
+			args: &command{
+				cmd:        verify,
+				subcmd:     acl,
+				config:     aclTesting5Config,
+				spec:       aclTesting5Spec,
+				outputFile: "%s/verify_acl_testing5/report.txt",
+			},
+			expectedWarning: utils.Ptr("12 required connections are blocked; 3 resources allow connections that are not required"),
+		},
+		{
+			testName: "verify_acl_testing5_tfstate",
+			args: &command{
+				cmd:        verify,
+				subcmd:     acl,
+				config:     tfstateACLTesting5Config,
+				spec:       aclTesting5Spec,
+				outputFile: "%s/verify_acl_testing5_tfstate/report.txt",
+			},
+			expectedWarning: utils.Ptr("12 required connections are blocked; 3 resources allow connections that are not required"),
+		},
+		{
+			testName: "verify_acl_forbidden",
+			args: &command{
+				cmd:        verify,
+				subcmd:     acl,
+				config:     aclTesting5Config,
+				spec:       aclForbiddenSpec,
+				outputFile: "%s/verify_acl_forbidden/report.txt",
+			},
+		},
+		{
+			testName: "verify_sg_testing3",
+			args: &command{
+				cmd:        verify,
+				subcmd:     sg,
+				config:     sgTesting3Config,
+				spec:       sgTesting3Spec,
+				outputFile: "%s/verify_sg_testing3/report.txt",
+			},
+			expectedWarning: utils.Ptr("8 required connections are blocked; 5 resources allow connections that are not required"),
+		},
+	}
+}
+
+func diffTestsLists() []testCase {
+	return []testCase{
+		{
+			testName: "diff_acl_optimize_acl2_acl3",
+			args: &command{
+				cmd:         diff,
+				subcmd:      acl,
+				config:      optimizeACL2Config,
+				otherConfig: optimizeACL3Config,
+				outputFile:  "%s/diff_acl_optimize_acl2_acl3/diff.txt",
+			},
+			expectedWarning: utils.Ptr("1 of 1 compared subnets allow different connectivity"),
+		},
+		{
+			testName: "diff_sg_optimize_sg_t_all",
+			args: &command{
+				cmd:         diff,
+				subcmd:      sg,
+				config:      "%s/optimize_sg_t/config_object.json",
+				otherConfig: "%s/optimize_sg_t_all/config_object.json",
+				outputFile:  "%s/diff_sg_optimize_sg_t_all/diff.txt",
+			},
+			expectedWarning: utils.Ptr("1 of 4 compared SG targets allow different connectivity"),
+		},
+		{
+			testName: "diff_acl_testing5_synth",
+			args: &command{
+				cmd:         diff,
+				subcmd:      acl,
+				config:      aclTesting5Config,
+				otherConfig: "%s/../expected/acl_testing5_json/nacl_expected.json",
+				outputFile:  "%s/diff_acl_testing5_synth/diff.txt",
+			},
+			expectedWarning: utils.Ptr("3 of 6 compared subnets allow different connectivity"),
+		},
+		{
+			testName: "diff_sg_testing3_synth",
+			args: &command{
+				cmd:         diff,
+				subcmd:      sg,
+				config:      sgTesting3Config,
+				otherConfig: "%s/../expected/sg_testing3_json/sg_expected.json",
+				outputFile:  "%s/diff_sg_testing3_synth/diff.txt",
+			},
+			expectedWarning: utils.Ptr("6 of 6 compared SG targets allow different connectivity"),
+		},
+	}
+}
+
+func queryTestsLists() []testCase {
+	return []testCase{
+		{
+			testName: "query_acl_testing5",
+			args: &command{
+				cmd:        query,
+				subcmd:     acl,
+				config:     aclTesting5Config,
+				src:        "10.240.1.5",
+				dst:        "10.240.2.9",
+				protocol:   "tcp",
+				dstPort:    80,
+				outputFile: "%s/query_acl_testing5/result.txt",
+			},
+			expectedWarning: utils.Ptr("src: 10.240.1.5, dst: 10.240.2.9, conns: TCP dst-ports: 80 is allowed"),
+		},
+		{
+			testName: "query_sg_testing3",
+			args: &command{
+				cmd:        query,
+				subcmd:     sg,
+				config:     sgTesting3Config,
+				src:        "10.240.128.4",
+				dst:        "10.240.128.5",
+				protocol:   "tcp",
+				outputFile: "%s/query_sg_testing3/result.txt",
+			},
+			expectedWarning: utils.Ptr("src: 10.240.128.4, dst: 10.240.128.5, conns: TCP is partially allowed: TCP dst-ports: 8181"),
+		},
+	}
+}
+
+func explainTestsLists() []testCase {
+	return []testCase{
+		{
+			testName: "explain_acl_testing5",
+			args: &command{
+				cmd:        explain,
+				subcmd:     acl,
+				config:     aclTesting5Config,
+				spec:       aclTesting5Spec,
+				outputFile: "%s/explain_acl_testing5/explanation.json",
+			},
+		},
+		{
+			testName: "explain_acl_protocols_responses",
+			args: &command{
+				cmd:          explain,
+				subcmd:       acl,
+				tcpResponses: "ephemeral",
+				udpResponses: "exact",
+				config:       tgMultipleConfig,
+				spec:         aclProtocolsSpec,
+				outputFile:   "%s/explain_acl_protocols_responses/explanation.json",
+			},
+		},
+		{
+			testName: "explain_acl_testing5_optimize",
+			args: &command{
+				cmd:        explain,
+				subcmd:     acl,
+				optimize:   true,
+				config:     aclTesting5Config,
+				spec:       aclTesting5Spec,
+				outputFile: "%s/explain_acl_testing5_optimize/explanation.json",
+			},
+		},
+		{
+			testName: "explain_sg_synth_optimize",
+			args: &command{
+				cmd:        explain,
+				subcmd:     sg,
+				optimize:   true,
+				config:     sgTesting3Config,
+				spec:       sgSynthOptimizeSpec,
+				outputFile: "%s/explain_sg_synth_optimize/explanation.json",
+			},
+		},
+		{
+			testName: "explain_sg_testing3_html",
+			args: &command{
+				cmd:        explain,
+				subcmd:     sg,
+				config:     sgTesting3Config,
+				spec:       sgTesting3Spec,
+				outputFile: "%s/explain_sg_testing3_html/explanation.html",
+			},
+		},
+	}
+}
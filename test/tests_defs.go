@@ -5,7 +5,10 @@ SPDX-License-Identifier: Apache-2.0
 
 package test
 
-import "fmt"
+import (
+	"fmt"
+	"strconv"
+)
 
 type testCase struct {
 	testName        string
@@ -15,17 +18,38 @@ type testCase struct {
 }
 
 type command struct {
-	cmd          string
-	subcmd       string
-	singleacl    bool
-	config       string
-	spec         string
-	outputFile   string
-	outputDir    string
-	prefix       string
-	format       string
-	locals       bool
-	firewallName string
+	cmd           string
+	subcmd        string
+	singleacl     bool
+	aclStrategy   string
+	tcpResponses  string
+	udpResponses  string
+	internalCidrs string
+	optimize      bool
+	config        string
+	otherConfig   string
+	spec          string
+	specFormat    string
+	outputFile    string
+	outputDir     string
+	prefix        string
+	format        string
+	locals        bool
+	attach        bool
+	inPlace       bool
+	firewallName  string
+
+	diagnosticsFormat string
+
+	// query flow
+	src      string
+	dst      string
+	protocol string
+	dstPort  int
+
+	// synthesis quotas
+	maxACLRules int
+	maxSGRules  int
 }
 
 const (
@@ -38,8 +62,13 @@ const (
 
 	synthesis string = "synth"
 	optimize  string = "optimize"
+	verify    string = "verify"
+	diff      string = "diff"
+	query     string = "query"
+	explain   string = "explain"
 	acl       string = "acl"
 	sg        string = "sg"
+	all       string = "all"
 )
 
 func (c *command) Args(dataFolder, resultsFolder string) []string {
@@ -54,12 +83,33 @@ func (c *command) Args(dataFolder, resultsFolder string) []string {
 	if c.singleacl {
 		res = append(res, "--single")
 	}
+	if c.aclStrategy != "" {
+		res = append(res, "--acl-strategy", c.aclStrategy)
+	}
+	if c.tcpResponses != "" {
+		res = append(res, "--tcp-responses", c.tcpResponses)
+	}
+	if c.udpResponses != "" {
+		res = append(res, "--udp-responses", c.udpResponses)
+	}
+	if c.internalCidrs != "" {
+		res = append(res, "--internal-cidrs", c.internalCidrs)
+	}
+	if c.optimize {
+		res = append(res, "--optimize")
+	}
 	if c.config != "" {
 		res = append(res, "-c", fmt.Sprintf(c.config, dataFolder))
 	}
+	if c.otherConfig != "" {
+		res = append(res, "--other-config", fmt.Sprintf(c.otherConfig, dataFolder))
+	}
 	if c.spec != "" {
 		res = append(res, "-s", fmt.Sprintf(c.spec, dataFolder))
 	}
+	if c.specFormat != "" {
+		res = append(res, "--spec-format", c.specFormat)
+	}
 	if c.outputFile != "" {
 		res = append(res, "-o", fmt.Sprintf(c.outputFile, resultsFolder))
 	}
@@ -75,9 +125,36 @@ func (c *command) Args(dataFolder, resultsFolder string) []string {
 	if c.locals {
 		res = append(res, "-l")
 	}
+	if c.attach {
+		res = append(res, "--attach")
+	}
+	if c.inPlace {
+		res = append(res, "--in-place")
+	}
 	if c.firewallName != "" {
 		res = append(res, "-n", c.firewallName)
 	}
+	if c.src != "" {
+		res = append(res, "--src", c.src)
+	}
+	if c.dst != "" {
+		res = append(res, "--dst", c.dst)
+	}
+	if c.protocol != "" {
+		res = append(res, "--protocol", c.protocol)
+	}
+	if c.dstPort != 0 {
+		res = append(res, "--dst-port", strconv.Itoa(c.dstPort))
+	}
+	if c.maxACLRules != 0 {
+		res = append(res, "--max-acl-rules", strconv.Itoa(c.maxACLRules))
+	}
+	if c.maxSGRules != 0 {
+		res = append(res, "--max-sg-rules", strconv.Itoa(c.maxSGRules))
+	}
+	if c.diagnosticsFormat != "" {
+		res = append(res, "--diagnostics-format", c.diagnosticsFormat)
+	}
 
 	return res
 }